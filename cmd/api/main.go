@@ -11,6 +11,7 @@ import (
 	"saythis-backend/internal/server"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 )
 
 func main() {
@@ -0,0 +1,89 @@
+// Command rebuild-rollups recomputes the activity rollups, tool totals and
+// streaks from raw tool sessions and daily stats. Timezone changes rebuild
+// the user's rollups on their own; run this after the tool groups change,
+// or whenever the rollups look off:
+//
+//	rebuild-rollups                # every user
+//	rebuild-rollups -user <uuid>   # a single user
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"saythis-backend/internal/config"
+	"saythis-backend/internal/database"
+	statsrepo "saythis-backend/internal/src/stats/repository"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func main() {
+	userFlag := flag.String("user", "", "rebuild only this user's rollups")
+	flag.Parse()
+
+	var userID *uuid.UUID
+	if *userFlag != "" {
+		id, err := uuid.Parse(*userFlag)
+		if err != nil {
+			slog.Error("❌ Invalid user ID", "user", *userFlag, "error", err)
+			os.Exit(2)
+		}
+		userID = &id
+	}
+
+	// ********************
+	// Env intilization
+	// ********************
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("❌ Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	_, err = config.InitLogger(config.LoggerConfig{
+		Env:     cfg.AppEnv,
+		Service: "saythis-rebuild-rollups",
+		Level:   slog.LevelInfo,
+		Output:  os.Stdout,
+	})
+	if err != nil {
+		slog.Error("❌ Failed to initialise logger", "error", err)
+		os.Exit(1)
+	}
+
+	// *******************
+	// Database intilization
+	// *******************
+
+	pool, err := database.ConnectWithRetry(cfg.DatabaseURL)
+	if err != nil {
+		slog.Error("❌ Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	// *******************
+	// Rebuild
+	// *******************
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	started := time.Now()
+	users, err := statsrepo.NewPostgresStatsRepo(pool).RebuildActivityRollups(ctx, userID)
+	if err != nil {
+		slog.Error("❌ Failed to rebuild rollups", "rebuilt_users", users, "error", err)
+		pool.Close()
+		os.Exit(1)
+	}
+	if userID != nil && users == 0 {
+		slog.Warn("⚠️  No such user, nothing rebuilt", "user", *userID)
+		return
+	}
+
+	slog.Info("✅ Rollups rebuilt", "users", users, "took", time.Since(started).Round(time.Millisecond))
+}
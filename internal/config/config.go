@@ -3,36 +3,62 @@ package config
 import (
 	"errors"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	DatabaseURL     string
-	Port            string
-	AppEnv          string
-	JWTSecret       string
-	AccessTokenTTL  time.Duration
-	RefreshTokenTTL time.Duration
-	ResendAPIKey    string
-	FrontendURL     string
-	CloudinaryURL   string
+	DatabaseURL   string
+	Port          string
+	AppEnv        string
+	JWTSecret     string
+	JWTKeysDir    string
+	JWTSigningKID string
+
+	// MFAEncryptionKey encrypts TOTP secrets at rest. It must stay stable
+	// for as long as any user has two-factor authentication enrolled.
+	MFAEncryptionKey string
+	AccessTokenTTL   time.Duration
+	RefreshTokenTTL  time.Duration
+	ResendAPIKey     string
+	FrontendURL      string
+	CloudinaryURL    string
+
+	// RecordingsDir is where the local blob store keeps uploaded audio.
+	RecordingsDir   string
+	RecordingURLTTL time.Duration
+
+	// TranscriptFillers overrides the transcript analyzer's filler
+	// lexicon. Set TRANSCRIPT_FILLERS to a comma-separated list.
+	TranscriptFillers []string
 }
 
 func LoadConfig() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		DatabaseURL:     os.Getenv("DATABASE_URL"),
-		Port:            os.Getenv("PORT"),
-		AppEnv:          os.Getenv("APP_ENV"),
-		JWTSecret:       os.Getenv("JWT_SECRET"),
-		AccessTokenTTL:  15 * time.Minute,
-		RefreshTokenTTL: 7 * 24 * time.Hour,
-		ResendAPIKey:    os.Getenv("RESEND_API_KEY"),
-		FrontendURL:     os.Getenv("FRONTEND_URL"),
-		CloudinaryURL:   os.Getenv("CLOUDINARY_URL"),
+		DatabaseURL:      os.Getenv("DATABASE_URL"),
+		Port:             os.Getenv("PORT"),
+		AppEnv:           os.Getenv("APP_ENV"),
+		JWTSecret:        os.Getenv("JWT_SECRET"),
+		JWTKeysDir:       os.Getenv("JWT_KEYS_DIR"),
+		JWTSigningKID:    os.Getenv("JWT_SIGNING_KID"),
+		MFAEncryptionKey: os.Getenv("MFA_ENCRYPTION_KEY"),
+		AccessTokenTTL:   15 * time.Minute,
+		RefreshTokenTTL:  7 * 24 * time.Hour,
+		ResendAPIKey:     os.Getenv("RESEND_API_KEY"),
+		FrontendURL:      os.Getenv("FRONTEND_URL"),
+		CloudinaryURL:    os.Getenv("CLOUDINARY_URL"),
+		RecordingsDir:    os.Getenv("RECORDINGS_DIR"),
+		RecordingURLTTL:  15 * time.Minute,
+	}
+
+	for _, filler := range strings.Split(os.Getenv("TRANSCRIPT_FILLERS"), ",") {
+		if filler = strings.TrimSpace(filler); filler != "" {
+			cfg.TranscriptFillers = append(cfg.TranscriptFillers, filler)
+		}
 	}
 
 	if cfg.DatabaseURL == "" {
@@ -54,6 +80,18 @@ func LoadConfig() (*Config, error) {
 	if cfg.AppEnv == "" {
 		cfg.AppEnv = "development"
 	}
+	if cfg.JWTKeysDir == "" && cfg.AppEnv == "production" {
+		return nil, errors.New("JWT_KEYS_DIR environment variable is required in production")
+	}
+	if cfg.MFAEncryptionKey == "" {
+		if cfg.AppEnv == "production" {
+			return nil, errors.New("MFA_ENCRYPTION_KEY environment variable is required in production")
+		}
+		cfg.MFAEncryptionKey = cfg.JWTSecret
+	}
+	if cfg.RecordingsDir == "" {
+		cfg.RecordingsDir = "data/recordings"
+	}
 	if cfg.FrontendURL == "" {
 		cfg.FrontendURL = "http://localhost:5173"
 	}
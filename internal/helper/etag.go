@@ -0,0 +1,45 @@
+package helper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ETag derives a strong entity tag from a version key. Keys are hashed so
+// they can hold internal details without exposing them.
+func ETag(version string) string {
+	sum := sha256.Sum256([]byte(version))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// SetETag marks a per-user response as cacheable by the client only, and
+// to be revalidated with the given tag before reuse.
+func SetETag(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, no-cache")
+}
+
+// NotModified answers 304 when the request's If-None-Match names etag,
+// and reports whether it did.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	SetETag(w, etag)
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches uses the weak comparison If-None-Match calls for, so a tag
+// sent back with a W/ prefix by a proxy still matches.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package helper_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"saythis-backend/internal/helper"
+)
+
+func TestETag_IsStrongAndStable(t *testing.T) {
+	etag := helper.ETag("stats|UTC|2026-03-01")
+	if etag != helper.ETag("stats|UTC|2026-03-01") {
+		t.Error("same version gave different tags")
+	}
+	if etag == helper.ETag("stats|UTC|2026-03-02") {
+		t.Error("different versions gave the same tag")
+	}
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		t.Errorf("want a quoted strong tag, got %s", etag)
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	etag := helper.ETag("v1")
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"no header", "", false},
+		{"same tag", etag, true},
+		{"other tag", helper.ETag("v2"), false},
+		{"in a list", helper.ETag("v2") + ", " + etag, true},
+		{"weak form", "W/" + etag, true},
+		{"wildcard", "*", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/stats", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+
+			if got := helper.NotModified(rec, req, etag); got != tt.want {
+				t.Fatalf("NotModified = %v, want %v", got, tt.want)
+			}
+			if !tt.want {
+				if rec.Header().Get("ETag") != "" {
+					t.Error("headers set on a request that was not answered")
+				}
+				return
+			}
+			if rec.Code != http.StatusNotModified {
+				t.Errorf("want 304, got %d", rec.Code)
+			}
+			if rec.Body.Len() != 0 {
+				t.Errorf("want empty body, got %q", rec.Body.String())
+			}
+			if got := rec.Header().Get("ETag"); got != etag {
+				t.Errorf("want ETag %s, got %s", etag, got)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != "private, no-cache" {
+				t.Errorf("want Cache-Control=private, no-cache, got %q", got)
+			}
+		})
+	}
+}
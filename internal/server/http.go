@@ -1,6 +1,7 @@
 package server
 
 import (
+	"log/slog"
 	"net/http"
 	"time"
 
@@ -9,13 +10,28 @@ import (
 	"saythis-backend/internal/config"
 	"saythis-backend/internal/health"
 	"saythis-backend/internal/middleware"
+	adminhandler "saythis-backend/internal/src/admin/handler"
+	adminrepo "saythis-backend/internal/src/admin/repository"
+	adminusecase "saythis-backend/internal/src/admin/usecase"
+	auditrepo "saythis-backend/internal/src/audit/repository"
 	"saythis-backend/internal/src/auth"
 	authhandler "saythis-backend/internal/src/auth/handler"
 	authrepo "saythis-backend/internal/src/auth/repository"
 	authusecase "saythis-backend/internal/src/auth/usecase"
+	planhandler "saythis-backend/internal/src/plan/handler"
+	planrepo "saythis-backend/internal/src/plan/repository"
+	planusecase "saythis-backend/internal/src/plan/usecase"
+	recordinghandler "saythis-backend/internal/src/recording/handler"
+	recordingrepo "saythis-backend/internal/src/recording/repository"
+	recordingusecase "saythis-backend/internal/src/recording/usecase"
+	statsdomain "saythis-backend/internal/src/stats/domain"
 	statshandler "saythis-backend/internal/src/stats/handler"
 	statsrepo "saythis-backend/internal/src/stats/repository"
 	statsusecase "saythis-backend/internal/src/stats/usecase"
+	therapisthandler "saythis-backend/internal/src/therapist/handler"
+	therapistrepo "saythis-backend/internal/src/therapist/repository"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+	therapycatalog "saythis-backend/internal/src/therapy/catalog"
 	therapyhandler "saythis-backend/internal/src/therapy/handler"
 	therapyrepo "saythis-backend/internal/src/therapy/repository"
 	therapyusecase "saythis-backend/internal/src/therapy/usecase"
@@ -33,8 +49,9 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 	// Shared infra
 	// *******************
 
-	jwtCfg := auth.NewJWTConfig(cfg)
-	bearerAuth := auth.BearerAuth(jwtCfg)
+	jwtKeys := mustLoadJWTKeys(cfg)
+	jwtCfg := auth.NewJWTConfig(cfg, jwtKeys)
+	curriculum := therapycatalog.MustLoad()
 
 	// *******************
 	// Repositories
@@ -42,13 +59,26 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 
 	userRepo := userrepo.NewPostgresUserRepo(db)
 	authRepo := authrepo.NewPostgresAuthRepo(db)
+	auditRepo := auditrepo.NewPostgresAuditRepo(db)
+	adminRepo := adminrepo.NewPostgresAdminRepo(db)
+	therapistRepo := therapistrepo.NewPostgresTherapistRepo(db)
+	planRepo := planrepo.NewPostgresPlanRepo(db)
+
+	revocations := auth.NewRevocationList(authRepo, 30*time.Second)
+	bearerAuth := auth.BearerAuth(jwtCfg, revocations)
+	authorizer := auth.NewAuthorizer(auditRepo)
+
+	// requirePermission chains BearerAuth in front of the permission check.
+	requirePermission := func(permission auth.Permission, h http.Handler) http.Handler {
+		return bearerAuth(authorizer.RequirePermission(permission)(h))
+	}
 
 	// *******************
 	// Auth
 	// *******************
 
 	emailSender := auth.NewResendClient(cfg.ResendAPIKey, "auth@hasn.me")
-	authUseCase := authusecase.NewAuthUseCase(authRepo, userRepo, jwtCfg, emailSender, cfg.FrontendURL)
+	authUseCase := authusecase.NewAuthUseCase(authRepo, userRepo, jwtCfg, emailSender, revocations, cfg.FrontendURL)
 
 	registerHandler := authhandler.NewRegisterHandler(authUseCase)
 	loginHandler := authhandler.NewLoginHandler(authUseCase)
@@ -57,36 +87,105 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 	forgotPasswordHandler := authhandler.NewForgotPasswordHandler(authUseCase)
 	resetPasswordHandler := authhandler.NewResetPasswordHandler(authUseCase)
 	resendVerificationHandler := authhandler.NewResendVerificationHandler(authUseCase, jwtCfg)
+	logoutHandler := authhandler.NewLogoutHandler(authUseCase)
+	mfaLoginHandler := authhandler.NewMFALoginHandler(authUseCase)
+	enrollMFAHandler := authhandler.NewEnrollMFAHandler(authUseCase)
+	verifyMFAHandler := authhandler.NewVerifyMFAHandler(authUseCase)
+	disableMFAHandler := authhandler.NewDisableMFAHandler(authUseCase)
+	listSessionsHandler := authhandler.NewListSessionsHandler(authUseCase)
+	revokeSessionHandler := authhandler.NewRevokeSessionHandler(authUseCase)
+	revokeOtherSessionsHandler := authhandler.NewRevokeOtherSessionsHandler(authUseCase)
 
 	// *******************
 	// User (protected)
 	// *******************
 
 	cloudinaryUploader := userusecase.MustNewCloudinaryUploader(cfg.CloudinaryURL)
-	userUseCase := userusecase.NewUserUseCase(userRepo, authRepo, cloudinaryUploader)
+	userUseCase := userusecase.NewUserUseCase(userRepo, authRepo, revocations, cloudinaryUploader)
 	getProfileHandler := userhandler.NewGetProfileHandler(userUseCase)
 	deleteAccountHandler := userhandler.NewDeleteAccountHandler(userUseCase)
 	updateProfileHandler := userhandler.NewUpdateProfileHandler(userUseCase)
 	updateAvatarHandler := userhandler.NewUpdateAvatarHandler(userUseCase)
 
+	// *******************
+	// Therapist links
+	// *******************
+
+	therapistUseCase := therapistusecase.NewTherapistUseCase(therapistRepo, userRepo, auditRepo)
+	createLinkCodeHandler := therapisthandler.NewCreateLinkCodeHandler(therapistUseCase)
+	listClientsHandler := therapisthandler.NewListClientsHandler(therapistUseCase)
+	removeClientHandler := therapisthandler.NewRemoveClientHandler(therapistUseCase)
+	linkTherapistHandler := therapisthandler.NewLinkTherapistHandler(therapistUseCase)
+	listTherapistsHandler := therapisthandler.NewListTherapistsHandler(therapistUseCase)
+	updateConsentHandler := therapisthandler.NewUpdateConsentHandler(therapistUseCase)
+	revokeTherapistHandler := therapisthandler.NewRevokeTherapistHandler(therapistUseCase)
+
+	// *******************
+	// Practice plans
+	// *******************
+
+	planUseCase := planusecase.NewPlanUseCase(planRepo, userRepo, therapistUseCase, curriculum)
+	assignPlanHandler := planhandler.NewAssignPlanHandler(planUseCase)
+	getClientPlanHandler := planhandler.NewGetClientPlanHandler(planUseCase)
+	archivePlanHandler := planhandler.NewArchivePlanHandler(planUseCase)
+	getMyPlansHandler := planhandler.NewGetMyPlansHandler(planUseCase)
+
+	// *******************
+	// Recordings
+	// *******************
+
+	recordingRepo := recordingrepo.NewPostgresRecordingRepo(db)
+	recordingBlobs := recordingusecase.MustNewLocalBlobStore(cfg.RecordingsDir)
+	recordingSigner := recordingusecase.NewURLSigner(cfg.JWTSecret, cfg.RecordingURLTTL)
+	recordingUseCase := recordingusecase.NewRecordingUseCase(recordingRepo, recordingBlobs, recordingSigner, therapistUseCase)
+	uploadRecordingHandler := recordinghandler.NewUploadRecordingHandler(recordingUseCase)
+	listRecordingsHandler := recordinghandler.NewListRecordingsHandler(recordingUseCase)
+	getRecordingHandler := recordinghandler.NewGetRecordingHandler(recordingUseCase)
+	deleteRecordingHandler := recordinghandler.NewDeleteRecordingHandler(recordingUseCase)
+	downloadRecordingHandler := recordinghandler.NewDownloadRecordingHandler(recordingUseCase)
+	listClientRecordingsHandler := recordinghandler.NewListClientRecordingsHandler(recordingUseCase)
+
 	// *******************
 	// Therapy progress
 	// *******************
 
 	therapyRepo := therapyrepo.NewPostgresTherapyRepo(db)
-	therapyUseCase := therapyusecase.NewTherapyUseCase(therapyRepo)
+	therapyUseCase := therapyusecase.NewTherapyUseCase(therapyRepo, userRepo, curriculum)
 	completeExerciseHandler := therapyhandler.NewCompleteExerciseHandler(therapyUseCase)
 	getProgressHandler := therapyhandler.NewGetProgressHandler(therapyUseCase)
+	getAttemptsHandler := therapyhandler.NewGetAttemptsHandler(therapyUseCase)
+	getCatalogHandler := therapyhandler.NewGetCatalogHandler(therapyUseCase)
+	getDueReviewsHandler := therapyhandler.NewGetDueReviewsHandler(therapyUseCase)
+	getClientProgressHandler := therapyhandler.NewGetClientProgressHandler(therapyUseCase, therapistUseCase)
 
 	// *******************
 	// Stats
 	// *******************
 
 	statsRepo := statsrepo.NewPostgresStatsRepo(db)
-	statsUseCase := statsusecase.NewStatsUseCase(statsRepo)
+	statsUseCase := statsusecase.NewStatsUseCase(statsRepo, userRepo, statsdomain.NewTranscriptAnalyzer(cfg.TranscriptFillers))
 	updateDailyStatsHandler := statshandler.NewUpdateDailyHandler(statsUseCase)
 	getStatsHandler := statshandler.NewGetStatsHandler(statsUseCase)
 	getDailyStatsHandler := statshandler.NewGetDailyHandler(statsUseCase)
+	logToolSessionsHandler := statshandler.NewLogToolSessionsHandler(statsUseCase)
+	getToolsHandler := statshandler.NewGetToolsHandler(statsUseCase)
+	analyzeTranscriptHandler := statshandler.NewAnalyzeTranscriptHandler(statsUseCase)
+	getClientStatsHandler := statshandler.NewGetClientStatsHandler(statsUseCase, therapistUseCase)
+	getInsightsHandler := statshandler.NewGetInsightsHandler(statsUseCase)
+	getClientInsightsHandler := statshandler.NewGetClientInsightsHandler(statsUseCase, therapistUseCase)
+
+	// *******************
+	// Admin
+	// *******************
+
+	adminUseCase := adminusecase.NewAdminUseCase(adminRepo, authRepo, auditRepo, revocations)
+	listUsersHandler := adminhandler.NewListUsersHandler(adminUseCase)
+	getUserHandler := adminhandler.NewGetUserHandler(adminUseCase)
+	suspendUserHandler := adminhandler.NewSuspendUserHandler(adminUseCase)
+	unsuspendUserHandler := adminhandler.NewUnsuspendUserHandler(adminUseCase)
+	verifyUserEmailHandler := adminhandler.NewVerifyUserEmailHandler(adminUseCase)
+	unlockUserHandler := adminhandler.NewUnlockUserHandler(adminUseCase)
+	changeRoleHandler := adminhandler.NewChangeRoleHandler(adminUseCase)
 
 	// *******************
 	// API routes (rate-limited)
@@ -97,6 +196,7 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 	// Public auth routes
 	apiMux.Handle("POST /api/v1/auth/register", registerHandler)
 	apiMux.Handle("POST /api/v1/auth/login", loginHandler)
+	apiMux.Handle("POST /api/v1/auth/login/mfa", mfaLoginHandler)
 	apiMux.Handle("POST /api/v1/auth/refresh", refreshHandler)
 	apiMux.Handle("POST /api/v1/auth/verify-email", verifyEmailHandler)
 	apiMux.Handle("POST /api/v1/auth/forgot-password", forgotPasswordHandler)
@@ -104,21 +204,68 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 
 	// Protected auth routes
 	apiMux.Handle("POST /api/v1/auth/resend-verification", bearerAuth(resendVerificationHandler))
+	apiMux.Handle("POST /api/v1/auth/logout", bearerAuth(logoutHandler))
+	apiMux.Handle("POST /api/v1/auth/mfa/enroll", bearerAuth(enrollMFAHandler))
+	apiMux.Handle("POST /api/v1/auth/mfa/verify", bearerAuth(verifyMFAHandler))
+	apiMux.Handle("POST /api/v1/auth/mfa/disable", bearerAuth(disableMFAHandler))
+	apiMux.Handle("GET /api/v1/auth/sessions", bearerAuth(listSessionsHandler))
+	apiMux.Handle("DELETE /api/v1/auth/sessions", bearerAuth(revokeOtherSessionsHandler))
+	apiMux.Handle("DELETE /api/v1/auth/sessions/{id}", bearerAuth(revokeSessionHandler))
 
 	// Protected user routes
 	apiMux.Handle("GET /api/v1/users/me", bearerAuth(getProfileHandler))
 	apiMux.Handle("PATCH /api/v1/users/me", bearerAuth(updateProfileHandler))
 	apiMux.Handle("PATCH /api/v1/users/me/avatar", bearerAuth(updateAvatarHandler))
 	apiMux.Handle("DELETE /api/v1/users/me", bearerAuth(deleteAccountHandler))
+	apiMux.Handle("POST /api/v1/users/me/therapists", bearerAuth(linkTherapistHandler))
+	apiMux.Handle("GET /api/v1/users/me/therapists", bearerAuth(listTherapistsHandler))
+	apiMux.Handle("PATCH /api/v1/users/me/therapists/{linkID}", bearerAuth(updateConsentHandler))
+	apiMux.Handle("DELETE /api/v1/users/me/therapists/{linkID}", bearerAuth(revokeTherapistHandler))
 
 	// Protected therapy routes
 	apiMux.Handle("POST /api/v1/therapy/progress", bearerAuth(completeExerciseHandler))
 	apiMux.Handle("GET /api/v1/therapy/progress", bearerAuth(getProgressHandler))
+	apiMux.Handle("GET /api/v1/therapy/progress/{exerciseID}/attempts", bearerAuth(getAttemptsHandler))
+	apiMux.Handle("GET /api/v1/therapy/plan", bearerAuth(getMyPlansHandler))
+	apiMux.Handle("GET /api/v1/therapy/catalog", bearerAuth(getCatalogHandler))
+	apiMux.Handle("GET /api/v1/therapy/reviews/due", bearerAuth(getDueReviewsHandler))
 
 	// Protected stats routes
 	apiMux.Handle("GET /api/v1/stats", bearerAuth(getStatsHandler))
 	apiMux.Handle("PATCH /api/v1/stats/daily", bearerAuth(updateDailyStatsHandler))
 	apiMux.Handle("GET /api/v1/stats/daily/{date}", bearerAuth(getDailyStatsHandler))
+	apiMux.Handle("POST /api/v1/stats/sessions", bearerAuth(logToolSessionsHandler))
+	apiMux.Handle("GET /api/v1/stats/tools", bearerAuth(getToolsHandler))
+	apiMux.Handle("POST /api/v1/stats/analyze", bearerAuth(analyzeTranscriptHandler))
+	apiMux.Handle("GET /api/v1/stats/insights", bearerAuth(getInsightsHandler))
+
+	// Recordings; downloads are authorised by their signed link instead
+	apiMux.Handle("POST /api/v1/recordings", bearerAuth(uploadRecordingHandler))
+	apiMux.Handle("GET /api/v1/recordings", bearerAuth(listRecordingsHandler))
+	apiMux.Handle("GET /api/v1/recordings/{recordingID}", bearerAuth(getRecordingHandler))
+	apiMux.Handle("DELETE /api/v1/recordings/{recordingID}", bearerAuth(deleteRecordingHandler))
+	apiMux.Handle("GET /api/v1/recordings/{recordingID}/download", downloadRecordingHandler)
+
+	// Therapist routes
+	apiMux.Handle("POST /api/v1/therapist/link-codes", requirePermission(auth.PermClientsManage, createLinkCodeHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients", requirePermission(auth.PermClientsManage, listClientsHandler))
+	apiMux.Handle("DELETE /api/v1/therapist/clients/{clientID}", requirePermission(auth.PermClientsManage, removeClientHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients/{clientID}/progress", requirePermission(auth.PermProgressReadAssigned, getClientProgressHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients/{clientID}/stats", requirePermission(auth.PermStatsReadAssigned, getClientStatsHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients/{clientID}/stats/insights", requirePermission(auth.PermStatsReadAssigned, getClientInsightsHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients/{clientID}/recordings", requirePermission(auth.PermStatsReadAssigned, listClientRecordingsHandler))
+	apiMux.Handle("GET /api/v1/therapist/clients/{clientID}/plan", requirePermission(auth.PermPlansManageAssigned, getClientPlanHandler))
+	apiMux.Handle("PUT /api/v1/therapist/clients/{clientID}/plan", requirePermission(auth.PermPlansManageAssigned, assignPlanHandler))
+	apiMux.Handle("DELETE /api/v1/therapist/clients/{clientID}/plan", requirePermission(auth.PermPlansManageAssigned, archivePlanHandler))
+
+	// Admin routes
+	apiMux.Handle("GET /api/v1/admin/users", requirePermission(auth.PermUsersReadAny, listUsersHandler))
+	apiMux.Handle("GET /api/v1/admin/users/{id}", requirePermission(auth.PermUsersReadAny, getUserHandler))
+	apiMux.Handle("POST /api/v1/admin/users/{id}/suspend", requirePermission(auth.PermUsersManage, suspendUserHandler))
+	apiMux.Handle("POST /api/v1/admin/users/{id}/unsuspend", requirePermission(auth.PermUsersManage, unsuspendUserHandler))
+	apiMux.Handle("POST /api/v1/admin/users/{id}/verify-email", requirePermission(auth.PermUsersManage, verifyUserEmailHandler))
+	apiMux.Handle("POST /api/v1/admin/users/{id}/unlock", requirePermission(auth.PermUsersManage, unlockUserHandler))
+	apiMux.Handle("PATCH /api/v1/admin/users/{id}/role", requirePermission(auth.PermUsersManage, changeRoleHandler))
 
 	// *******************
 	// Middleware
@@ -129,7 +276,7 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 	corsMiddleware := middleware.CORS(middleware.CORSConfig{
 		AllowedOrigins: []string{"*"},
 		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Request-ID"},
+		AllowedHeaders: []string{"Content-Type", "Authorization", "X-Request-ID", "X-Device-Name", "If-None-Match"},
 	})
 
 	// *******************
@@ -138,6 +285,7 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 
 	mux := http.NewServeMux()
 	mux.Handle("GET /health", health.NewHandler(db, cfg.AppEnv, startTime))
+	mux.Handle("GET /.well-known/jwks.json", authhandler.NewJWKSHandler(jwtKeys))
 	mux.Handle("/", middleware.Chain(apiMux,
 		middleware.RequestID,
 		corsMiddleware,
@@ -146,3 +294,18 @@ func NewRouter(db *pgxpool.Pool, cfg *config.Config) http.Handler {
 
 	return mux
 }
+
+func mustLoadJWTKeys(cfg *config.Config) *auth.KeySet {
+	if cfg.JWTKeysDir != "" {
+		keys := auth.MustLoadKeySet(cfg.JWTKeysDir, cfg.JWTSigningKID)
+		slog.Info("🔑 JWT signing keys loaded", "dir", cfg.JWTKeysDir, "signing_kid", keys.SigningKID())
+		return keys
+	}
+
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		panic("jwt keys: " + err.Error())
+	}
+	slog.Warn("⚠️  JWT_KEYS_DIR not set, using an ephemeral signing key; tokens will not survive a restart")
+	return keys
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+var (
+	ErrCannotModifySelf      = errors.New("admins cannot perform this action on their own account")
+	ErrUserAlreadySuspended  = errors.New("user is already suspended")
+	ErrUserNotSuspended      = errors.New("user is not suspended")
+	ErrUserDeleted           = errors.New("user account has been deleted")
+	ErrEmptySuspensionReason = errors.New("suspension reason cannot be empty")
+	ErrSuspensionReasonLong  = errors.New("suspension reason cannot exceed 500 characters")
+	ErrInvalidUserFilter     = errors.New("invalid user filter")
+)
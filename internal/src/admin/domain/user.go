@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+const (
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 100
+	MaxSuspensionReason = 500
+)
+
+// Actor is the admin performing an action, recorded in the audit log.
+type Actor struct {
+	UserID    uuid.UUID
+	IPAddress string
+}
+
+type UserFilter struct {
+	Query       string
+	Status      *userdomain.UserStatus
+	Role        *userdomain.UserRole
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+	Offset      int
+}
+
+// UserDetail is the admin view of an account: the profile plus the
+// credential and session state that support staff need to act on it.
+type UserDetail struct {
+	User             *userdomain.User
+	SuspendedAt      *time.Time
+	SuspensionReason string
+	LastLogin        *time.Time
+	FailedAttempts   int
+	LockedUntil      *time.Time
+	MFAEnabled       bool
+	ActiveSessions   int
+}
+
+type UserPage struct {
+	Users  []*UserDetail
+	Total  int
+	Limit  int
+	Offset int
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	admindomain "saythis-backend/internal/src/admin/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+func mapAdminError(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, userdomain.ErrInvalidRole),
+		errors.Is(err, userdomain.ErrInvalidStatus),
+		errors.Is(err, admindomain.ErrEmptySuspensionReason),
+		errors.Is(err, admindomain.ErrSuspensionReasonLong),
+		errors.Is(err, admindomain.ErrInvalidUserFilter):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, admindomain.ErrCannotModifySelf):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, userdomain.ErrUserNotFound):
+		return http.StatusNotFound, userdomain.ErrUserNotFound.Error()
+
+	case errors.Is(err, admindomain.ErrUserAlreadySuspended),
+		errors.Is(err, admindomain.ErrUserNotSuspended),
+		errors.Is(err, admindomain.ErrUserDeleted):
+		return http.StatusConflict, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/admin/usecase"
+)
+
+type GetUserHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewGetUserHandler(uc *usecase.AdminUseCase) *GetUserHandler {
+	return &GetUserHandler{usecase: uc}
+}
+
+func (h *GetUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.usecase.GetUser(r.Context(), userID)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"saythis-backend/internal/helper"
+	admindomain "saythis-backend/internal/src/admin/domain"
+	"saythis-backend/internal/src/admin/usecase"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type ListUsersHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewListUsersHandler(uc *usecase.AdminUseCase) *ListUsersHandler {
+	return &ListUsersHandler{usecase: uc}
+}
+
+type listUsersResponse struct {
+	Users  []adminUserPayload `json:"users"`
+	Total  int                `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+func (h *ListUsersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	page, err := h.usecase.ListUsers(r.Context(), filter)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	users := make([]adminUserPayload, 0, len(page.Users))
+	for _, u := range page.Users {
+		users = append(users, toAdminUserPayload(u))
+	}
+
+	helper.JSON(w, http.StatusOK, listUsersResponse{
+		Users:  users,
+		Total:  page.Total,
+		Limit:  page.Limit,
+		Offset: page.Offset,
+	})
+}
+
+func parseUserFilter(r *http.Request) (admindomain.UserFilter, error) {
+	query := r.URL.Query()
+	filter := admindomain.UserFilter{Query: query.Get("q")}
+
+	if value := query.Get("status"); value != "" {
+		status := userdomain.UserStatus(value)
+		filter.Status = &status
+	}
+	if value := query.Get("role"); value != "" {
+		role := userdomain.UserRole(value)
+		filter.Role = &role
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseTimeQuery(query.Get("created_from")); err != nil {
+		return filter, admindomain.ErrInvalidUserFilter
+	}
+	if filter.CreatedTo, err = parseTimeQuery(query.Get("created_to")); err != nil {
+		return filter, admindomain.ErrInvalidUserFilter
+	}
+	if filter.Limit, err = parseIntQuery(query.Get("limit")); err != nil {
+		return filter, admindomain.ErrInvalidUserFilter
+	}
+	if filter.Offset, err = parseIntQuery(query.Get("offset")); err != nil {
+		return filter, admindomain.ErrInvalidUserFilter
+	}
+	return filter, nil
+}
+
+// parseTimeQuery accepts either an RFC 3339 timestamp or a bare date.
+func parseTimeQuery(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return &parsed, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func parseIntQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	admindomain "saythis-backend/internal/src/admin/domain"
+	"saythis-backend/internal/src/auth"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type adminUserPayload struct {
+	ID               uuid.UUID             `json:"id"`
+	Email            string                `json:"email"`
+	FullName         string                `json:"full_name"`
+	AvatarURL        string                `json:"avatar_url,omitempty"`
+	Timezone         string                `json:"timezone"`
+	Role             userdomain.UserRole   `json:"role"`
+	Status           userdomain.UserStatus `json:"status"`
+	EmailVerifiedAt  *time.Time            `json:"email_verified_at"`
+	SuspendedAt      *time.Time            `json:"suspended_at"`
+	SuspensionReason string                `json:"suspension_reason,omitempty"`
+	LastLogin        *time.Time            `json:"last_login"`
+	FailedAttempts   int                   `json:"failed_attempts"`
+	LockedUntil      *time.Time            `json:"locked_until"`
+	MFAEnabled       bool                  `json:"mfa_enabled"`
+	ActiveSessions   int                   `json:"active_sessions"`
+	CreatedAt        time.Time             `json:"created_at"`
+	UpdatedAt        time.Time             `json:"updated_at"`
+}
+
+type adminUserResponse struct {
+	User adminUserPayload `json:"user"`
+}
+
+func toAdminUserPayload(d *admindomain.UserDetail) adminUserPayload {
+	return adminUserPayload{
+		ID:               d.User.ID(),
+		Email:            d.User.Email(),
+		FullName:         d.User.FullName(),
+		AvatarURL:        d.User.AvatarURL(),
+		Timezone:         d.User.Timezone(),
+		Role:             d.User.Role(),
+		Status:           d.User.Status(),
+		EmailVerifiedAt:  d.User.EmailVerifiedAt(),
+		SuspendedAt:      d.SuspendedAt,
+		SuspensionReason: d.SuspensionReason,
+		LastLogin:        d.LastLogin,
+		FailedAttempts:   d.FailedAttempts,
+		LockedUntil:      d.LockedUntil,
+		MFAEnabled:       d.MFAEnabled,
+		ActiveSessions:   d.ActiveSessions,
+		CreatedAt:        d.User.CreatedAt(),
+		UpdatedAt:        d.User.UpdatedAt(),
+	}
+}
+
+// actorAndTarget pulls the acting admin from the token and the target user
+// from the {id} path segment, writing the error response itself on failure.
+func actorAndTarget(w http.ResponseWriter, r *http.Request) (admindomain.Actor, uuid.UUID, bool) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return admindomain.Actor{}, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid user id")
+		return admindomain.Actor{}, uuid.Nil, false
+	}
+
+	return admindomain.Actor{UserID: claims.UserID, IPAddress: helper.ClientIP(r)}, userID, true
+}
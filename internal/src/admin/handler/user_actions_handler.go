@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/admin/usecase"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type SuspendUserHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewSuspendUserHandler(uc *usecase.AdminUseCase) *SuspendUserHandler {
+	return &SuspendUserHandler{usecase: uc}
+}
+
+type suspendUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *SuspendUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req suspendUserRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	detail, err := h.usecase.SuspendUser(r.Context(), actor, userID, req.Reason)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
+
+type UnsuspendUserHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewUnsuspendUserHandler(uc *usecase.AdminUseCase) *UnsuspendUserHandler {
+	return &UnsuspendUserHandler{usecase: uc}
+}
+
+func (h *UnsuspendUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.usecase.UnsuspendUser(r.Context(), actor, userID)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
+
+type VerifyUserEmailHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewVerifyUserEmailHandler(uc *usecase.AdminUseCase) *VerifyUserEmailHandler {
+	return &VerifyUserEmailHandler{usecase: uc}
+}
+
+func (h *VerifyUserEmailHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.usecase.ForceVerifyEmail(r.Context(), actor, userID)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
+
+type UnlockUserHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewUnlockUserHandler(uc *usecase.AdminUseCase) *UnlockUserHandler {
+	return &UnlockUserHandler{usecase: uc}
+}
+
+func (h *UnlockUserHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	detail, err := h.usecase.UnlockUser(r.Context(), actor, userID)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
+
+type ChangeRoleHandler struct {
+	usecase *usecase.AdminUseCase
+}
+
+func NewChangeRoleHandler(uc *usecase.AdminUseCase) *ChangeRoleHandler {
+	return &ChangeRoleHandler{usecase: uc}
+}
+
+type changeRoleRequest struct {
+	Role userdomain.UserRole `json:"role"`
+}
+
+func (h *ChangeRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	actor, userID, ok := actorAndTarget(w, r)
+	if !ok {
+		return
+	}
+
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req changeRoleRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	detail, err := h.usecase.ChangeRole(r.Context(), actor, userID, req.Role)
+	if err != nil {
+		status, msg := mapAdminError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, adminUserResponse{User: toAdminUserPayload(detail)})
+}
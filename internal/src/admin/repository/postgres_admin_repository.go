@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	admindomain "saythis-backend/internal/src/admin/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+var _ AdminRepository = (*PostgresAdminRepo)(nil)
+
+type PostgresAdminRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresAdminRepo(db *pgxpool.Pool) *PostgresAdminRepo {
+	return &PostgresAdminRepo{db: db}
+}
+
+const userDetailColumns = `
+	u.id, u.email, u.full_name, COALESCE(u.avatar_url, ''), u.timezone, u.role, u.status,
+	u.email_verified_at, u.created_at, u.updated_at,
+	u.suspended_at, COALESCE(u.suspension_reason, ''),
+	c.last_login, COALESCE(c.failed_attempts, 0), c.locked_until,
+	c.mfa_enabled_at IS NOT NULL,
+	(
+		SELECT COUNT(*)
+		FROM   auth_sessions s
+		WHERE  s.user_id = u.id
+		  AND  EXISTS (
+		           SELECT 1 FROM refresh_tokens t
+		           WHERE  t.session_id = s.id
+		             AND  t.rotated_at IS NULL
+		             AND  t.expires_at > NOW()
+		       )
+	)
+`
+
+func scanUserDetail(row pgx.Row, extra ...any) (*admindomain.UserDetail, error) {
+	var (
+		id               uuid.UUID
+		email            string
+		fullName         string
+		avatarURL        string
+		timezone         string
+		role             userdomain.UserRole
+		status           userdomain.UserStatus
+		emailVerifiedAt  *time.Time
+		createdAt        time.Time
+		updatedAt        time.Time
+		suspendedAt      *time.Time
+		suspensionReason string
+		lastLogin        *time.Time
+		failedAttempts   int
+		lockedUntil      *time.Time
+		mfaEnabled       bool
+		activeSessions   int
+	)
+	dest := []any{
+		&id, &email, &fullName, &avatarURL, &timezone, &role, &status,
+		&emailVerifiedAt, &createdAt, &updatedAt,
+		&suspendedAt, &suspensionReason,
+		&lastLogin, &failedAttempts, &lockedUntil,
+		&mfaEnabled, &activeSessions,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	return &admindomain.UserDetail{
+		User:             userdomain.ReconstitueUser(id, email, fullName, avatarURL, timezone, role, status, emailVerifiedAt, createdAt, updatedAt),
+		SuspendedAt:      suspendedAt,
+		SuspensionReason: suspensionReason,
+		LastLogin:        lastLogin,
+		FailedAttempts:   failedAttempts,
+		LockedUntil:      lockedUntil,
+		MFAEnabled:       mfaEnabled,
+		ActiveSessions:   activeSessions,
+	}, nil
+}
+
+func (r *PostgresAdminRepo) ListUsers(ctx context.Context, filter admindomain.UserFilter) ([]*admindomain.UserDetail, int, error) {
+	query := `
+		SELECT ` + userDetailColumns + `, COUNT(*) OVER ()
+		FROM   users u
+		LEFT   JOIN auth_credentials c ON c.user_id = u.id
+		WHERE  ($1 = '' OR u.email ILIKE $1 ESCAPE '\' OR u.full_name ILIKE $1 ESCAPE '\')
+		  AND  ($2::text IS NULL OR u.status = $2)
+		  AND  ($3::text IS NULL OR u.role = $3)
+		  AND  ($4::timestamptz IS NULL OR u.created_at >= $4)
+		  AND  ($5::timestamptz IS NULL OR u.created_at < $5)
+		ORDER  BY u.created_at DESC, u.id
+		LIMIT  $6 OFFSET $7
+	`
+	pattern := ""
+	if filter.Query != "" {
+		pattern = "%" + escapeLike(filter.Query) + "%"
+	}
+	var status, role *string
+	if filter.Status != nil {
+		value := string(*filter.Status)
+		status = &value
+	}
+	if filter.Role != nil {
+		value := string(*filter.Role)
+		role = &value
+	}
+
+	rows, err := r.db.Query(ctx, query,
+		pattern, status, role, filter.CreatedFrom, filter.CreatedTo,
+		filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]*admindomain.UserDetail, 0)
+	total := 0
+	for rows.Next() {
+		detail, err := scanUserDetail(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan user row: %w", err)
+		}
+		users = append(users, detail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate user rows: %w", err)
+	}
+
+	// COUNT(*) OVER () is only available on returned rows, so a page past
+	// the end needs its own count.
+	if len(users) == 0 && filter.Offset > 0 {
+		countQuery := `
+			SELECT COUNT(*)
+			FROM   users u
+			WHERE  ($1 = '' OR u.email ILIKE $1 ESCAPE '\' OR u.full_name ILIKE $1 ESCAPE '\')
+			  AND  ($2::text IS NULL OR u.status = $2)
+			  AND  ($3::text IS NULL OR u.role = $3)
+			  AND  ($4::timestamptz IS NULL OR u.created_at >= $4)
+			  AND  ($5::timestamptz IS NULL OR u.created_at < $5)
+		`
+		if err := r.db.QueryRow(ctx, countQuery,
+			pattern, status, role, filter.CreatedFrom, filter.CreatedTo,
+		).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count users: %w", err)
+		}
+	}
+
+	return users, total, nil
+}
+
+func (r *PostgresAdminRepo) GetUser(ctx context.Context, userID uuid.UUID) (*admindomain.UserDetail, error) {
+	query := `
+		SELECT ` + userDetailColumns + `
+		FROM   users u
+		LEFT   JOIN auth_credentials c ON c.user_id = u.id
+		WHERE  u.id = $1
+	`
+	detail, err := scanUserDetail(r.db.QueryRow(ctx, query, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, userdomain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return detail, nil
+}
+
+func (r *PostgresAdminRepo) SuspendUser(ctx context.Context, userID uuid.UUID, reason string, suspendedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET    status            = 'suspended',
+		       suspended_at      = $2,
+		       suspension_reason = $3
+		WHERE  id = $1
+		  AND  status IN ('pending', 'active')
+	`
+	tag, err := r.db.Exec(ctx, query, userID, suspendedAt, reason)
+	if err != nil {
+		return fmt.Errorf("suspend user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return admindomain.ErrUserAlreadySuspended
+	}
+	return nil
+}
+
+func (r *PostgresAdminRepo) UnsuspendUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE users
+		SET    status            = CASE WHEN email_verified_at IS NULL THEN 'pending' ELSE 'active' END,
+		       suspended_at      = NULL,
+		       suspension_reason = NULL
+		WHERE  id = $1
+		  AND  status = 'suspended'
+	`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("unsuspend user: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return admindomain.ErrUserNotSuspended
+	}
+	return nil
+}
+
+func (r *PostgresAdminRepo) ForceVerifyEmail(ctx context.Context, userID uuid.UUID, verifiedAt time.Time) error {
+	query := `
+		UPDATE users
+		SET    email_verified_at = COALESCE(email_verified_at, $2),
+		       status            = CASE WHEN status = 'pending' THEN 'active' ELSE status END
+		WHERE  id = $1
+		  AND  status <> 'deleted'
+	`
+	tag, err := r.db.Exec(ctx, query, userID, verifiedAt)
+	if err != nil {
+		return fmt.Errorf("force verify email: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return userdomain.ErrUserNotFound
+	}
+
+	if _, err = r.db.Exec(ctx, `DELETE FROM email_verification_tokens WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete verification tokens: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAdminRepo) UnlockCredentials(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE auth_credentials
+		SET    failed_attempts = 0,
+		       locked_until    = NULL
+		WHERE  user_id = $1
+	`
+	tag, err := r.db.Exec(ctx, query, userID)
+	if err != nil {
+		return fmt.Errorf("unlock credentials: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return userdomain.ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAdminRepo) UpdateRole(ctx context.Context, userID uuid.UUID, role userdomain.UserRole) error {
+	query := `
+		UPDATE users
+		SET    role = $2
+		WHERE  id = $1
+		  AND  status <> 'deleted'
+	`
+	tag, err := r.db.Exec(ctx, query, userID, string(role))
+	if err != nil {
+		return fmt.Errorf("update role: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return userdomain.ErrUserNotFound
+	}
+	return nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	admindomain "saythis-backend/internal/src/admin/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type AdminRepository interface {
+	ListUsers(ctx context.Context, filter admindomain.UserFilter) ([]*admindomain.UserDetail, int, error)
+
+	GetUser(ctx context.Context, userID uuid.UUID) (*admindomain.UserDetail, error)
+
+	SuspendUser(ctx context.Context, userID uuid.UUID, reason string, suspendedAt time.Time) error
+
+	UnsuspendUser(ctx context.Context, userID uuid.UUID) error
+
+	ForceVerifyEmail(ctx context.Context, userID uuid.UUID, verifiedAt time.Time) error
+
+	UnlockCredentials(ctx context.Context, userID uuid.UUID) error
+
+	UpdateRole(ctx context.Context, userID uuid.UUID, role userdomain.UserRole) error
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	admindomain "saythis-backend/internal/src/admin/domain"
+	adminrepo "saythis-backend/internal/src/admin/repository"
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	auditrepo "saythis-backend/internal/src/audit/repository"
+	"saythis-backend/internal/src/auth"
+	authrepo "saythis-backend/internal/src/auth/repository"
+)
+
+type AdminUseCase struct {
+	adminRepo   adminrepo.AdminRepository
+	authRepo    authrepo.AuthRepository
+	auditRepo   auditrepo.AuditRepository
+	revocations *auth.RevocationList
+}
+
+func NewAdminUseCase(
+	adminRepo adminrepo.AdminRepository,
+	authRepo authrepo.AuthRepository,
+	auditRepo auditrepo.AuditRepository,
+	revocations *auth.RevocationList,
+) *AdminUseCase {
+	return &AdminUseCase{
+		adminRepo:   adminRepo,
+		authRepo:    authRepo,
+		auditRepo:   auditRepo,
+		revocations: revocations,
+	}
+}
+
+// revokeAccess signs the user out everywhere: refresh tokens stop working
+// and access tokens already in flight are rejected by BearerAuth.
+func (uc *AdminUseCase) revokeAccess(ctx context.Context, userID uuid.UUID) {
+	if err := uc.authRepo.DeleteAllRefreshTokensByUserID(ctx, userID); err != nil {
+		slog.Error("admin: failed to revoke sessions", "user_id", userID, "error", err)
+	}
+	if err := uc.revocations.RevokeUser(ctx, userID); err != nil {
+		slog.Error("admin: failed to revoke access tokens", "user_id", userID, "error", err)
+	}
+}
+
+func (uc *AdminUseCase) audit(ctx context.Context, actor admindomain.Actor, action string, targetID uuid.UUID, metadata map[string]any) {
+	entry := auditdomain.NewEntry(&actor.UserID, action, auditdomain.TargetUser, &targetID, metadata, actor.IPAddress)
+	if err := uc.auditRepo.Record(ctx, entry); err != nil {
+		slog.Error("admin: failed to write audit entry",
+			"action", action,
+			"actor_id", actor.UserID,
+			"target_id", targetID,
+			"error", err,
+		)
+	}
+}
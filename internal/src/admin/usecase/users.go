@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	admindomain "saythis-backend/internal/src/admin/domain"
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+func (uc *AdminUseCase) ListUsers(ctx context.Context, filter admindomain.UserFilter) (admindomain.UserPage, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Status != nil && !filter.Status.IsValid() {
+		return admindomain.UserPage{}, userdomain.ErrInvalidStatus
+	}
+	if filter.Role != nil && !filter.Role.IsValid() {
+		return admindomain.UserPage{}, userdomain.ErrInvalidRole
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return admindomain.UserPage{}, admindomain.ErrInvalidUserFilter
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = admindomain.DefaultUserPageSize
+	}
+	if filter.Limit > admindomain.MaxUserPageSize {
+		filter.Limit = admindomain.MaxUserPageSize
+	}
+	if filter.Offset < 0 {
+		return admindomain.UserPage{}, admindomain.ErrInvalidUserFilter
+	}
+
+	users, total, err := uc.adminRepo.ListUsers(ctx, filter)
+	if err != nil {
+		return admindomain.UserPage{}, fmt.Errorf("list users: %w", err)
+	}
+
+	return admindomain.UserPage{
+		Users:  users,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+func (uc *AdminUseCase) GetUser(ctx context.Context, userID uuid.UUID) (*admindomain.UserDetail, error) {
+	detail, err := uc.adminRepo.GetUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, userdomain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get user: %w", err)
+	}
+	return detail, nil
+}
+
+func (uc *AdminUseCase) SuspendUser(ctx context.Context, actor admindomain.Actor, userID uuid.UUID, reason string) (*admindomain.UserDetail, error) {
+	if actor.UserID == userID {
+		return nil, admindomain.ErrCannotModifySelf
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, admindomain.ErrEmptySuspensionReason
+	}
+	if utf8.RuneCountInString(reason) > admindomain.MaxSuspensionReason {
+		return nil, admindomain.ErrSuspensionReasonLong
+	}
+
+	detail, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch detail.User.Status() {
+	case userdomain.StatusDeleted:
+		return nil, admindomain.ErrUserDeleted
+	case userdomain.StatusSuspended:
+		return nil, admindomain.ErrUserAlreadySuspended
+	}
+
+	if err = uc.adminRepo.SuspendUser(ctx, userID, reason, time.Now().UTC()); err != nil {
+		if errors.Is(err, admindomain.ErrUserAlreadySuspended) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("suspend user: %w", err)
+	}
+
+	uc.revokeAccess(ctx, userID)
+	uc.audit(ctx, actor, auditdomain.ActionUserSuspended, userID, map[string]any{
+		"reason":          reason,
+		"previous_status": detail.User.Status(),
+	})
+	slog.Info("admin: user suspended", "actor_id", actor.UserID, "user_id", userID)
+
+	return uc.GetUser(ctx, userID)
+}
+
+func (uc *AdminUseCase) UnsuspendUser(ctx context.Context, actor admindomain.Actor, userID uuid.UUID) (*admindomain.UserDetail, error) {
+	detail, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.User.Status() != userdomain.StatusSuspended {
+		return nil, admindomain.ErrUserNotSuspended
+	}
+
+	if err = uc.adminRepo.UnsuspendUser(ctx, userID); err != nil {
+		if errors.Is(err, admindomain.ErrUserNotSuspended) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unsuspend user: %w", err)
+	}
+
+	uc.audit(ctx, actor, auditdomain.ActionUserUnsuspended, userID, map[string]any{
+		"suspension_reason": detail.SuspensionReason,
+	})
+	slog.Info("admin: user unsuspended", "actor_id", actor.UserID, "user_id", userID)
+
+	return uc.GetUser(ctx, userID)
+}
+
+func (uc *AdminUseCase) ForceVerifyEmail(ctx context.Context, actor admindomain.Actor, userID uuid.UUID) (*admindomain.UserDetail, error) {
+	detail, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.User.Status() == userdomain.StatusDeleted {
+		return nil, admindomain.ErrUserDeleted
+	}
+
+	if err = uc.adminRepo.ForceVerifyEmail(ctx, userID, time.Now().UTC()); err != nil {
+		if errors.Is(err, userdomain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("force verify email: %w", err)
+	}
+
+	uc.audit(ctx, actor, auditdomain.ActionUserEmailVerified, userID, map[string]any{
+		"was_verified": detail.User.EmailVerifiedAt() != nil,
+	})
+	slog.Info("admin: email force-verified", "actor_id", actor.UserID, "user_id", userID)
+
+	return uc.GetUser(ctx, userID)
+}
+
+func (uc *AdminUseCase) UnlockUser(ctx context.Context, actor admindomain.Actor, userID uuid.UUID) (*admindomain.UserDetail, error) {
+	detail, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.adminRepo.UnlockCredentials(ctx, userID); err != nil {
+		if errors.Is(err, userdomain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unlock user: %w", err)
+	}
+
+	uc.audit(ctx, actor, auditdomain.ActionUserUnlocked, userID, map[string]any{
+		"failed_attempts": detail.FailedAttempts,
+		"locked_until":    detail.LockedUntil,
+	})
+	slog.Info("admin: credentials unlocked", "actor_id", actor.UserID, "user_id", userID)
+
+	return uc.GetUser(ctx, userID)
+}
+
+// ChangeRole revokes the user's sessions because the role is baked into
+// every access token they hold.
+func (uc *AdminUseCase) ChangeRole(ctx context.Context, actor admindomain.Actor, userID uuid.UUID, role userdomain.UserRole) (*admindomain.UserDetail, error) {
+	if actor.UserID == userID {
+		return nil, admindomain.ErrCannotModifySelf
+	}
+	if !role.IsValid() {
+		return nil, userdomain.ErrInvalidRole
+	}
+
+	detail, err := uc.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if detail.User.Status() == userdomain.StatusDeleted {
+		return nil, admindomain.ErrUserDeleted
+	}
+	if detail.User.Role() == role {
+		return detail, nil
+	}
+
+	if err = uc.adminRepo.UpdateRole(ctx, userID, role); err != nil {
+		if errors.Is(err, userdomain.ErrUserNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("change role: %w", err)
+	}
+
+	uc.revokeAccess(ctx, userID)
+	uc.audit(ctx, actor, auditdomain.ActionUserRoleChanged, userID, map[string]any{
+		"previous_role": detail.User.Role(),
+		"new_role":      role,
+	})
+	slog.Info("admin: role changed", "actor_id", actor.UserID, "user_id", userID, "role", role)
+
+	return uc.GetUser(ctx, userID)
+}
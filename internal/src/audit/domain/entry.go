@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ActionAuthorizationDenied = "authorization.denied"
+
+	ActionUserSuspended     = "admin.user.suspended"
+	ActionUserUnsuspended   = "admin.user.unsuspended"
+	ActionUserEmailVerified = "admin.user.email_verified"
+	ActionUserUnlocked      = "admin.user.unlocked"
+	ActionUserRoleChanged   = "admin.user.role_changed"
+
+	ActionTherapistLinked        = "therapist.link.created"
+	ActionTherapistScopesChanged = "therapist.link.scopes_changed"
+	ActionTherapistUnlinked      = "therapist.link.revoked"
+
+	TargetRoute = "route"
+	TargetUser  = "user"
+	TargetLink  = "therapist_link"
+)
+
+type Entry struct {
+	ID         uuid.UUID
+	ActorID    *uuid.UUID
+	Action     string
+	TargetType string
+	TargetID   *uuid.UUID
+	Metadata   map[string]any
+	IPAddress  string
+	CreatedAt  time.Time
+}
+
+func NewEntry(actorID *uuid.UUID, action, targetType string, targetID *uuid.UUID, metadata map[string]any, ipAddress string) *Entry {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	return &Entry{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Metadata:   metadata,
+		IPAddress:  ipAddress,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	auditdomain "saythis-backend/internal/src/audit/domain"
+)
+
+var _ AuditRepository = (*PostgresAuditRepo)(nil)
+
+type PostgresAuditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresAuditRepo(db *pgxpool.Pool) *PostgresAuditRepo {
+	return &PostgresAuditRepo{db: db}
+}
+
+func (r *PostgresAuditRepo) Record(ctx context.Context, entry *auditdomain.Entry) error {
+	metadata, err := json.Marshal(entry.Metadata)
+	if err != nil {
+		return fmt.Errorf("marshal audit metadata: %w", err)
+	}
+
+	query := `
+		INSERT INTO audit_log (id, actor_id, action, target_type, target_id, metadata, ip_address, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err = r.db.Exec(ctx, query,
+		entry.ID, entry.ActorID, entry.Action, entry.TargetType, entry.TargetID,
+		metadata, entry.IPAddress, entry.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"context"
+
+	auditdomain "saythis-backend/internal/src/audit/domain"
+)
+
+type AuditRepository interface {
+	Record(ctx context.Context, entry *auditdomain.Entry) error
+}
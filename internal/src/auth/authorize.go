@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type Permission string
+
+const (
+	PermUsersReadAny         Permission = "users:read_any"
+	PermUsersManage          Permission = "users:manage"
+	PermClientsManage        Permission = "clients:manage"
+	PermProgressReadAssigned Permission = "progress:read_assigned"
+	PermStatsReadAssigned    Permission = "stats:read_assigned"
+	PermPlansManageAssigned  Permission = "plans:manage_assigned"
+)
+
+// rolePermissions is the single source of truth for what each role may do
+// beyond acting on its own data. "Assigned" permissions are further narrowed
+// to linked clients by the handlers that use them.
+var rolePermissions = map[userdomain.UserRole][]Permission{
+	userdomain.RoleAdmin: {
+		PermUsersReadAny,
+		PermUsersManage,
+	},
+	userdomain.RoleTherapist: {
+		PermClientsManage,
+		PermProgressReadAssigned,
+		PermStatsReadAssigned,
+		PermPlansManageAssigned,
+	},
+	userdomain.RoleUser: {},
+}
+
+const errForbidden = "you do not have permission to perform this action"
+
+func HasPermission(role userdomain.UserRole, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+type AuditRecorder interface {
+	Record(ctx context.Context, entry *auditdomain.Entry) error
+}
+
+// Authorizer builds middleware that must run after BearerAuth.
+type Authorizer struct {
+	audit AuditRecorder
+}
+
+func NewAuthorizer(audit AuditRecorder) *Authorizer {
+	return &Authorizer{audit: audit}
+}
+
+func (a *Authorizer) RequireRole(roles ...userdomain.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				helper.Error(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+
+			for _, role := range roles {
+				if userdomain.UserRole(claims.Role) == role {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			a.deny(w, r, claims, map[string]any{"required_roles": roles})
+		})
+	}
+}
+
+func (a *Authorizer) RequirePermission(permission Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				helper.Error(w, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+
+			if !HasPermission(userdomain.UserRole(claims.Role), permission) {
+				a.deny(w, r, claims, map[string]any{"required_permission": permission})
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (a *Authorizer) deny(w http.ResponseWriter, r *http.Request, claims *Claims, metadata map[string]any) {
+	metadata["method"] = r.Method
+	metadata["path"] = r.URL.Path
+	metadata["role"] = claims.Role
+
+	slog.Warn("authorization denied",
+		"user_id", claims.UserID,
+		"role", claims.Role,
+		"method", r.Method,
+		"path", r.URL.Path,
+	)
+
+	actorID := claims.UserID
+	entry := auditdomain.NewEntry(&actorID, auditdomain.ActionAuthorizationDenied, auditdomain.TargetRoute, nil, metadata, helper.ClientIP(r))
+	if err := a.audit.Record(r.Context(), entry); err != nil {
+		slog.Error("authorization: failed to write audit entry", "user_id", claims.UserID, "error", err)
+	}
+
+	helper.Error(w, http.StatusForbidden, errForbidden)
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+type fakeAuditRecorder struct {
+	entries []*auditdomain.Entry
+}
+
+func (f *fakeAuditRecorder) Record(_ context.Context, entry *auditdomain.Entry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func serveWithRole(handler http.Handler, role userdomain.UserRole) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/users", nil)
+	claims := &Claims{UserID: uuid.New(), Role: string(role)}
+	req = req.WithContext(context.WithValue(req.Context(), claimsContextKey, claims))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		role   userdomain.UserRole
+		perm   Permission
+		status int
+	}{
+		{userdomain.RoleAdmin, PermUsersReadAny, http.StatusOK},
+		{userdomain.RoleTherapist, PermUsersReadAny, http.StatusForbidden},
+		{userdomain.RoleUser, PermUsersReadAny, http.StatusForbidden},
+		{userdomain.RoleTherapist, PermProgressReadAssigned, http.StatusOK},
+		{userdomain.RoleAdmin, PermProgressReadAssigned, http.StatusForbidden},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.role)+" "+string(tc.perm), func(t *testing.T) {
+			recorder := &fakeAuditRecorder{}
+			handler := NewAuthorizer(recorder).RequirePermission(tc.perm)(okHandler)
+
+			rec := serveWithRole(handler, tc.role)
+			if rec.Code != tc.status {
+				t.Fatalf("want %d, got %d", tc.status, rec.Code)
+			}
+
+			wantEntries := 0
+			if tc.status == http.StatusForbidden {
+				wantEntries = 1
+			}
+			if len(recorder.entries) != wantEntries {
+				t.Fatalf("want %d audit entries, got %d", wantEntries, len(recorder.entries))
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	recorder := &fakeAuditRecorder{}
+	handler := NewAuthorizer(recorder).RequireRole(userdomain.RoleAdmin, userdomain.RoleTherapist)(okHandler)
+
+	if rec := serveWithRole(handler, userdomain.RoleTherapist); rec.Code != http.StatusOK {
+		t.Errorf("want therapist allowed, got %d", rec.Code)
+	}
+	if rec := serveWithRole(handler, userdomain.RoleUser); rec.Code != http.StatusForbidden {
+		t.Errorf("want user forbidden, got %d", rec.Code)
+	}
+	if len(recorder.entries) != 1 || recorder.entries[0].Action != auditdomain.ActionAuthorizationDenied {
+		t.Errorf("want a single denial audit entry, got %+v", recorder.entries)
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -12,7 +13,7 @@ type contextKey string
 
 const claimsContextKey contextKey = "auth_claims"
 
-func BearerAuth(cfg JWTConfig) func(http.Handler) http.Handler {
+func BearerAuth(cfg JWTConfig, revocations *RevocationList) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			header := r.Header.Get("Authorization")
@@ -33,6 +34,17 @@ func BearerAuth(cfg JWTConfig) func(http.Handler) http.Handler {
 				return
 			}
 
+			revoked, err := revocations.IsRevoked(r.Context(), claims)
+			if err != nil {
+				slog.Error("bearer_auth: revocation check failed", "user_id", claims.UserID, "error", err)
+				helper.Error(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+			if revoked {
+				helper.Error(w, http.StatusUnauthorized, "token has been revoked")
+				return
+			}
+
 			ctx := context.WithValue(r.Context(), claimsContextKey, claims)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
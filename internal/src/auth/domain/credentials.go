@@ -13,6 +13,8 @@ type AuthCredentials struct {
 	lastLogin      *time.Time
 	failedAttempts int
 	lockedUntil    *time.Time
+	mfaSecret      string
+	mfaEnabledAt   *time.Time
 	createdAt      time.Time
 	updatedAt      time.Time
 }
@@ -33,6 +35,8 @@ func ReconstitueAuthCredentials(
 	lastLogin *time.Time,
 	failedAttempts int,
 	lockedUntil *time.Time,
+	mfaSecret string,
+	mfaEnabledAt *time.Time,
 	createdAt, updatedAt time.Time,
 ) *AuthCredentials {
 	return &AuthCredentials{
@@ -42,20 +46,30 @@ func ReconstitueAuthCredentials(
 		lastLogin:      lastLogin,
 		failedAttempts: failedAttempts,
 		lockedUntil:    lockedUntil,
+		mfaSecret:      mfaSecret,
+		mfaEnabledAt:   mfaEnabledAt,
 		createdAt:      createdAt,
 		updatedAt:      updatedAt,
 	}
 }
 
-func (c *AuthCredentials) ID() uuid.UUID           { return c.id }
-func (c *AuthCredentials) UserID() uuid.UUID       { return c.userID }
-func (c *AuthCredentials) PasswordHash() string    { return c.passwordHash }
-func (c *AuthCredentials) LastLogin() *time.Time   { return c.lastLogin }
-func (c *AuthCredentials) FailedAttempts() int     { return c.failedAttempts }
-func (c *AuthCredentials) LockedUntil() *time.Time { return c.lockedUntil }
-func (c *AuthCredentials) CreatedAt() time.Time    { return c.createdAt }
-func (c *AuthCredentials) UpdatedAt() time.Time    { return c.updatedAt }
+func (c *AuthCredentials) ID() uuid.UUID            { return c.id }
+func (c *AuthCredentials) UserID() uuid.UUID        { return c.userID }
+func (c *AuthCredentials) PasswordHash() string     { return c.passwordHash }
+func (c *AuthCredentials) LastLogin() *time.Time    { return c.lastLogin }
+func (c *AuthCredentials) FailedAttempts() int      { return c.failedAttempts }
+func (c *AuthCredentials) LockedUntil() *time.Time  { return c.lockedUntil }
+func (c *AuthCredentials) MFASecret() string        { return c.mfaSecret }
+func (c *AuthCredentials) MFAEnabledAt() *time.Time { return c.mfaEnabledAt }
+func (c *AuthCredentials) CreatedAt() time.Time     { return c.createdAt }
+func (c *AuthCredentials) UpdatedAt() time.Time     { return c.updatedAt }
 
 func (c *AuthCredentials) IsLocked() bool {
 	return c.lockedUntil != nil && time.Now().UTC().Before(*c.lockedUntil)
 }
+
+func (c *AuthCredentials) MFAEnabled() bool { return c.mfaEnabledAt != nil }
+
+// MFAPending reports an enrollment that has a secret but was never confirmed
+// with a valid code.
+func (c *AuthCredentials) MFAPending() bool { return c.mfaSecret != "" && c.mfaEnabledAt == nil }
@@ -10,6 +10,8 @@ var (
 	ErrInvalidToken  = errors.New("invalid or malformed token")
 	ErrExpiredToken  = errors.New("token has expired")
 	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenReused   = errors.New("refresh token has already been used")
+	ErrTokenRotated  = errors.New("refresh token was just rotated, use the one from the latest response")
 
 	ErrEmailAlreadyVerified = errors.New("email address is already verified")
 	ErrResendTooSoon        = errors.New("you can only request a new verification email once every 24 hours")
@@ -21,4 +23,11 @@ var (
 	ErrAccountLocked = errors.New("account is temporarily locked, too many failed attempts")
 
 	ErrCredentialsNotFound = errors.New("credentials not found")
+
+	ErrSessionNotFound = errors.New("session not found")
+
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrollment has not been started")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid authentication code")
 )
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxDeviceNameLength = 100
+	maxUserAgentLength  = 512
+	maxIPAddressLength  = 45
+)
+
+type ClientInfo struct {
+	DeviceName string
+	UserAgent  string
+	IPAddress  string
+}
+
+func NewClientInfo(deviceName, userAgent, ipAddress string) ClientInfo {
+	return ClientInfo{
+		DeviceName: truncate(strings.TrimSpace(deviceName), maxDeviceNameLength),
+		UserAgent:  truncate(strings.TrimSpace(userAgent), maxUserAgentLength),
+		IPAddress:  truncate(strings.TrimSpace(ipAddress), maxIPAddressLength),
+	}
+}
+
+type Session struct {
+	id         uuid.UUID
+	userID     uuid.UUID
+	deviceName string
+	userAgent  string
+	ipAddress  string
+	createdAt  time.Time
+	lastUsedAt time.Time
+}
+
+func NewSession(userID uuid.UUID, client ClientInfo, timeNow time.Time) *Session {
+	return &Session{
+		id:         uuid.New(),
+		userID:     userID,
+		deviceName: client.DeviceName,
+		userAgent:  client.UserAgent,
+		ipAddress:  client.IPAddress,
+		createdAt:  timeNow,
+		lastUsedAt: timeNow,
+	}
+}
+
+func ReconstitueSession(
+	id, userID uuid.UUID,
+	deviceName, userAgent, ipAddress string,
+	createdAt, lastUsedAt time.Time,
+) *Session {
+	return &Session{
+		id:         id,
+		userID:     userID,
+		deviceName: deviceName,
+		userAgent:  userAgent,
+		ipAddress:  ipAddress,
+		createdAt:  createdAt,
+		lastUsedAt: lastUsedAt,
+	}
+}
+
+func (s *Session) ID() uuid.UUID         { return s.id }
+func (s *Session) UserID() uuid.UUID     { return s.userID }
+func (s *Session) DeviceName() string    { return s.deviceName }
+func (s *Session) UserAgent() string     { return s.userAgent }
+func (s *Session) IPAddress() string     { return s.ipAddress }
+func (s *Session) CreatedAt() time.Time  { return s.createdAt }
+func (s *Session) LastUsedAt() time.Time { return s.lastUsedAt }
+
+// truncate cuts value to at most max bytes without splitting a character,
+// since Postgres rejects invalid UTF-8.
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	for max > 0 && !utf8.RuneStart(value[max]) {
+		max--
+	}
+	return value[:max]
+}
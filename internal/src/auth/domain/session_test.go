@@ -0,0 +1,24 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	authdomain "saythis-backend/internal/src/auth/domain"
+)
+
+func TestNewClientInfo_TruncatesOnCharacterBoundary(t *testing.T) {
+	// 99 ASCII bytes leave one byte of the limit for a two-byte "é".
+	deviceName := strings.Repeat("a", 99) + "é phone"
+	userAgent := strings.Repeat("日本", 100)
+
+	client := authdomain.NewClientInfo(deviceName, userAgent, "203.0.113.7")
+
+	if !utf8.ValidString(client.DeviceName) || client.DeviceName != strings.Repeat("a", 99) {
+		t.Errorf("want the device name cut before the split character, got %q", client.DeviceName)
+	}
+	if !utf8.ValidString(client.UserAgent) || len(client.UserAgent) != 510 {
+		t.Errorf("want a valid 510-byte user agent, got %d bytes", len(client.UserAgent))
+	}
+}
@@ -9,40 +9,60 @@ import (
 type RefreshToken struct {
 	id        uuid.UUID
 	userID    uuid.UUID
+	sessionID uuid.UUID
 	tokenHash string
 	expiresAt time.Time
 	createdAt time.Time
+	rotatedAt *time.Time
 }
 
-func NewRefreshToken(userID uuid.UUID, tokenHash string, expiresAt time.Time) *RefreshToken {
+func NewRefreshToken(userID, sessionID uuid.UUID, tokenHash string, expiresAt time.Time) *RefreshToken {
 	return &RefreshToken{
 		id:        uuid.New(),
 		userID:    userID,
+		sessionID: sessionID,
 		tokenHash: tokenHash,
 		expiresAt: expiresAt,
 		createdAt: time.Now().UTC(),
 	}
 }
 
-func ReconstitueRefreshToken(id, userID uuid.UUID, tokenHash string, expiresAt, createdAt time.Time) *RefreshToken {
+func ReconstitueRefreshToken(id, userID, sessionID uuid.UUID, tokenHash string, expiresAt, createdAt time.Time, rotatedAt *time.Time) *RefreshToken {
 	return &RefreshToken{
 		id:        id,
 		userID:    userID,
+		sessionID: sessionID,
 		tokenHash: tokenHash,
 		expiresAt: expiresAt,
 		createdAt: createdAt,
+		rotatedAt: rotatedAt,
 	}
 }
 
-func (t *RefreshToken) ID() uuid.UUID        { return t.id }
-func (t *RefreshToken) UserID() uuid.UUID    { return t.userID }
-func (t *RefreshToken) TokenHash() string    { return t.tokenHash }
-func (t *RefreshToken) ExpiresAt() time.Time { return t.expiresAt }
-func (t *RefreshToken) CreatedAt() time.Time { return t.createdAt }
+func (t *RefreshToken) ID() uuid.UUID         { return t.id }
+func (t *RefreshToken) UserID() uuid.UUID     { return t.userID }
+func (t *RefreshToken) SessionID() uuid.UUID  { return t.sessionID }
+func (t *RefreshToken) TokenHash() string     { return t.tokenHash }
+func (t *RefreshToken) ExpiresAt() time.Time  { return t.expiresAt }
+func (t *RefreshToken) CreatedAt() time.Time  { return t.createdAt }
+func (t *RefreshToken) RotatedAt() *time.Time { return t.rotatedAt }
 
 func (t *RefreshToken) IsExpired() bool { return time.Now().UTC().After(t.expiresAt) }
 
+func (t *RefreshToken) IsRotated() bool { return t.rotatedAt != nil }
+
 type TokenPair struct {
 	AccessToken  string
 	RefreshToken string
 }
+
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+type MFAEnrollment struct {
+	Secret        string
+	OTPAuthURI    string
+	RecoveryCodes []string
+}
@@ -24,6 +24,8 @@ func mapAuthError(err error) (int, string) {
 
 	case errors.Is(err, authdomain.ErrInvalidCredentials),
 		errors.Is(err, authdomain.ErrTokenNotFound),
+		errors.Is(err, authdomain.ErrTokenReused),
+		errors.Is(err, authdomain.ErrInvalidMFACode),
 		errors.Is(err, authdomain.ErrExpiredToken):
 		return http.StatusUnauthorized, err.Error()
 
@@ -34,6 +36,14 @@ func mapAuthError(err error) (int, string) {
 	case errors.Is(err, userdomain.ErrDuplicateEmail):
 		return http.StatusConflict, userdomain.ErrDuplicateEmail.Error()
 
+	case errors.Is(err, authdomain.ErrMFAAlreadyEnabled),
+		errors.Is(err, authdomain.ErrMFANotEnrolled),
+		errors.Is(err, authdomain.ErrMFANotEnabled):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, authdomain.ErrTokenRotated):
+		return http.StatusConflict, err.Error()
+
 	case errors.Is(err, authdomain.ErrEmailAlreadyVerified):
 		return http.StatusConflict, authdomain.ErrEmailAlreadyVerified.Error()
 
@@ -43,6 +53,9 @@ func mapAuthError(err error) (int, string) {
 	case errors.Is(err, userdomain.ErrUserNotFound):
 		return http.StatusNotFound, userdomain.ErrUserNotFound.Error()
 
+	case errors.Is(err, authdomain.ErrSessionNotFound):
+		return http.StatusNotFound, authdomain.ErrSessionNotFound.Error()
+
 	default:
 		return http.StatusInternalServerError, "internal server error"
 	}
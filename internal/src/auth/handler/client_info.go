@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	authdomain "saythis-backend/internal/src/auth/domain"
+)
+
+const deviceNameHeader = "X-Device-Name"
+
+func clientInfoFromRequest(r *http.Request) authdomain.ClientInfo {
+	return authdomain.NewClientInfo(
+		r.Header.Get(deviceNameHeader),
+		r.UserAgent(),
+		helper.ClientIP(r),
+	)
+}
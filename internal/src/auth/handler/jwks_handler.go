@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+)
+
+type JWKSHandler struct {
+	keys *auth.KeySet
+}
+
+func NewJWKSHandler(keys *auth.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	helper.JSON(w, http.StatusOK, h.keys.JWKS())
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"net/http"
+	"time"
 
 	"saythis-backend/internal/helper"
 	"saythis-backend/internal/src/auth/usecase"
@@ -26,6 +27,12 @@ type loginResponse struct {
 	RefreshToken string      `json:"refresh_token"`
 }
 
+type mfaChallengeResponse struct {
+	MFARequired bool      `json:"mfa_required"`
+	MFAToken    string    `json:"mfa_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
 func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	const maxBodySize = 1 << 20
 	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
@@ -37,18 +44,28 @@ func (h *LoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, tokens, err := h.usecase.Login(r.Context(), req.Email, req.Password)
+	user, tokens, challenge, err := h.usecase.Login(r.Context(), req.Email, req.Password, clientInfoFromRequest(r))
 	if err != nil {
 		status, msg := mapAuthError(err)
 		helper.Error(w, status, msg)
 		return
 	}
 
+	if challenge != nil {
+		helper.JSON(w, http.StatusOK, mfaChallengeResponse{
+			MFARequired: true,
+			MFAToken:    challenge.Token,
+			ExpiresAt:   challenge.ExpiresAt,
+		})
+		return
+	}
+
 	helper.JSON(w, http.StatusOK, loginResponse{
 		User: userPayload{
 			ID:              user.ID(),
 			Email:           user.Email(),
 			FullName:        user.FullName(),
+			Timezone:        user.Timezone(),
 			Role:            user.Role(),
 			Status:          user.Status(),
 			EmailVerifiedAt: user.EmailVerifiedAt(),
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/auth/usecase"
+)
+
+type LogoutHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewLogoutHandler(uc *usecase.AuthUseCase) *LogoutHandler {
+	return &LogoutHandler{usecase: uc}
+}
+
+type logoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func (h *LogoutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req logoutRequest
+	if err := helper.DecodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.usecase.Logout(r.Context(), claims, req.RefreshToken); err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/auth/usecase"
+)
+
+type EnrollMFAHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewEnrollMFAHandler(uc *usecase.AuthUseCase) *EnrollMFAHandler {
+	return &EnrollMFAHandler{usecase: uc}
+}
+
+type enrollMFAResponse struct {
+	Secret        string   `json:"secret"`
+	OTPAuthURI    string   `json:"otpauth_uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func (h *EnrollMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	enrollment, err := h.usecase.EnrollMFA(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, enrollMFAResponse{
+		Secret:        enrollment.Secret,
+		OTPAuthURI:    enrollment.OTPAuthURI,
+		RecoveryCodes: enrollment.RecoveryCodes,
+	})
+}
+
+type VerifyMFAHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewVerifyMFAHandler(uc *usecase.AuthUseCase) *VerifyMFAHandler {
+	return &VerifyMFAHandler{usecase: uc}
+}
+
+type verifyMFARequest struct {
+	Code string `json:"code"`
+}
+
+type verifyMFAResponse struct {
+	MFAEnabled bool `json:"mfa_enabled"`
+}
+
+func (h *VerifyMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req verifyMFARequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.usecase.ConfirmMFA(r.Context(), claims.UserID, req.Code); err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, verifyMFAResponse{MFAEnabled: true})
+}
+
+type DisableMFAHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewDisableMFAHandler(uc *usecase.AuthUseCase) *DisableMFAHandler {
+	return &DisableMFAHandler{usecase: uc}
+}
+
+type disableMFARequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+func (h *DisableMFAHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req disableMFARequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.usecase.DisableMFA(r.Context(), claims.UserID, req.Password, req.Code); err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type MFALoginHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewMFALoginHandler(uc *usecase.AuthUseCase) *MFALoginHandler {
+	return &MFALoginHandler{usecase: uc}
+}
+
+type mfaLoginRequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+func (h *MFALoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req mfaLoginRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, tokens, err := h.usecase.CompleteMFALogin(r.Context(), req.MFAToken, req.Code, clientInfoFromRequest(r))
+	if err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, loginResponse{
+		User: userPayload{
+			ID:              user.ID(),
+			Email:           user.Email(),
+			FullName:        user.FullName(),
+			Timezone:        user.Timezone(),
+			Role:            user.Role(),
+			Status:          user.Status(),
+			EmailVerifiedAt: user.EmailVerifiedAt(),
+			CreatedAt:       user.CreatedAt(),
+		},
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
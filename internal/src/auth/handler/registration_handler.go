@@ -35,6 +35,7 @@ type userPayload struct {
 	ID              uuid.UUID             `json:"id"`
 	Email           string                `json:"email"`
 	FullName        string                `json:"full_name"`
+	Timezone        string                `json:"timezone"`
 	Role            userdomain.UserRole   `json:"role"`
 	Status          userdomain.UserStatus `json:"status"`
 	EmailVerifiedAt *time.Time            `json:"email_verified_at"`
@@ -52,7 +53,7 @@ func (h *RegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, tokens, err := h.usecase.Register(r.Context(), req.Email, req.FullName, req.Password)
+	user, tokens, err := h.usecase.Register(r.Context(), req.Email, req.FullName, req.Password, clientInfoFromRequest(r))
 	if err != nil {
 		status, msg := mapAuthError(err)
 		helper.Error(w, status, msg)
@@ -64,6 +65,7 @@ func (h *RegisterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			ID:              user.ID(),
 			Email:           user.Email(),
 			FullName:        user.FullName(),
+			Timezone:        user.Timezone(),
 			Role:            user.Role(),
 			Status:          user.Status(),
 			EmailVerifiedAt: user.EmailVerifiedAt(),
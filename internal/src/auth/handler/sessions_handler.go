@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/auth/usecase"
+)
+
+type ListSessionsHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewListSessionsHandler(uc *usecase.AuthUseCase) *ListSessionsHandler {
+	return &ListSessionsHandler{usecase: uc}
+}
+
+type sessionPayload struct {
+	ID         uuid.UUID `json:"id"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	Current    bool      `json:"current"`
+}
+
+type listSessionsResponse struct {
+	Sessions []sessionPayload `json:"sessions"`
+}
+
+func (h *ListSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	sessions, err := h.usecase.ListSessions(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	items := make([]sessionPayload, 0, len(sessions))
+	for _, s := range sessions {
+		items = append(items, sessionPayload{
+			ID:         s.ID(),
+			DeviceName: s.DeviceName(),
+			UserAgent:  s.UserAgent(),
+			IPAddress:  s.IPAddress(),
+			CreatedAt:  s.CreatedAt(),
+			LastUsedAt: s.LastUsedAt(),
+			Current:    s.ID() == claims.SessionID,
+		})
+	}
+
+	helper.JSON(w, http.StatusOK, listSessionsResponse{Sessions: items})
+}
+
+type RevokeSessionHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewRevokeSessionHandler(uc *usecase.AuthUseCase) *RevokeSessionHandler {
+	return &RevokeSessionHandler{usecase: uc}
+}
+
+func (h *RevokeSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	sessionID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid session id")
+		return
+	}
+
+	if err := h.usecase.RevokeSession(r.Context(), claims.UserID, sessionID); err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type RevokeOtherSessionsHandler struct {
+	usecase *usecase.AuthUseCase
+}
+
+func NewRevokeOtherSessionsHandler(uc *usecase.AuthUseCase) *RevokeOtherSessionsHandler {
+	return &RevokeOtherSessionsHandler{usecase: uc}
+}
+
+type revokeOtherSessionsResponse struct {
+	RevokedSessions int64 `json:"revoked_sessions"`
+}
+
+func (h *RevokeOtherSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	revoked, err := h.usecase.RevokeOtherSessions(r.Context(), claims.UserID, claims.SessionID)
+	if err != nil {
+		status, msg := mapAuthError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, revokeOtherSessionsResponse{RevokedSessions: revoked})
+}
@@ -35,7 +35,7 @@ func (h *RefreshHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokens, err := h.usecase.Refresh(r.Context(), req.RefreshToken)
+	tokens, err := h.usecase.Refresh(r.Context(), req.RefreshToken, clientInfoFromRequest(r))
 	if err != nil {
 		status, msg := mapAuthError(err)
 		helper.Error(w, status, msg)
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
@@ -15,39 +16,57 @@ import (
 	"saythis-backend/internal/config"
 )
 
+// JWTConfig signs access tokens with the asymmetric Keys. Secret is kept
+// for material that never leaves this service, such as MFA challenge
+// tokens. MFAKey encrypts TOTP secrets and is separate so that rotating the
+// JWT secret does not lock users out of two-factor authentication.
 type JWTConfig struct {
+	Keys            *KeySet
 	Secret          []byte
+	MFAKey          []byte
 	AccessTokenTTL  time.Duration
 	RefreshTokenTTL time.Duration
+	MFAChallengeTTL time.Duration
 }
 
-func NewJWTConfig(cfg *config.Config) JWTConfig {
+func NewJWTConfig(cfg *config.Config, keys *KeySet) JWTConfig {
 	return JWTConfig{
+		Keys:            keys,
 		Secret:          []byte(cfg.JWTSecret),
+		MFAKey:          []byte(cfg.MFAEncryptionKey),
 		AccessTokenTTL:  cfg.AccessTokenTTL,
 		RefreshTokenTTL: cfg.RefreshTokenTTL,
+		MFAChallengeTTL: 5 * time.Minute,
 	}
 }
 
 type Claims struct {
-	UserID uuid.UUID `json:"user_id"`
-	Email  string    `json:"email"`
-	Role   string    `json:"role"`
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID uuid.UUID `json:"sid"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
 	jwt.RegisteredClaims
 }
 
-func GenerateAccessToken(cfg JWTConfig, userID uuid.UUID, email, role string) (string, error) {
+func GenerateAccessToken(cfg JWTConfig, userID, sessionID uuid.UUID, email, role string) (string, error) {
+	return GenerateAccessTokenAt(cfg, time.Now().UTC(), userID, sessionID, email, role)
+}
+
+// GenerateAccessTokenAt dates the token issuedAt, see
+// RevocationList.IssueTime.
+func GenerateAccessTokenAt(cfg JWTConfig, issuedAt time.Time, userID, sessionID uuid.UUID, email, role string) (string, error) {
 	claims := Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:    userID,
+		SessionID: sessionID,
+		Email:     email,
+		Role:      role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().UTC().Add(cfg.AccessTokenTTL)),
-			IssuedAt:  jwt.NewNumericDate(time.Now().UTC()),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(cfg.AccessTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(cfg.Secret)
+	signed, err := cfg.Keys.sign(claims)
 	if err != nil {
 		return "", fmt.Errorf("sign access token: %w", err)
 	}
@@ -55,12 +74,9 @@ func GenerateAccessToken(cfg JWTConfig, userID uuid.UUID, email, role string) (s
 }
 
 func ValidateAccessToken(cfg JWTConfig, tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(t *jwt.Token) (interface{}, error) {
-		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-		}
-		return cfg.Secret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, cfg.Keys.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("parse token: %w", err)
 	}
@@ -72,6 +88,54 @@ func ValidateAccessToken(cfg JWTConfig, tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+const mfaChallengeAudience = "mfa_challenge"
+
+// MFAChallengeClaims identify a user who passed the password step but still
+// owes a second factor. They are signed with a key derived for this purpose
+// only, so a challenge token can never pass as an access token.
+type MFAChallengeClaims struct {
+	UserID uuid.UUID `json:"user_id"`
+	jwt.RegisteredClaims
+}
+
+func GenerateMFAChallengeToken(cfg JWTConfig, userID uuid.UUID) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(cfg.MFAChallengeTTL)
+	claims := MFAChallengeClaims{
+		UserID: userID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Audience:  jwt.ClaimStrings{mfaChallengeAudience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(deriveKey(cfg.Secret, mfaChallengeAudience))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("sign mfa challenge token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+func ValidateMFAChallengeToken(cfg JWTConfig, tokenString string) (*MFAChallengeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &MFAChallengeClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return deriveKey(cfg.Secret, mfaChallengeAudience), nil
+	}, jwt.WithAudience(mfaChallengeAudience))
+	if err != nil {
+		return nil, fmt.Errorf("parse mfa challenge token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*MFAChallengeClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid mfa challenge claims")
+	}
+	return claims, nil
+}
+
 func GenerateRefreshToken() (plaintext, hash string, err error) {
 	b := make([]byte, 32)
 	if _, err = rand.Read(b); err != nil {
@@ -83,6 +147,17 @@ func GenerateRefreshToken() (plaintext, hash string, err error) {
 	return plaintext, hash, nil
 }
 
+// SuccessorRefreshToken derives the token that replaces plaintext when it
+// is rotated. Deriving it instead of drawing it lets a retry of the rotated
+// token be answered with the same successor, although only hashes are
+// stored.
+func SuccessorRefreshToken(cfg JWTConfig, plaintext string) (successor, hash string) {
+	mac := hmac.New(sha256.New, cfg.Secret)
+	mac.Write([]byte("refresh-successor:" + plaintext))
+	successor = base64.URLEncoding.EncodeToString(mac.Sum(nil))
+	return successor, HashRefreshToken(successor)
+}
+
 func HashRefreshToken(plaintext string) string {
 	sum := sha256.Sum256([]byte(plaintext))
 	return hex.EncodeToString(sum[:])
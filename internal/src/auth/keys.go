@@ -0,0 +1,245 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySet holds the key used to sign new access tokens and every key that is
+// still accepted for verification.
+//
+// Keys are read from a directory of PEM files; the file name without its
+// extension is the kid. A private key (PKCS#8 Ed25519 or RSA, or PKCS#1 RSA)
+// can sign and verify, a public key (PKIX) only verifies. To rotate, drop a
+// new private key in, and keep the old key's public half around until every
+// token it signed has expired.
+type KeySet struct {
+	signingKID string
+	signer     crypto.Signer
+	method     jwt.SigningMethod
+	public     map[string]crypto.PublicKey
+}
+
+// LoadKeySet reads every *.pem file in dir. signingKID picks the signing
+// key; when empty the lexicographically last private key is used, so
+// date-prefixed names rotate naturally.
+func LoadKeySet(dir, signingKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("list key files: %w", err)
+	}
+	sort.Strings(paths)
+
+	ks := &KeySet{public: make(map[string]crypto.PublicKey)}
+	signers := make(map[string]crypto.Signer)
+	lastPrivate := ""
+
+	for _, path := range paths {
+		kid := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read key %s: %w", kid, err)
+		}
+
+		private, public, err := parsePEMKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s: %w", kid, err)
+		}
+		if private != nil {
+			signers[kid] = private
+			lastPrivate = kid
+		}
+		ks.public[kid] = public
+	}
+
+	if signingKID == "" {
+		signingKID = lastPrivate
+	}
+	signer, ok := signers[signingKID]
+	if !ok {
+		return nil, fmt.Errorf("no private signing key found in %s", dir)
+	}
+	if err := ks.setSigner(signingKID, signer); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// NewEphemeralKeySet generates an in-memory Ed25519 key. Tokens signed with
+// it do not survive a restart, so it is only meant for local development.
+func NewEphemeralKeySet() (*KeySet, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate ed25519 key: %w", err)
+	}
+
+	ks := &KeySet{public: map[string]crypto.PublicKey{"ephemeral": public}}
+	if err := ks.setSigner("ephemeral", private); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+func MustLoadKeySet(dir, signingKID string) *KeySet {
+	ks, err := LoadKeySet(dir, signingKID)
+	if err != nil {
+		panic("jwt keys: " + err.Error())
+	}
+	return ks
+}
+
+func (ks *KeySet) setSigner(kid string, signer crypto.Signer) error {
+	switch signer.(type) {
+	case ed25519.PrivateKey:
+		ks.method = jwt.SigningMethodEdDSA
+	case *rsa.PrivateKey:
+		ks.method = jwt.SigningMethodRS256
+	default:
+		return fmt.Errorf("unsupported signing key type %T", signer)
+	}
+	ks.signingKID = kid
+	ks.signer = signer
+	return nil
+}
+
+func (ks *KeySet) SigningKID() string { return ks.signingKID }
+
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.method, claims)
+	token.Header["kid"] = ks.signingKID
+	return token.SignedString(ks.signer)
+}
+
+func (ks *KeySet) verificationKey(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	key, ok := ks.public[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	switch key.(type) {
+	case ed25519.PublicKey:
+		if _, ok := t.Method.(*jwt.SigningMethodEd25519); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+	case *rsa.PublicKey:
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+	}
+	return key, nil
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+func (ks *KeySet) JWKS() JWKS {
+	kids := make([]string, 0, len(ks.public))
+	for kid := range ks.public {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	set := JWKS{Keys: make([]JWK, 0, len(kids))}
+	for _, kid := range kids {
+		switch key := ks.public[kid].(type) {
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "OKP",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: "EdDSA",
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(key),
+			})
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				KeyType:   "RSA",
+				KeyID:     kid,
+				Use:       "sig",
+				Algorithm: "RS256",
+				N:         base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+	}
+	return set
+}
+
+func parsePEMKey(data []byte) (crypto.Signer, crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("no PEM block found")
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return checkKeyType(signer, signer.Public())
+
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return checkKeyType(key, key.Public())
+
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		return checkKeyType(nil, key)
+
+	default:
+		return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func checkKeyType(private crypto.Signer, public crypto.PublicKey) (crypto.Signer, crypto.PublicKey, error) {
+	switch key := public.(type) {
+	case ed25519.PublicKey:
+		return private, key, nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < 2048 {
+			return nil, nil, errors.New("rsa keys must be at least 2048 bits")
+		}
+		return private, key, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported key type %T", public)
+	}
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadKeySet_RotationKeepsOldTokensValid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldDER, _ := x509.MarshalPKCS8PrivateKey(oldKey)
+	writePEM(t, dir, "2026-01-01.pem", "PRIVATE KEY", oldDER)
+
+	oldSet, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCfg := JWTConfig{Keys: oldSet, AccessTokenTTL: time.Minute}
+	oldToken, err := GenerateAccessToken(oldCfg, uuid.New(), uuid.New(), "a@example.com", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, dir, "2026-06-01.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey))
+
+	newSet, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newSet.SigningKID() != "2026-06-01" {
+		t.Fatalf("want newest key to sign, got %s", newSet.SigningKID())
+	}
+
+	newCfg := JWTConfig{Keys: newSet, AccessTokenTTL: time.Minute}
+	if _, err = ValidateAccessToken(newCfg, oldToken); err != nil {
+		t.Errorf("want token signed by the previous key to verify: %v", err)
+	}
+
+	newToken, err := GenerateAccessToken(newCfg, uuid.New(), uuid.New(), "a@example.com", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ValidateAccessToken(newCfg, newToken); err != nil {
+		t.Errorf("want RS256 token to verify: %v", err)
+	}
+
+	if got := len(newSet.JWKS().Keys); got != 2 {
+		t.Errorf("want 2 keys in JWKS, got %d", got)
+	}
+}
+
+func TestValidateAccessToken_RejectsHMACAndUnknownKid(t *testing.T) {
+	keys, err := NewEphemeralKeySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := JWTConfig{Keys: keys, AccessTokenTTL: time.Minute}
+
+	claims := Claims{
+		UserID: uuid.New(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = keys.SigningKID()
+	signed, err := hmacToken.SignedString([]byte("shared-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ValidateAccessToken(cfg, signed); err == nil {
+		t.Error("want HS256 token to be rejected")
+	}
+
+	other, err := NewEphemeralKeySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other.signingKID = "someone-else"
+	foreign, err := GenerateAccessToken(JWTConfig{Keys: other, AccessTokenTTL: time.Minute}, uuid.New(), uuid.New(), "a@example.com", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ValidateAccessToken(cfg, foreign); err == nil {
+		t.Error("want token with unknown kid to be rejected")
+	}
+}
@@ -38,13 +38,13 @@ func (r *PostgresAuthRepo) Register(ctx context.Context, user *userdomain.User,
 	defer tx.Rollback(ctx)
 
 	userQuery := `
-		INSERT INTO users (id, email, full_name, role, status, email_verified_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, email, full_name, timezone, role, status, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
 	`
 	var createdAt, updatedAt time.Time
 	err = tx.QueryRow(ctx, userQuery,
-		user.ID(), user.Email(), user.FullName(), user.Role(),
+		user.ID(), user.Email(), user.FullName(), user.Timezone(), user.Role(),
 		user.Status(), user.EmailVerifiedAt(), user.CreatedAt(), user.UpdatedAt(),
 	).Scan(&createdAt, &updatedAt)
 	if err != nil {
@@ -82,7 +82,8 @@ func (r *PostgresAuthRepo) Register(ctx context.Context, user *userdomain.User,
 func (r *PostgresAuthRepo) FindCredentialsByUserID(ctx context.Context, userID uuid.UUID) (*authdomain.AuthCredentials, error) {
 	query := `
 		SELECT id, user_id, password_hash,
-		       last_login, failed_attempts, locked_until,
+		       last_login, COALESCE(failed_attempts, 0), locked_until,
+		       COALESCE(mfa_secret, ''), mfa_enabled_at,
 		       created_at, updated_at
 		FROM auth_credentials
 		WHERE user_id = $1
@@ -94,12 +95,15 @@ func (r *PostgresAuthRepo) FindCredentialsByUserID(ctx context.Context, userID u
 		lastLogin      *time.Time
 		failedAttempts int
 		lockedUntil    *time.Time
+		mfaSecret      string
+		mfaEnabledAt   *time.Time
 		createdAt      time.Time
 		updatedAt      time.Time
 	)
 	err := r.db.QueryRow(ctx, query, userID).Scan(
 		&id, &dbUserID, &passwordHash,
 		&lastLogin, &failedAttempts, &lockedUntil,
+		&mfaSecret, &mfaEnabledAt,
 		&createdAt, &updatedAt,
 	)
 	if err != nil {
@@ -111,6 +115,7 @@ func (r *PostgresAuthRepo) FindCredentialsByUserID(ctx context.Context, userID u
 	return authdomain.ReconstitueAuthCredentials(
 		id, dbUserID, passwordHash,
 		lastLogin, failedAttempts, lockedUntil,
+		mfaSecret, mfaEnabledAt,
 		createdAt, updatedAt,
 	), nil
 }
@@ -133,11 +138,11 @@ func (r *PostgresAuthRepo) UpdateLastLogin(ctx context.Context, userID uuid.UUID
 
 func (r *PostgresAuthRepo) SaveRefreshToken(ctx context.Context, token *authdomain.RefreshToken) error {
 	query := `
-		INSERT INTO refresh_tokens (id, user_id, token_hash, expires_at, created_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO refresh_tokens (id, user_id, session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
 	`
 	_, err := r.db.Exec(ctx, query,
-		token.ID(), token.UserID(), token.TokenHash(), token.ExpiresAt(), token.CreatedAt(),
+		token.ID(), token.UserID(), token.SessionID(), token.TokenHash(), token.ExpiresAt(), token.CreatedAt(),
 	)
 	if err != nil {
 		return fmt.Errorf("save refresh token: %w", err)
@@ -147,25 +152,62 @@ func (r *PostgresAuthRepo) SaveRefreshToken(ctx context.Context, token *authdoma
 
 func (r *PostgresAuthRepo) FindRefreshToken(ctx context.Context, tokenHash string) (*authdomain.RefreshToken, error) {
 	query := `
-		SELECT id, user_id, token_hash, expires_at, created_at
+		SELECT id, user_id, session_id, token_hash, expires_at, created_at, rotated_at
 		FROM refresh_tokens
 		WHERE token_hash = $1
 	`
 	var (
 		id        uuid.UUID
 		userID    uuid.UUID
+		sessionID uuid.UUID
 		hash      string
 		expiresAt time.Time
 		createdAt time.Time
+		rotatedAt *time.Time
 	)
-	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&id, &userID, &hash, &expiresAt, &createdAt)
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&id, &userID, &sessionID, &hash, &expiresAt, &createdAt, &rotatedAt)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, authdomain.ErrTokenNotFound
 		}
 		return nil, fmt.Errorf("find refresh token: %w", err)
 	}
-	return authdomain.ReconstitueRefreshToken(id, userID, hash, expiresAt, createdAt), nil
+	return authdomain.ReconstitueRefreshToken(id, userID, sessionID, hash, expiresAt, createdAt, rotatedAt), nil
+}
+
+func (r *PostgresAuthRepo) RotateRefreshToken(ctx context.Context, id uuid.UUID, successor *authdomain.RefreshToken, rotatedAt time.Time) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE refresh_tokens
+		SET    rotated_at = $2
+		WHERE  id = $1
+		  AND  rotated_at IS NULL
+	`
+	tag, err := tx.Exec(ctx, query, id, rotatedAt)
+	if err != nil {
+		return false, fmt.Errorf("mark refresh token rotated: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return false, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO refresh_tokens (id, user_id, session_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, successor.ID(), successor.UserID(), successor.SessionID(), successor.TokenHash(), successor.ExpiresAt(), successor.CreatedAt())
+	if err != nil {
+		return false, fmt.Errorf("save successor refresh token: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("commit transaction: %w", err)
+	}
+	return true, nil
 }
 
 func (r *PostgresAuthRepo) DeleteRefreshToken(ctx context.Context, tokenHash string) error {
@@ -177,8 +219,20 @@ func (r *PostgresAuthRepo) DeleteRefreshToken(ctx context.Context, tokenHash str
 	return nil
 }
 
+func (r *PostgresAuthRepo) DeleteRotatedRefreshTokens(ctx context.Context, sessionID uuid.UUID, rotatedBefore time.Time) error {
+	query := `
+		DELETE FROM refresh_tokens
+		WHERE  session_id = $1
+		  AND  (rotated_at < $2 OR expires_at < NOW())
+	`
+	if _, err := r.db.Exec(ctx, query, sessionID, rotatedBefore); err != nil {
+		return fmt.Errorf("delete rotated refresh tokens: %w", err)
+	}
+	return nil
+}
+
 func (r *PostgresAuthRepo) DeleteAllRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error {
-	query := `DELETE FROM refresh_tokens WHERE user_id = $1`
+	query := `DELETE FROM auth_sessions WHERE user_id = $1`
 	_, err := r.db.Exec(ctx, query, userID)
 	if err != nil {
 		return fmt.Errorf("delete all refresh tokens for user: %w", err)
@@ -186,6 +240,210 @@ func (r *PostgresAuthRepo) DeleteAllRefreshTokensByUserID(ctx context.Context, u
 	return nil
 }
 
+func (r *PostgresAuthRepo) RevokeAccessToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_access_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, query, jti, userID, expiresAt); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`); err != nil {
+		slog.Warn("failed to prune expired revoked access tokens", "error", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE jti = $1)`
+	var revoked bool
+	if err := r.db.QueryRow(ctx, query, jti).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("check revoked access token: %w", err)
+	}
+	return revoked, nil
+}
+
+func (r *PostgresAuthRepo) RevokeUserAccess(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	query := `
+		INSERT INTO user_access_revocations (user_id, revoked_at)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET revoked_at = GREATEST(user_access_revocations.revoked_at, EXCLUDED.revoked_at)
+	`
+	if _, err := r.db.Exec(ctx, query, userID, revokedAt); err != nil {
+		return fmt.Errorf("revoke user access: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) FindUserAccessRevocation(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	query := `SELECT revoked_at FROM user_access_revocations WHERE user_id = $1`
+	var revokedAt time.Time
+	err := r.db.QueryRow(ctx, query, userID).Scan(&revokedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("find user access revocation: %w", err)
+	}
+	return revokedAt, nil
+}
+
+func (r *PostgresAuthRepo) RevokeSessionAccess(ctx context.Context, userID uuid.UUID, sessionIDs []uuid.UUID, expiresAt time.Time) error {
+	query := `
+		INSERT INTO revoked_sessions (session_id, user_id, expires_at)
+		SELECT id, $2, $3 FROM unnest($1::uuid[]) AS id
+		ON CONFLICT (session_id) DO UPDATE
+		SET expires_at = GREATEST(revoked_sessions.expires_at, EXCLUDED.expires_at)
+	`
+	if _, err := r.db.Exec(ctx, query, sessionIDs, userID, expiresAt); err != nil {
+		return fmt.Errorf("revoke session access: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM revoked_sessions WHERE expires_at < NOW()`); err != nil {
+		slog.Warn("failed to prune expired revoked sessions", "error", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) IsSessionAccessRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM revoked_sessions WHERE session_id = $1)`
+	var revoked bool
+	if err := r.db.QueryRow(ctx, query, sessionID).Scan(&revoked); err != nil {
+		return false, fmt.Errorf("check revoked session: %w", err)
+	}
+	return revoked, nil
+}
+
+func (r *PostgresAuthRepo) CreateSession(ctx context.Context, session *authdomain.Session) error {
+	query := `
+		INSERT INTO auth_sessions (id, user_id, device_name, user_agent, ip_address, created_at, last_used_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query,
+		session.ID(), session.UserID(), session.DeviceName(), session.UserAgent(),
+		session.IPAddress(), session.CreatedAt(), session.LastUsedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("create session: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) TouchSession(ctx context.Context, sessionID uuid.UUID, client authdomain.ClientInfo, usedAt time.Time) error {
+	query := `
+		UPDATE auth_sessions
+		SET last_used_at = $2,
+		    user_agent   = COALESCE(NULLIF($3::text, ''), user_agent),
+		    ip_address   = COALESCE(NULLIF($4::text, ''), ip_address),
+		    device_name  = COALESCE(NULLIF($5::text, ''), device_name)
+		WHERE id = $1
+	`
+	tag, err := r.db.Exec(ctx, query, sessionID, usedAt, client.UserAgent, client.IPAddress, client.DeviceName)
+	if err != nil {
+		return fmt.Errorf("touch session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return authdomain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) FindSessionByID(ctx context.Context, sessionID uuid.UUID) (*authdomain.Session, error) {
+	query := `
+		SELECT id, user_id, device_name, user_agent, ip_address, created_at, last_used_at
+		FROM   auth_sessions
+		WHERE  id = $1
+	`
+	var (
+		id         uuid.UUID
+		userID     uuid.UUID
+		deviceName string
+		userAgent  string
+		ipAddress  string
+		createdAt  time.Time
+		lastUsedAt time.Time
+	)
+	err := r.db.QueryRow(ctx, query, sessionID).Scan(&id, &userID, &deviceName, &userAgent, &ipAddress, &createdAt, &lastUsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, authdomain.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("find session: %w", err)
+	}
+	return authdomain.ReconstitueSession(id, userID, deviceName, userAgent, ipAddress, createdAt, lastUsedAt), nil
+}
+
+func (r *PostgresAuthRepo) FindSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*authdomain.Session, error) {
+	query := `
+		SELECT s.id, s.user_id, s.device_name, s.user_agent, s.ip_address, s.created_at, s.last_used_at
+		FROM   auth_sessions s
+		WHERE  s.user_id = $1
+		  AND  EXISTS (
+		           SELECT 1 FROM refresh_tokens t
+		           WHERE  t.session_id = s.id
+		             AND  t.rotated_at IS NULL
+		             AND  t.expires_at > NOW()
+		       )
+		ORDER  BY s.last_used_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*authdomain.Session, 0)
+	for rows.Next() {
+		var (
+			id         uuid.UUID
+			dbUserID   uuid.UUID
+			deviceName string
+			userAgent  string
+			ipAddress  string
+			createdAt  time.Time
+			lastUsedAt time.Time
+		)
+		if err := rows.Scan(&id, &dbUserID, &deviceName, &userAgent, &ipAddress, &createdAt, &lastUsedAt); err != nil {
+			return nil, fmt.Errorf("scan session row: %w", err)
+		}
+		sessions = append(sessions, authdomain.ReconstitueSession(
+			id, dbUserID, deviceName, userAgent, ipAddress, createdAt, lastUsedAt,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate session rows: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *PostgresAuthRepo) DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	query := `DELETE FROM auth_sessions WHERE id = $1 AND user_id = $2`
+	tag, err := r.db.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return fmt.Errorf("delete session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return authdomain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) DeleteOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID) ([]uuid.UUID, error) {
+	query := `DELETE FROM auth_sessions WHERE user_id = $1 AND id <> $2 RETURNING id`
+	rows, err := r.db.Query(ctx, query, userID, keepSessionID)
+	if err != nil {
+		return nil, fmt.Errorf("delete other sessions: %w", err)
+	}
+	deleted, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("delete other sessions: %w", err)
+	}
+	return deleted, nil
+}
+
 func (r *PostgresAuthRepo) SaveEmailVerificationToken(ctx context.Context, token *authdomain.EmailVerificationToken) error {
 	query := `
 		INSERT INTO email_verification_tokens (id, user_id, token_hash, expires_at, created_at)
@@ -270,7 +528,7 @@ func (r *PostgresAuthRepo) MarkEmailVerified(ctx context.Context, userID uuid.UU
 	query := `
 		UPDATE users
 		SET email_verified_at = $2,
-		    status            = 'active',
+		    status            = CASE WHEN status = 'pending' THEN 'active' ELSE status END,
 		    updated_at        = NOW()
 		WHERE id = $1
 	`
@@ -365,3 +623,125 @@ func (r *PostgresAuthRepo) UpdatePassword(ctx context.Context, userID uuid.UUID,
 	}
 	return nil
 }
+
+func (r *PostgresAuthRepo) StartMFAEnrollment(ctx context.Context, userID uuid.UUID, encryptedSecret string, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE auth_credentials
+		SET mfa_secret         = $2,
+		    mfa_enabled_at     = NULL,
+		    mfa_last_used_step = 0,
+		    updated_at         = NOW()
+		WHERE user_id = $1
+		  AND mfa_enabled_at IS NULL
+	`
+	tag, err := tx.Exec(ctx, query, userID, encryptedSecret)
+	if err != nil {
+		return fmt.Errorf("store mfa secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return authdomain.ErrMFAAlreadyEnabled
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete old recovery codes: %w", err)
+	}
+
+	for _, hash := range recoveryCodeHashes {
+		if _, err = tx.Exec(ctx,
+			`INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2)`,
+			userID, hash,
+		); err != nil {
+			return fmt.Errorf("insert recovery code: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) EnableMFA(ctx context.Context, userID uuid.UUID, enabledAt time.Time) error {
+	query := `
+		UPDATE auth_credentials
+		SET mfa_enabled_at = $2,
+		    updated_at     = NOW()
+		WHERE user_id = $1
+		  AND mfa_secret IS NOT NULL
+		  AND mfa_enabled_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID, enabledAt)
+	if err != nil {
+		return fmt.Errorf("enable mfa: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return authdomain.ErrMFANotEnrolled
+	}
+	return nil
+}
+
+func (r *PostgresAuthRepo) DisableMFA(ctx context.Context, userID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE auth_credentials
+		SET mfa_secret         = NULL,
+		    mfa_enabled_at     = NULL,
+		    mfa_last_used_step = 0,
+		    updated_at         = NOW()
+		WHERE user_id = $1
+	`
+	if _, err = tx.Exec(ctx, query, userID); err != nil {
+		return fmt.Errorf("clear mfa secret: %w", err)
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("delete recovery codes: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// AdvanceMFAStep records the TOTP step that was just used. It reports false
+// when that step (or a later one) was already consumed, which means the code
+// is being replayed.
+func (r *PostgresAuthRepo) AdvanceMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE auth_credentials
+		SET mfa_last_used_step = $2
+		WHERE user_id = $1
+		  AND mfa_last_used_step < $2
+	`
+	tag, err := r.db.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("advance mfa step: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresAuthRepo) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE mfa_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id   = $1
+		  AND code_hash = $2
+		  AND used_at IS NULL
+	`
+	tag, err := r.db.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("consume recovery code: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
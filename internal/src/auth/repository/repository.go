@@ -23,10 +23,44 @@ type AuthRepository interface {
 
 	FindRefreshToken(ctx context.Context, tokenHash string) (*authdomain.RefreshToken, error)
 
+	// RotateRefreshToken marks the token rotated and saves its successor in
+	// one transaction. It reports false, saving nothing, when another
+	// request rotated the token first.
+	RotateRefreshToken(ctx context.Context, id uuid.UUID, successor *authdomain.RefreshToken, rotatedAt time.Time) (bool, error)
+
 	DeleteRefreshToken(ctx context.Context, tokenHash string) error
 
+	// DeleteRotatedRefreshTokens removes the session's tokens rotated
+	// before the cutoff, and any that have expired.
+	DeleteRotatedRefreshTokens(ctx context.Context, sessionID uuid.UUID, rotatedBefore time.Time) error
+
 	DeleteAllRefreshTokensByUserID(ctx context.Context, userID uuid.UUID) error
 
+	RevokeAccessToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error
+
+	IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+
+	RevokeUserAccess(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+
+	FindUserAccessRevocation(ctx context.Context, userID uuid.UUID) (time.Time, error)
+
+	RevokeSessionAccess(ctx context.Context, userID uuid.UUID, sessionIDs []uuid.UUID, expiresAt time.Time) error
+
+	IsSessionAccessRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+
+	CreateSession(ctx context.Context, session *authdomain.Session) error
+
+	TouchSession(ctx context.Context, sessionID uuid.UUID, client authdomain.ClientInfo, usedAt time.Time) error
+
+	FindSessionByID(ctx context.Context, sessionID uuid.UUID) (*authdomain.Session, error)
+
+	FindSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*authdomain.Session, error)
+
+	DeleteSession(ctx context.Context, userID, sessionID uuid.UUID) error
+
+	// DeleteOtherSessions returns the IDs of the sessions it deleted.
+	DeleteOtherSessions(ctx context.Context, userID, keepSessionID uuid.UUID) ([]uuid.UUID, error)
+
 	SaveEmailVerificationToken(ctx context.Context, token *authdomain.EmailVerificationToken) error
 
 	FindEmailVerificationToken(ctx context.Context, tokenHash string) (*authdomain.EmailVerificationToken, error)
@@ -46,4 +80,14 @@ type AuthRepository interface {
 	DeletePasswordResetToken(ctx context.Context, tokenHash string) error
 
 	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+
+	StartMFAEnrollment(ctx context.Context, userID uuid.UUID, encryptedSecret string, recoveryCodeHashes []string) error
+
+	EnableMFA(ctx context.Context, userID uuid.UUID, enabledAt time.Time) error
+
+	DisableMFA(ctx context.Context, userID uuid.UUID) error
+
+	AdvanceMFAStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
 }
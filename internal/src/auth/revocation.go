@@ -0,0 +1,253 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevocationStore is the durable denylist shared by every API instance.
+type RevocationStore interface {
+	RevokeAccessToken(ctx context.Context, jti, userID uuid.UUID, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error)
+	RevokeUserAccess(ctx context.Context, userID uuid.UUID, revokedAt time.Time) error
+	FindUserAccessRevocation(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	RevokeSessionAccess(ctx context.Context, userID uuid.UUID, sessionIDs []uuid.UUID, expiresAt time.Time) error
+	IsSessionAccessRevoked(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+type cachedTokenRevocation struct {
+	revoked   bool
+	expiresAt time.Time
+	checkedAt time.Time
+}
+
+type cachedUserRevocation struct {
+	revokedAt time.Time
+	checkedAt time.Time
+}
+
+// RevocationList answers "is this access token still allowed?" from an
+// in-process cache in front of the store. Revocations made through this
+// instance take effect immediately; ones made elsewhere are picked up once
+// the cached answer is older than ttl.
+type RevocationList struct {
+	store RevocationStore
+	ttl   time.Duration
+
+	mu       sync.Mutex
+	tokens   map[uuid.UUID]cachedTokenRevocation
+	sessions map[uuid.UUID]cachedTokenRevocation
+	users    map[uuid.UUID]cachedUserRevocation
+}
+
+func NewRevocationList(store RevocationStore, ttl time.Duration) *RevocationList {
+	l := &RevocationList{
+		store:    store,
+		ttl:      ttl,
+		tokens:   make(map[uuid.UUID]cachedTokenRevocation),
+		sessions: make(map[uuid.UUID]cachedTokenRevocation),
+		users:    make(map[uuid.UUID]cachedUserRevocation),
+	}
+
+	go l.cleanup()
+	return l
+}
+
+func (l *RevocationList) RevokeToken(ctx context.Context, claims *Claims) error {
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return errors.New("token has no jti")
+	}
+
+	expiresAt := time.Now().UTC().Add(time.Hour)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	if err := l.store.RevokeAccessToken(ctx, jti, claims.UserID, expiresAt); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+
+	l.mu.Lock()
+	l.tokens[jti] = cachedTokenRevocation{revoked: true, expiresAt: expiresAt, checkedAt: time.Now()}
+	l.mu.Unlock()
+	return nil
+}
+
+// RevokeUser invalidates every access token issued to userID up to now.
+// The revocation time is stored at full precision; see userCutoff for how
+// it is compared with whole-second issue times.
+func (l *RevocationList) RevokeUser(ctx context.Context, userID uuid.UUID) error {
+	revokedAt := time.Now().UTC()
+
+	if err := l.store.RevokeUserAccess(ctx, userID, revokedAt); err != nil {
+		return fmt.Errorf("revoke user access: %w", err)
+	}
+
+	l.mu.Lock()
+	l.users[userID] = cachedUserRevocation{revokedAt: revokedAt, checkedAt: time.Now()}
+	l.mu.Unlock()
+	return nil
+}
+
+// RevokeSessions invalidates every access token issued to the sessions.
+// expiresAt only has to outlive the longest-lived of those tokens, since a
+// deleted session cannot be refreshed into new ones.
+func (l *RevocationList) RevokeSessions(ctx context.Context, userID uuid.UUID, sessionIDs []uuid.UUID, expiresAt time.Time) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+	if err := l.store.RevokeSessionAccess(ctx, userID, sessionIDs, expiresAt); err != nil {
+		return fmt.Errorf("revoke session access: %w", err)
+	}
+
+	l.mu.Lock()
+	for _, sessionID := range sessionIDs {
+		l.sessions[sessionID] = cachedTokenRevocation{revoked: true, expiresAt: expiresAt, checkedAt: time.Now()}
+	}
+	l.mu.Unlock()
+	return nil
+}
+
+func (l *RevocationList) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	revokedAt, err := l.userRevokedAt(ctx, claims.UserID)
+	if err != nil {
+		return false, err
+	}
+	if !revokedAt.IsZero() {
+		if claims.IssuedAt == nil || claims.IssuedAt.Time.Before(userCutoff(revokedAt)) {
+			return true, nil
+		}
+	}
+
+	if claims.SessionID != uuid.Nil {
+		revoked, err := l.cachedRevocation(ctx, l.sessions, claims.SessionID, l.store.IsSessionAccessRevoked)
+		if err != nil {
+			return false, fmt.Errorf("check session revocation: %w", err)
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	if claims.ID == "" {
+		return false, nil
+	}
+	jti, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return true, nil
+	}
+	return l.tokenRevoked(ctx, jti)
+}
+
+// IssueTime returns the issue time for a token issued to userID now. A
+// token issued in the same second as a user revocation, after it, is dated
+// to the cutoff so that its whole-second issue time does not read as
+// before the revocation. The store is asked directly: a token dated from a
+// stale cache would be refused for its whole lifetime.
+func (l *RevocationList) IssueTime(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	revokedAt, err := l.store.FindUserAccessRevocation(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("find user access revocation: %w", err)
+	}
+
+	l.mu.Lock()
+	l.users[userID] = cachedUserRevocation{revokedAt: revokedAt, checkedAt: time.Now()}
+	l.mu.Unlock()
+
+	now := time.Now().UTC()
+	if cutoff := userCutoff(revokedAt); !revokedAt.IsZero() && now.Before(cutoff) {
+		return cutoff, nil
+	}
+	return now, nil
+}
+
+// userCutoff is the earliest issue time a token can carry and outlive a
+// user revocation: the revocation time rounded up to the next second,
+// since JWT issue times are whole seconds.
+func userCutoff(revokedAt time.Time) time.Time {
+	return revokedAt.Truncate(time.Second).Add(time.Second)
+}
+
+func (l *RevocationList) userRevokedAt(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	l.mu.Lock()
+	cached, ok := l.users[userID]
+	l.mu.Unlock()
+	if ok && time.Since(cached.checkedAt) < l.ttl {
+		return cached.revokedAt, nil
+	}
+
+	revokedAt, err := l.store.FindUserAccessRevocation(ctx, userID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("find user access revocation: %w", err)
+	}
+
+	l.mu.Lock()
+	l.users[userID] = cachedUserRevocation{revokedAt: revokedAt, checkedAt: time.Now()}
+	l.mu.Unlock()
+	return revokedAt, nil
+}
+
+func (l *RevocationList) tokenRevoked(ctx context.Context, jti uuid.UUID) (bool, error) {
+	revoked, err := l.cachedRevocation(ctx, l.tokens, jti, l.store.IsAccessTokenRevoked)
+	if err != nil {
+		return false, fmt.Errorf("check access token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// cachedRevocation answers from cache, where a revocation sticks until it
+// expires and a clean answer is trusted for ttl, and asks the store
+// otherwise.
+func (l *RevocationList) cachedRevocation(
+	ctx context.Context,
+	cache map[uuid.UUID]cachedTokenRevocation,
+	id uuid.UUID,
+	lookup func(context.Context, uuid.UUID) (bool, error),
+) (bool, error) {
+	l.mu.Lock()
+	cached, ok := cache[id]
+	l.mu.Unlock()
+	if ok && (cached.revoked || time.Since(cached.checkedAt) < l.ttl) {
+		return cached.revoked, nil
+	}
+
+	revoked, err := lookup(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	l.mu.Lock()
+	cache[id] = cachedTokenRevocation{revoked: revoked, checkedAt: time.Now()}
+	l.mu.Unlock()
+	return revoked, nil
+}
+
+func (l *RevocationList) cleanup() {
+	for {
+		time.Sleep(time.Minute)
+		now := time.Now()
+		l.mu.Lock()
+		for _, cache := range []map[uuid.UUID]cachedTokenRevocation{l.tokens, l.sessions} {
+			for id, entry := range cache {
+				if entry.revoked && now.Before(entry.expiresAt) {
+					continue
+				}
+				if now.Sub(entry.checkedAt) > l.ttl {
+					delete(cache, id)
+				}
+			}
+		}
+		for userID, entry := range l.users {
+			if now.Sub(entry.checkedAt) > l.ttl {
+				delete(l.users, userID)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+type fakeRevocationStore struct {
+	tokens   map[uuid.UUID]bool
+	sessions map[uuid.UUID]bool
+	users    map[uuid.UUID]time.Time
+}
+
+func newFakeRevocationStore() *fakeRevocationStore {
+	return &fakeRevocationStore{
+		tokens:   map[uuid.UUID]bool{},
+		sessions: map[uuid.UUID]bool{},
+		users:    map[uuid.UUID]time.Time{},
+	}
+}
+
+func (f *fakeRevocationStore) RevokeAccessToken(_ context.Context, jti, _ uuid.UUID, _ time.Time) error {
+	f.tokens[jti] = true
+	return nil
+}
+
+func (f *fakeRevocationStore) IsAccessTokenRevoked(_ context.Context, jti uuid.UUID) (bool, error) {
+	return f.tokens[jti], nil
+}
+
+func (f *fakeRevocationStore) RevokeUserAccess(_ context.Context, userID uuid.UUID, revokedAt time.Time) error {
+	f.users[userID] = revokedAt
+	return nil
+}
+
+func (f *fakeRevocationStore) FindUserAccessRevocation(_ context.Context, userID uuid.UUID) (time.Time, error) {
+	return f.users[userID], nil
+}
+
+func (f *fakeRevocationStore) RevokeSessionAccess(_ context.Context, _ uuid.UUID, sessionIDs []uuid.UUID, _ time.Time) error {
+	for _, id := range sessionIDs {
+		f.sessions[id] = true
+	}
+	return nil
+}
+
+func (f *fakeRevocationStore) IsSessionAccessRevoked(_ context.Context, sessionID uuid.UUID) (bool, error) {
+	return f.sessions[sessionID], nil
+}
+
+func TestRevocationList_RevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	list := NewRevocationList(newFakeRevocationStore(), time.Minute)
+
+	userID := uuid.New()
+	signedOut, kept := uuid.New(), uuid.New()
+	claimsFor := func(sessionID uuid.UUID) *Claims {
+		return &Claims{
+			UserID:    userID,
+			SessionID: sessionID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:       uuid.NewString(),
+				IssuedAt: jwt.NewNumericDate(time.Now()),
+			},
+		}
+	}
+
+	if err := list.RevokeSessions(ctx, userID, []uuid.UUID{signedOut}, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("RevokeSessions: %v", err)
+	}
+
+	if revoked, err := list.IsRevoked(ctx, claimsFor(signedOut)); err != nil || !revoked {
+		t.Errorf("token of a signed-out session: revoked = %v, err = %v, want true", revoked, err)
+	}
+	if revoked, err := list.IsRevoked(ctx, claimsFor(kept)); err != nil || revoked {
+		t.Errorf("token of another session: revoked = %v, err = %v, want false", revoked, err)
+	}
+}
+
+func TestRevocationList_RevokeUserKeepsTokensIssuedAfterInTheSameSecond(t *testing.T) {
+	ctx := context.Background()
+	store := newFakeRevocationStore()
+	list := NewRevocationList(store, time.Minute)
+	userID := uuid.New()
+
+	// Claims carry whole seconds once signed and parsed.
+	claimsAt := func(issuedAt time.Time) *Claims {
+		return &Claims{
+			UserID: userID,
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:       uuid.NewString(),
+				IssuedAt: jwt.NewNumericDate(issuedAt.Truncate(time.Second)),
+			},
+		}
+	}
+
+	before := time.Now().UTC()
+	if err := list.RevokeUser(ctx, userID); err != nil {
+		t.Fatalf("RevokeUser: %v", err)
+	}
+	issuedAt, err := list.IssueTime(ctx, userID)
+	if err != nil {
+		t.Fatalf("IssueTime: %v", err)
+	}
+
+	if revoked, err := list.IsRevoked(ctx, claimsAt(before)); err != nil || !revoked {
+		t.Errorf("token issued before the revocation: revoked = %v, err = %v, want true", revoked, err)
+	}
+	if revoked, err := list.IsRevoked(ctx, claimsAt(issuedAt)); err != nil || revoked {
+		t.Errorf("token issued after the revocation: revoked = %v, err = %v, want false", revoked, err)
+	}
+	if issuedAt.Sub(store.users[userID]) > time.Second {
+		t.Errorf("want the issue time within a second of the revocation, got %v after", issuedAt.Sub(store.users[userID]))
+	}
+}
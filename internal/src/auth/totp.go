@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpDigits    = 6
+	totpPeriod    = 30
+	totpSkewSteps = 1
+
+	recoveryCodeCount  = 10
+	recoveryCodeLength = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+func TOTPURI(issuer, account, secret string) string {
+	label := url.PathEscape(issuer + ":" + account)
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// ValidateTOTP checks code against the steps around now and returns the
+// matching step so callers can reject replays of an already used code.
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for offset := int64(-totpSkewSteps); offset <= totpSkewSteps; offset++ {
+		step := current + offset
+		if hmac.Equal([]byte(totpCode(key, step)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// GenerateRecoveryCodes returns codes formatted for display ("abcde-fghij")
+// together with their hashes for storage.
+func GenerateRecoveryCodes() (codes, hashes []string, err error) {
+	alphabet := "abcdefghjkmnpqrstuvwxyz23456789"
+	codes = make([]string, 0, recoveryCodeCount)
+	hashes = make([]string, 0, recoveryCodeCount)
+
+	// Bytes at or above the largest multiple of the alphabet size are
+	// redrawn, so every character is equally likely.
+	limit := byte(256 - 256%len(alphabet))
+	buf := make([]byte, recoveryCodeLength)
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 0, recoveryCodeLength)
+		for len(b) < recoveryCodeLength {
+			if _, err = rand.Read(buf); err != nil {
+				return nil, nil, fmt.Errorf("generate random bytes: %w", err)
+			}
+			for _, c := range buf {
+				if c < limit && len(b) < recoveryCodeLength {
+					b = append(b, alphabet[int(c)%len(alphabet)])
+				}
+			}
+		}
+		code := string(b[:recoveryCodeLength/2]) + "-" + string(b[recoveryCodeLength/2:])
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.TrimSpace(code))
+	normalized = strings.ReplaceAll(normalized, "-", "")
+	normalized = strings.ReplaceAll(normalized, " ", "")
+	return HashToken(normalized)
+}
+
+// EncryptTOTPSecret seals the secret with AES-GCM. Unlike the other tokens
+// the TOTP secret has to be recoverable, so it cannot simply be hashed.
+func EncryptTOTPSecret(cfg JWTConfig, secret string) (string, error) {
+	gcm, err := totpCipher(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func DecryptTOTPSecret(cfg JWTConfig, encrypted string) (string, error) {
+	gcm, err := totpCipher(cfg)
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil {
+		return "", fmt.Errorf("decode totp secret: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("totp secret is too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		legacy, legacyErr := legacyTOTPCipher(cfg)
+		if legacyErr != nil {
+			return "", legacyErr
+		}
+		if plain, legacyErr = legacy.Open(nil, nonce, ciphertext, nil); legacyErr != nil {
+			return "", fmt.Errorf("decrypt totp secret: %w", err)
+		}
+	}
+	return string(plain), nil
+}
+
+func totpCipher(cfg JWTConfig) (cipher.AEAD, error) {
+	return totpCipherFromKey(deriveKey(cfg.MFAKey, "totp-secret"))
+}
+
+// legacyTOTPCipher opens secrets sealed before MFA_ENCRYPTION_KEY existed,
+// when the key was derived from the JWT secret.
+func legacyTOTPCipher(cfg JWTConfig) (cipher.AEAD, error) {
+	return totpCipherFromKey(deriveKey(cfg.Secret, "totp-secret"))
+}
+
+func totpCipherFromKey(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create totp cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create totp gcm: %w", err)
+	}
+	return gcm, nil
+}
+
+func deriveKey(secret []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
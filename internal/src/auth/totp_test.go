@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// Vectors from RFC 6238 appendix B (SHA-1), truncated to six digits.
+func TestValidateTOTP_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range tests {
+		step, ok := ValidateTOTP(secret, tc.code, time.Unix(tc.unix, 0))
+		if !ok {
+			t.Errorf("t=%d: code %s rejected", tc.unix, tc.code)
+			continue
+		}
+		if want := tc.unix / totpPeriod; step != want {
+			t.Errorf("t=%d: want step %d, got %d", tc.unix, want, step)
+		}
+	}
+}
+
+func TestValidateTOTP_RejectsCodesOutsideSkew(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+	if _, ok := ValidateTOTP(secret, "287082", time.Unix(59+3*totpPeriod, 0)); ok {
+		t.Error("want code from three steps ago to be rejected")
+	}
+	if _, ok := ValidateTOTP(secret, "28708", time.Unix(59, 0)); ok {
+		t.Error("want short code to be rejected")
+	}
+}
+
+func TestTOTPSecret_EncryptRoundTrip(t *testing.T) {
+	cfg := JWTConfig{Secret: []byte("test-secret"), MFAKey: []byte("test-mfa-key")}
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := EncryptTOTPSecret(cfg, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decrypted, err := DecryptTOTPSecret(cfg, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decrypted != secret {
+		t.Errorf("want %s, got %s", secret, decrypted)
+	}
+
+	if _, err = DecryptTOTPSecret(JWTConfig{Secret: []byte("other"), MFAKey: []byte("other")}, encrypted); err == nil {
+		t.Error("want decryption with a different key to fail")
+	}
+}
+
+func TestHashRecoveryCode_NormalisesInput(t *testing.T) {
+	if HashRecoveryCode("abcde-fghjk") != HashRecoveryCode(" ABCDE FGHJK ") {
+		t.Error("want formatting differences to hash identically")
+	}
+}
+
+func TestGenerateRecoveryCodes_Shape(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("want %d codes and hashes, got %d and %d", recoveryCodeCount, len(codes), len(hashes))
+	}
+	for i, code := range codes {
+		if len(code) != recoveryCodeLength+1 || code[recoveryCodeLength/2] != '-' {
+			t.Errorf("code %q is not formatted as xxxxx-xxxxx", code)
+		}
+		if hashes[i] != HashRecoveryCode(code) {
+			t.Errorf("hash %d does not match its code", i)
+		}
+	}
+}
+
+func TestDecryptTOTPSecret_ReadsSecretsSealedWithJWTSecret(t *testing.T) {
+	legacy := JWTConfig{Secret: []byte("jwt-secret"), MFAKey: []byte("jwt-secret")}
+	sealed, err := EncryptTOTPSecret(legacy, "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	current := JWTConfig{Secret: []byte("jwt-secret"), MFAKey: []byte("mfa-key")}
+	plain, err := DecryptTOTPSecret(current, sealed)
+	if err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("decrypt = %q, %v, want the legacy secret", plain, err)
+	}
+
+	rotated := JWTConfig{Secret: []byte("new-jwt-secret"), MFAKey: []byte("mfa-key")}
+	resealed, err := EncryptTOTPSecret(current, "JBSWY3DPEHPK3PXP")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	if plain, err = DecryptTOTPSecret(rotated, resealed); err != nil || plain != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("decrypt after JWT secret rotation = %q, %v", plain, err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"golang.org/x/crypto/bcrypt"
 
+	"saythis-backend/internal/src/auth"
 	authdomain "saythis-backend/internal/src/auth/domain"
 	userdomain "saythis-backend/internal/src/user/domain"
 )
@@ -24,41 +25,43 @@ func init() {
 	dummyHash = h
 }
 
-func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*userdomain.User, authdomain.TokenPair, error) {
+// Login returns a token pair, or an MFA challenge instead when the account
+// has two-factor authentication enabled.
+func (uc *AuthUseCase) Login(ctx context.Context, email, password string, client authdomain.ClientInfo) (*userdomain.User, authdomain.TokenPair, *authdomain.MFAChallenge, error) {
 
 	email = strings.ToLower(strings.TrimSpace(email))
 	if email == "" {
-		return nil, authdomain.TokenPair{}, userdomain.ErrEmptyEmail
+		return nil, authdomain.TokenPair{}, nil, userdomain.ErrEmptyEmail
 	}
 	if strings.TrimSpace(password) == "" {
-		return nil, authdomain.TokenPair{}, authdomain.ErrEmptyPassword
+		return nil, authdomain.TokenPair{}, nil, authdomain.ErrEmptyPassword
 	}
 
 	user, err := uc.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, userdomain.ErrUserNotFound) {
 			_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
-			return nil, authdomain.TokenPair{}, authdomain.ErrInvalidCredentials
+			return nil, authdomain.TokenPair{}, nil, authdomain.ErrInvalidCredentials
 		}
-		return nil, authdomain.TokenPair{}, fmt.Errorf("lookup user: %w", err)
+		return nil, authdomain.TokenPair{}, nil, fmt.Errorf("lookup user: %w", err)
 	}
 
 	switch user.Status() {
 	case userdomain.StatusSuspended:
-		return nil, authdomain.TokenPair{}, authdomain.ErrAccountSuspended
+		return nil, authdomain.TokenPair{}, nil, authdomain.ErrAccountSuspended
 
 	case userdomain.StatusDeleted:
 		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
-		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidCredentials
+		return nil, authdomain.TokenPair{}, nil, authdomain.ErrInvalidCredentials
 	}
 
 	creds, err := uc.authRepo.FindCredentialsByUserID(ctx, user.ID())
 	if err != nil {
-		return nil, authdomain.TokenPair{}, fmt.Errorf("fetch credentials: %w", err)
+		return nil, authdomain.TokenPair{}, nil, fmt.Errorf("fetch credentials: %w", err)
 	}
 
 	if creds.IsLocked() {
-		return nil, authdomain.TokenPair{}, authdomain.ErrAccountLocked
+		return nil, authdomain.TokenPair{}, nil, authdomain.ErrAccountLocked
 	}
 
 	if err = bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash()), []byte(password)); err != nil {
@@ -68,7 +71,16 @@ func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*user
 				"error", recErr,
 			)
 		}
-		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidCredentials
+		return nil, authdomain.TokenPair{}, nil, authdomain.ErrInvalidCredentials
+	}
+
+	if creds.MFAEnabled() {
+		token, expiresAt, err := auth.GenerateMFAChallengeToken(uc.jwtCfg, user.ID())
+		if err != nil {
+			return nil, authdomain.TokenPair{}, nil, fmt.Errorf("generate mfa challenge: %w", err)
+		}
+		slog.Info("login: mfa challenge issued", "user_id", user.ID())
+		return user, authdomain.TokenPair{}, &authdomain.MFAChallenge{Token: token, ExpiresAt: expiresAt}, nil
 	}
 
 	now := time.Now().UTC()
@@ -79,12 +91,12 @@ func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*user
 		)
 	}
 
-	tokens, err := uc.issueTokenPair(ctx, user)
+	tokens, err := uc.startSession(ctx, user, client)
 	if err != nil {
-		return nil, authdomain.TokenPair{}, err
+		return nil, authdomain.TokenPair{}, nil, err
 	}
 
 	slog.Info("user logged in", "user_id", user.ID(), "email", user.Email())
 
-	return user, tokens, nil
+	return user, tokens, nil, nil
 }
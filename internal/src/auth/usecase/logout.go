@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/src/auth"
+	authdomain "saythis-backend/internal/src/auth/domain"
+)
+
+func (uc *AuthUseCase) Logout(ctx context.Context, claims *auth.Claims, plaintextRefreshToken string) error {
+	if claims.ID != "" {
+		if err := uc.revocations.RevokeToken(ctx, claims); err != nil {
+			return fmt.Errorf("logout: %w", err)
+		}
+	}
+
+	// Tokens issued before sessions existed carry no sid, so their session
+	// can only be found through the refresh token.
+	sessionID := claims.SessionID
+	if sessionID == uuid.Nil && strings.TrimSpace(plaintextRefreshToken) != "" {
+		stored, err := uc.authRepo.FindRefreshToken(ctx, auth.HashRefreshToken(plaintextRefreshToken))
+		if err == nil && stored.UserID() == claims.UserID {
+			sessionID = stored.SessionID()
+		}
+	}
+
+	if sessionID != uuid.Nil {
+		if err := uc.authRepo.DeleteSession(ctx, claims.UserID, sessionID); err != nil && !errors.Is(err, authdomain.ErrSessionNotFound) {
+			slog.Warn("logout: failed to delete session",
+				"user_id", claims.UserID,
+				"session_id", sessionID,
+				"error", err,
+			)
+		}
+		if err := uc.revokeSessionAccess(ctx, claims.UserID, sessionID); err != nil {
+			return fmt.Errorf("logout: %w", err)
+		}
+	}
+
+	slog.Info("user logged out", "user_id", claims.UserID, "session_id", sessionID)
+	return nil
+}
@@ -0,0 +1,226 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+
+	"saythis-backend/internal/src/auth"
+	authdomain "saythis-backend/internal/src/auth/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+const totpIssuer = "SayThis"
+
+func (uc *AuthUseCase) EnrollMFA(ctx context.Context, userID uuid.UUID) (authdomain.MFAEnrollment, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return authdomain.MFAEnrollment{}, fmt.Errorf("get user for mfa enrollment: %w", err)
+	}
+
+	creds, err := uc.authRepo.FindCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return authdomain.MFAEnrollment{}, fmt.Errorf("fetch credentials: %w", err)
+	}
+	if creds.MFAEnabled() {
+		return authdomain.MFAEnrollment{}, authdomain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return authdomain.MFAEnrollment{}, fmt.Errorf("generate totp secret: %w", err)
+	}
+	encrypted, err := auth.EncryptTOTPSecret(uc.jwtCfg, secret)
+	if err != nil {
+		return authdomain.MFAEnrollment{}, fmt.Errorf("encrypt totp secret: %w", err)
+	}
+
+	codes, hashes, err := auth.GenerateRecoveryCodes()
+	if err != nil {
+		return authdomain.MFAEnrollment{}, fmt.Errorf("generate recovery codes: %w", err)
+	}
+
+	if err = uc.authRepo.StartMFAEnrollment(ctx, userID, encrypted, hashes); err != nil {
+		if errors.Is(err, authdomain.ErrMFAAlreadyEnabled) {
+			return authdomain.MFAEnrollment{}, err
+		}
+		return authdomain.MFAEnrollment{}, fmt.Errorf("start mfa enrollment: %w", err)
+	}
+
+	return authdomain.MFAEnrollment{
+		Secret:        secret,
+		OTPAuthURI:    auth.TOTPURI(totpIssuer, user.Email(), secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (uc *AuthUseCase) ConfirmMFA(ctx context.Context, userID uuid.UUID, code string) error {
+	creds, err := uc.authRepo.FindCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("fetch credentials: %w", err)
+	}
+	if creds.MFAEnabled() {
+		return authdomain.ErrMFAAlreadyEnabled
+	}
+	if !creds.MFAPending() {
+		return authdomain.ErrMFANotEnrolled
+	}
+
+	if err = uc.verifyTOTP(ctx, creds, code); err != nil {
+		return err
+	}
+
+	if err = uc.authRepo.EnableMFA(ctx, userID, time.Now().UTC()); err != nil {
+		if errors.Is(err, authdomain.ErrMFANotEnrolled) {
+			return err
+		}
+		return fmt.Errorf("enable mfa: %w", err)
+	}
+
+	slog.Info("mfa enabled", "user_id", userID)
+	return nil
+}
+
+func (uc *AuthUseCase) DisableMFA(ctx context.Context, userID uuid.UUID, password, code string) error {
+	creds, err := uc.authRepo.FindCredentialsByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("fetch credentials: %w", err)
+	}
+	if !creds.MFAEnabled() {
+		return authdomain.ErrMFANotEnabled
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash()), []byte(password)); err != nil {
+		return authdomain.ErrInvalidCredentials
+	}
+	if err = uc.verifySecondFactor(ctx, creds, code); err != nil {
+		return err
+	}
+
+	if err = uc.authRepo.DisableMFA(ctx, userID); err != nil {
+		return fmt.Errorf("disable mfa: %w", err)
+	}
+
+	slog.Info("mfa disabled", "user_id", userID)
+	return nil
+}
+
+// CompleteMFALogin is the second step of Login for accounts with MFA
+// enabled. Wrong codes count towards the same lockout as wrong passwords.
+func (uc *AuthUseCase) CompleteMFALogin(ctx context.Context, mfaToken, code string, client authdomain.ClientInfo) (*userdomain.User, authdomain.TokenPair, error) {
+	claims, err := auth.ValidateMFAChallengeToken(uc.jwtCfg, mfaToken)
+	if err != nil {
+		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidToken
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidToken
+	}
+
+	switch user.Status() {
+	case userdomain.StatusSuspended:
+		return nil, authdomain.TokenPair{}, authdomain.ErrAccountSuspended
+
+	case userdomain.StatusDeleted:
+		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidToken
+	}
+
+	creds, err := uc.authRepo.FindCredentialsByUserID(ctx, user.ID())
+	if err != nil {
+		return nil, authdomain.TokenPair{}, fmt.Errorf("fetch credentials: %w", err)
+	}
+	if creds.IsLocked() {
+		return nil, authdomain.TokenPair{}, authdomain.ErrAccountLocked
+	}
+	if !creds.MFAEnabled() {
+		return nil, authdomain.TokenPair{}, authdomain.ErrInvalidToken
+	}
+
+	if err = uc.verifySecondFactor(ctx, creds, code); err != nil {
+		if errors.Is(err, authdomain.ErrInvalidMFACode) {
+			if recErr := uc.authRepo.RecordFailedAttempt(ctx, user.ID()); recErr != nil {
+				slog.Warn("mfa_login: failed to record failed attempt",
+					"user_id", user.ID(),
+					"error", recErr,
+				)
+			}
+		}
+		return nil, authdomain.TokenPair{}, err
+	}
+
+	if err = uc.authRepo.UpdateLastLogin(ctx, user.ID(), time.Now().UTC()); err != nil {
+		slog.Warn("mfa_login: failed to record successful login",
+			"user_id", user.ID(),
+			"error", err,
+		)
+	}
+
+	tokens, err := uc.startSession(ctx, user, client)
+	if err != nil {
+		return nil, authdomain.TokenPair{}, err
+	}
+
+	slog.Info("user logged in with mfa", "user_id", user.ID(), "email", user.Email())
+
+	return user, tokens, nil
+}
+
+// verifySecondFactor accepts either a current TOTP code or an unused
+// recovery code.
+func (uc *AuthUseCase) verifySecondFactor(ctx context.Context, creds *authdomain.AuthCredentials, code string) error {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return authdomain.ErrInvalidMFACode
+	}
+	if isNumeric(code) {
+		return uc.verifyTOTP(ctx, creds, code)
+	}
+
+	consumed, err := uc.authRepo.ConsumeRecoveryCode(ctx, creds.UserID(), auth.HashRecoveryCode(code))
+	if err != nil {
+		return fmt.Errorf("consume recovery code: %w", err)
+	}
+	if !consumed {
+		return authdomain.ErrInvalidMFACode
+	}
+
+	slog.Info("mfa recovery code used", "user_id", creds.UserID())
+	return nil
+}
+
+func (uc *AuthUseCase) verifyTOTP(ctx context.Context, creds *authdomain.AuthCredentials, code string) error {
+	secret, err := auth.DecryptTOTPSecret(uc.jwtCfg, creds.MFASecret())
+	if err != nil {
+		return fmt.Errorf("decrypt totp secret: %w", err)
+	}
+
+	step, ok := auth.ValidateTOTP(secret, code, time.Now().UTC())
+	if !ok {
+		return authdomain.ErrInvalidMFACode
+	}
+
+	fresh, err := uc.authRepo.AdvanceMFAStep(ctx, creds.UserID(), step)
+	if err != nil {
+		return fmt.Errorf("advance mfa step: %w", err)
+	}
+	if !fresh {
+		return authdomain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -2,14 +2,23 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"time"
 
 	"saythis-backend/internal/src/auth"
 	authdomain "saythis-backend/internal/src/auth/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
 )
 
-func (uc *AuthUseCase) Refresh(ctx context.Context, plaintextToken string) (authdomain.TokenPair, error) {
+// refreshReuseGraceWindow is how long a rotated token is answered with its
+// successor again, for clients that retried or raced before the first
+// response arrived. Reuse after it means the token chain has leaked.
+const refreshReuseGraceWindow = 30 * time.Second
+
+func (uc *AuthUseCase) Refresh(ctx context.Context, plaintextToken string, client authdomain.ClientInfo) (authdomain.TokenPair, error) {
 	if strings.TrimSpace(plaintextToken) == "" {
 		return authdomain.TokenPair{}, authdomain.ErrInvalidToken
 	}
@@ -26,14 +35,145 @@ func (uc *AuthUseCase) Refresh(ctx context.Context, plaintextToken string) (auth
 		return authdomain.TokenPair{}, authdomain.ErrExpiredToken
 	}
 
-	if err = uc.authRepo.DeleteRefreshToken(ctx, tokenHash); err != nil {
+	now := time.Now().UTC()
+
+	if stored.IsRotated() {
+		return uc.replayRotation(ctx, plaintextToken, stored, client, now)
+	}
+
+	successorToken, successorHash := auth.SuccessorRefreshToken(uc.jwtCfg, plaintextToken)
+	successor := authdomain.NewRefreshToken(stored.UserID(), stored.SessionID(), successorHash, now.Add(uc.jwtCfg.RefreshTokenTTL))
+
+	rotated, err := uc.authRepo.RotateRefreshToken(ctx, stored.ID(), successor, now)
+	if err != nil {
 		return authdomain.TokenPair{}, fmt.Errorf("rotate token: %w", err)
 	}
+	if !rotated {
+		// A concurrent request with the same token won the rotation race.
+		// Answer like a retry, with the successor it saved.
+		stored, err = uc.authRepo.FindRefreshToken(ctx, tokenHash)
+		if err != nil {
+			return authdomain.TokenPair{}, authdomain.ErrTokenNotFound
+		}
+		return uc.replayRotation(ctx, plaintextToken, stored, client, now)
+	}
+
+	// Rotated tokens are only kept to answer a retry within the window.
+	if err = uc.authRepo.DeleteRotatedRefreshTokens(ctx, stored.SessionID(), now.Add(-refreshReuseGraceWindow)); err != nil {
+		slog.Warn("refresh: failed to prune rotated tokens",
+			"session_id", stored.SessionID(),
+			"error", err,
+		)
+	}
+
+	if err = uc.authRepo.TouchSession(ctx, stored.SessionID(), client, now); err != nil {
+		if errors.Is(err, authdomain.ErrSessionNotFound) {
+			return authdomain.TokenPair{}, authdomain.ErrTokenNotFound
+		}
+		slog.Warn("refresh: failed to update session activity",
+			"session_id", stored.SessionID(),
+			"error", err,
+		)
+	}
+
+	return uc.refreshedPair(ctx, stored, successorToken)
+}
+
+// replayRotation answers a rotated token. Within the grace window the same
+// client gets the successor again, so a lost response or a lost race does
+// not sign it out; a successor that has been used already means the client
+// has moved on and the request is refused. Only reuse after the window
+// revokes the token family.
+func (uc *AuthUseCase) replayRotation(ctx context.Context, plaintextToken string, stored *authdomain.RefreshToken, client authdomain.ClientInfo, now time.Time) (authdomain.TokenPair, error) {
+	if now.Sub(*stored.RotatedAt()) > refreshReuseGraceWindow {
+		return authdomain.TokenPair{}, uc.revokeTokenFamily(ctx, stored, client)
+	}
+
+	session, err := uc.authRepo.FindSessionByID(ctx, stored.SessionID())
+	if err != nil {
+		if errors.Is(err, authdomain.ErrSessionNotFound) {
+			return authdomain.TokenPair{}, authdomain.ErrTokenNotFound
+		}
+		return authdomain.TokenPair{}, fmt.Errorf("find session for refresh: %w", err)
+	}
+	if client.UserAgent == "" || client.UserAgent != session.UserAgent() {
+		return authdomain.TokenPair{}, authdomain.ErrTokenRotated
+	}
+
+	successorToken, successorHash := auth.SuccessorRefreshToken(uc.jwtCfg, plaintextToken)
+	successor, err := uc.authRepo.FindRefreshToken(ctx, successorHash)
+	if err != nil {
+		if errors.Is(err, authdomain.ErrTokenNotFound) {
+			return authdomain.TokenPair{}, authdomain.ErrTokenRotated
+		}
+		return authdomain.TokenPair{}, fmt.Errorf("find successor token: %w", err)
+	}
+	if successor.IsRotated() || successor.IsExpired() {
+		return authdomain.TokenPair{}, authdomain.ErrTokenRotated
+	}
 
+	slog.Info("refresh: rotated token retried within grace window",
+		"user_id", stored.UserID(),
+		"session_id", stored.SessionID(),
+	)
+	return uc.refreshedPair(ctx, stored, successorToken)
+}
+
+// refreshedPair pairs a fresh access token with the refresh token, once
+// the user is checked to still be allowed in.
+func (uc *AuthUseCase) refreshedPair(ctx context.Context, stored *authdomain.RefreshToken, refreshToken string) (authdomain.TokenPair, error) {
 	user, err := uc.userRepo.GetByID(ctx, stored.UserID())
 	if err != nil {
 		return authdomain.TokenPair{}, fmt.Errorf("get user for refresh: %w", err)
 	}
 
-	return uc.issueTokenPair(ctx, user)
+	switch user.Status() {
+	case userdomain.StatusSuspended:
+		_ = uc.authRepo.DeleteAllRefreshTokensByUserID(ctx, user.ID())
+		return authdomain.TokenPair{}, authdomain.ErrAccountSuspended
+
+	case userdomain.StatusDeleted:
+		_ = uc.authRepo.DeleteAllRefreshTokensByUserID(ctx, user.ID())
+		return authdomain.TokenPair{}, authdomain.ErrTokenNotFound
+	}
+
+	accessToken, err := uc.generateAccessToken(ctx, user, stored.SessionID())
+	if err != nil {
+		return authdomain.TokenPair{}, err
+	}
+	return authdomain.TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// revokeTokenFamily handles reuse of a rotated token: the token chain has
+// leaked, so the session and the access tokens issued to it are revoked.
+func (uc *AuthUseCase) revokeTokenFamily(ctx context.Context, stored *authdomain.RefreshToken, client authdomain.ClientInfo) error {
+	err := uc.authRepo.DeleteSession(ctx, stored.UserID(), stored.SessionID())
+	if err != nil && !errors.Is(err, authdomain.ErrSessionNotFound) {
+		slog.Error("refresh: failed to revoke token family after reuse",
+			"user_id", stored.UserID(),
+			"session_id", stored.SessionID(),
+			"error", err,
+		)
+	}
+	if err = uc.revokeSessionAccess(ctx, stored.UserID(), stored.SessionID()); err != nil {
+		slog.Error("refresh: failed to revoke access tokens after reuse",
+			"user_id", stored.UserID(),
+			"session_id", stored.SessionID(),
+			"error", err,
+		)
+	}
+
+	slog.Warn("security: refresh token reuse detected, token family revoked",
+		"event", "refresh_token_reuse",
+		"user_id", stored.UserID(),
+		"session_id", stored.SessionID(),
+		"token_id", stored.ID(),
+		"rotated_at", *stored.RotatedAt(),
+		"ip_address", client.IPAddress,
+		"user_agent", client.UserAgent,
+	)
+	return authdomain.ErrTokenReused
 }
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/src/auth"
+	authdomain "saythis-backend/internal/src/auth/domain"
+	authrepo "saythis-backend/internal/src/auth/repository"
+	userdomain "saythis-backend/internal/src/user/domain"
+	userrepo "saythis-backend/internal/src/user/repository"
+)
+
+// fakeAuthRepo keeps the refresh tokens and sessions Refresh touches. The
+// embedded interface is nil, so any other method panics.
+type fakeAuthRepo struct {
+	authrepo.AuthRepository
+
+	tokens          map[string]*authdomain.RefreshToken
+	sessions        map[uuid.UUID]*authdomain.Session
+	revokedSessions map[uuid.UUID]bool
+
+	// beforeRotate runs inside RotateRefreshToken, to let a concurrent
+	// request win the race.
+	beforeRotate func()
+}
+
+func newFakeAuthRepo() *fakeAuthRepo {
+	return &fakeAuthRepo{
+		tokens:          map[string]*authdomain.RefreshToken{},
+		sessions:        map[uuid.UUID]*authdomain.Session{},
+		revokedSessions: map[uuid.UUID]bool{},
+	}
+}
+
+func (f *fakeAuthRepo) FindRefreshToken(_ context.Context, tokenHash string) (*authdomain.RefreshToken, error) {
+	token, ok := f.tokens[tokenHash]
+	if !ok {
+		return nil, authdomain.ErrTokenNotFound
+	}
+	return token, nil
+}
+
+func (f *fakeAuthRepo) RotateRefreshToken(_ context.Context, id uuid.UUID, successor *authdomain.RefreshToken, rotatedAt time.Time) (bool, error) {
+	if f.beforeRotate != nil {
+		f.beforeRotate()
+	}
+	for _, token := range f.tokens {
+		if token.ID() == id {
+			if token.IsRotated() {
+				return false, nil
+			}
+			f.setRotatedAt(token, rotatedAt)
+			f.tokens[successor.TokenHash()] = successor
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeAuthRepo) setRotatedAt(token *authdomain.RefreshToken, rotatedAt time.Time) {
+	f.tokens[token.TokenHash()] = authdomain.ReconstitueRefreshToken(
+		token.ID(), token.UserID(), token.SessionID(), token.TokenHash(),
+		token.ExpiresAt(), token.CreatedAt(), &rotatedAt,
+	)
+}
+
+func (f *fakeAuthRepo) DeleteRotatedRefreshTokens(context.Context, uuid.UUID, time.Time) error {
+	return nil
+}
+
+func (f *fakeAuthRepo) TouchSession(_ context.Context, sessionID uuid.UUID, _ authdomain.ClientInfo, _ time.Time) error {
+	if _, ok := f.sessions[sessionID]; !ok {
+		return authdomain.ErrSessionNotFound
+	}
+	return nil
+}
+
+func (f *fakeAuthRepo) FindSessionByID(_ context.Context, sessionID uuid.UUID) (*authdomain.Session, error) {
+	session, ok := f.sessions[sessionID]
+	if !ok {
+		return nil, authdomain.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (f *fakeAuthRepo) DeleteSession(_ context.Context, _, sessionID uuid.UUID) error {
+	delete(f.sessions, sessionID)
+	for hash, token := range f.tokens {
+		if token.SessionID() == sessionID {
+			delete(f.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (f *fakeAuthRepo) RevokeSessionAccess(_ context.Context, _ uuid.UUID, sessionIDs []uuid.UUID, _ time.Time) error {
+	for _, id := range sessionIDs {
+		f.revokedSessions[id] = true
+	}
+	return nil
+}
+
+func (f *fakeAuthRepo) FindUserAccessRevocation(context.Context, uuid.UUID) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+type fakeUserRepo struct {
+	userrepo.UserRepository
+	user *userdomain.User
+}
+
+func (f *fakeUserRepo) GetByID(context.Context, uuid.UUID) (*userdomain.User, error) {
+	return f.user, nil
+}
+
+type refreshFixture struct {
+	uc      *AuthUseCase
+	repo    *fakeAuthRepo
+	session *authdomain.Session
+	client  authdomain.ClientInfo
+	token   string
+}
+
+func newRefreshFixture(t *testing.T) *refreshFixture {
+	t.Helper()
+
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwtCfg := auth.JWTConfig{
+		Keys:            keys,
+		Secret:          []byte("refresh-test-secret"),
+		AccessTokenTTL:  time.Minute,
+		RefreshTokenTTL: time.Hour,
+	}
+
+	now := time.Now().UTC()
+	user, err := userdomain.NewUser("refresh@example.com", "Refresh Test", userdomain.RoleUser, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client := authdomain.NewClientInfo("Pixel 8", "SayThis/2.1 (Android 15)", "203.0.113.7")
+	session := authdomain.NewSession(user.ID(), client, now)
+
+	plaintext, hash, err := auth.GenerateRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := newFakeAuthRepo()
+	repo.sessions[session.ID()] = session
+	repo.tokens[hash] = authdomain.NewRefreshToken(user.ID(), session.ID(), hash, now.Add(time.Hour))
+
+	uc := NewAuthUseCase(repo, &fakeUserRepo{user: user}, jwtCfg, nil, auth.NewRevocationList(repo, time.Minute), "")
+	return &refreshFixture{uc: uc, repo: repo, session: session, client: client, token: plaintext}
+}
+
+func TestRefresh_RetryWithinGraceGetsSameSuccessor(t *testing.T) {
+	ctx := context.Background()
+	f := newRefreshFixture(t)
+
+	first, err := f.uc.Refresh(ctx, f.token, f.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first response was lost, so the client retries the old token.
+	retry, err := f.uc.Refresh(ctx, f.token, f.client)
+	if err != nil {
+		t.Fatalf("want the retry answered, got %v", err)
+	}
+	if retry.RefreshToken != first.RefreshToken {
+		t.Errorf("want the same successor on retry")
+	}
+	if retry.AccessToken == "" {
+		t.Errorf("want an access token on retry")
+	}
+
+	if _, err := f.uc.Refresh(ctx, retry.RefreshToken, f.client); err != nil {
+		t.Fatalf("want the successor usable, got %v", err)
+	}
+
+	// The successor has been used now, so the old token is only refused.
+	if _, err := f.uc.Refresh(ctx, f.token, f.client); !errors.Is(err, authdomain.ErrTokenRotated) {
+		t.Errorf("want %v once the successor was used, got %v", authdomain.ErrTokenRotated, err)
+	}
+	if _, ok := f.repo.sessions[f.session.ID()]; !ok || f.repo.revokedSessions[f.session.ID()] {
+		t.Errorf("want the session kept within the grace window")
+	}
+}
+
+func TestRefresh_RaceLoserGetsWinnersSuccessor(t *testing.T) {
+	ctx := context.Background()
+	f := newRefreshFixture(t)
+
+	want, wantHash := auth.SuccessorRefreshToken(f.uc.jwtCfg, f.token)
+	stored := f.repo.tokens[auth.HashRefreshToken(f.token)]
+	f.repo.beforeRotate = func() {
+		f.repo.beforeRotate = nil
+		f.repo.setRotatedAt(stored, time.Now().UTC())
+		f.repo.tokens[wantHash] = authdomain.NewRefreshToken(stored.UserID(), stored.SessionID(), wantHash, time.Now().Add(time.Hour))
+	}
+
+	pair, err := f.uc.Refresh(ctx, f.token, f.client)
+	if err != nil {
+		t.Fatalf("want the race loser answered, got %v", err)
+	}
+	if pair.RefreshToken != want {
+		t.Errorf("want the winner's successor")
+	}
+}
+
+func TestRefresh_ReuseAfterGraceRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	f := newRefreshFixture(t)
+
+	if _, err := f.uc.Refresh(ctx, f.token, f.client); err != nil {
+		t.Fatal(err)
+	}
+	stored := f.repo.tokens[auth.HashRefreshToken(f.token)]
+	f.repo.setRotatedAt(stored, time.Now().UTC().Add(-refreshReuseGraceWindow-time.Second))
+
+	if _, err := f.uc.Refresh(ctx, f.token, f.client); !errors.Is(err, authdomain.ErrTokenReused) {
+		t.Fatalf("want %v, got %v", authdomain.ErrTokenReused, err)
+	}
+	if _, ok := f.repo.sessions[f.session.ID()]; ok {
+		t.Errorf("want the session deleted")
+	}
+	if !f.repo.revokedSessions[f.session.ID()] {
+		t.Errorf("want the session's access tokens revoked")
+	}
+}
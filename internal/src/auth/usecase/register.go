@@ -27,6 +27,7 @@ type AuthUseCase struct {
 	userRepo    userrepo.UserRepository
 	jwtCfg      auth.JWTConfig
 	emailSender auth.EmailSender
+	revocations *auth.RevocationList
 	frontendURL string
 }
 
@@ -35,6 +36,7 @@ func NewAuthUseCase(
 	userRepo userrepo.UserRepository,
 	jwtCfg auth.JWTConfig,
 	emailSender auth.EmailSender,
+	revocations *auth.RevocationList,
 	frontendURL string,
 ) *AuthUseCase {
 	return &AuthUseCase{
@@ -42,11 +44,12 @@ func NewAuthUseCase(
 		userRepo:    userRepo,
 		jwtCfg:      jwtCfg,
 		emailSender: emailSender,
+		revocations: revocations,
 		frontendURL: frontendURL,
 	}
 }
 
-func (uc *AuthUseCase) Register(ctx context.Context, email, fullName, password string) (*userdomain.User, authdomain.TokenPair, error) {
+func (uc *AuthUseCase) Register(ctx context.Context, email, fullName, password string, client authdomain.ClientInfo) (*userdomain.User, authdomain.TokenPair, error) {
 
 	if strings.TrimSpace(password) == "" {
 		return nil, authdomain.TokenPair{}, authdomain.ErrEmptyPassword
@@ -78,7 +81,7 @@ func (uc *AuthUseCase) Register(ctx context.Context, email, fullName, password s
 
 	uc.dispatchVerificationEmail(ctx, user.ID(), user.Email())
 
-	tokens, err := uc.issueTokenPair(ctx, user)
+	tokens, err := uc.startSession(ctx, user, client)
 	if err != nil {
 		return nil, authdomain.TokenPair{}, err
 	}
@@ -117,10 +120,18 @@ func (uc *AuthUseCase) dispatchVerificationEmail(ctx context.Context, userID uui
 	}
 }
 
-func (uc *AuthUseCase) issueTokenPair(ctx context.Context, user *userdomain.User) (authdomain.TokenPair, error) {
-	accessToken, err := auth.GenerateAccessToken(uc.jwtCfg, user.ID(), user.Email(), string(user.Role()))
+func (uc *AuthUseCase) startSession(ctx context.Context, user *userdomain.User, client authdomain.ClientInfo) (authdomain.TokenPair, error) {
+	session := authdomain.NewSession(user.ID(), client, time.Now().UTC())
+	if err := uc.authRepo.CreateSession(ctx, session); err != nil {
+		return authdomain.TokenPair{}, fmt.Errorf("create session: %w", err)
+	}
+	return uc.issueTokenPair(ctx, user, session.ID())
+}
+
+func (uc *AuthUseCase) issueTokenPair(ctx context.Context, user *userdomain.User, sessionID uuid.UUID) (authdomain.TokenPair, error) {
+	accessToken, err := uc.generateAccessToken(ctx, user, sessionID)
 	if err != nil {
-		return authdomain.TokenPair{}, fmt.Errorf("generate access token: %w", err)
+		return authdomain.TokenPair{}, err
 	}
 
 	plaintext, hash, err := auth.GenerateRefreshToken()
@@ -129,7 +140,7 @@ func (uc *AuthUseCase) issueTokenPair(ctx context.Context, user *userdomain.User
 	}
 
 	expiresAt := time.Now().UTC().Add(uc.jwtCfg.RefreshTokenTTL)
-	refreshToken := authdomain.NewRefreshToken(user.ID(), hash, expiresAt)
+	refreshToken := authdomain.NewRefreshToken(user.ID(), sessionID, hash, expiresAt)
 
 	if err = uc.authRepo.SaveRefreshToken(ctx, refreshToken); err != nil {
 		return authdomain.TokenPair{}, fmt.Errorf("save refresh token: %w", err)
@@ -140,3 +151,17 @@ func (uc *AuthUseCase) issueTokenPair(ctx context.Context, user *userdomain.User
 		RefreshToken: plaintext,
 	}, nil
 }
+
+// generateAccessToken dates the token so that a user revocation made
+// earlier in the same second does not cover it.
+func (uc *AuthUseCase) generateAccessToken(ctx context.Context, user *userdomain.User, sessionID uuid.UUID) (string, error) {
+	issuedAt, err := uc.revocations.IssueTime(ctx, user.ID())
+	if err != nil {
+		return "", fmt.Errorf("get access token issue time: %w", err)
+	}
+	accessToken, err := auth.GenerateAccessTokenAt(uc.jwtCfg, issuedAt, user.ID(), sessionID, user.Email(), string(user.Role()))
+	if err != nil {
+		return "", fmt.Errorf("generate access token: %w", err)
+	}
+	return accessToken, nil
+}
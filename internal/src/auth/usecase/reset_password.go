@@ -56,6 +56,20 @@ func (uc *AuthUseCase) ResetPassword(ctx context.Context, plaintextToken, newPas
 		)
 	}
 
+	if err = uc.authRepo.DeleteAllRefreshTokensByUserID(ctx, token.UserID()); err != nil {
+		slog.Warn("reset_password: failed to revoke refresh tokens",
+			"user_id", token.UserID(),
+			"error", err,
+		)
+	}
+
+	if err = uc.revocations.RevokeUser(ctx, token.UserID()); err != nil {
+		slog.Error("reset_password: failed to revoke access tokens",
+			"user_id", token.UserID(),
+			"error", err,
+		)
+	}
+
 	slog.Info("reset_password: password updated successfully", "user_id", token.UserID())
 	return nil
 }
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	authdomain "saythis-backend/internal/src/auth/domain"
+)
+
+func (uc *AuthUseCase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*authdomain.Session, error) {
+	sessions, err := uc.authRepo.FindSessionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if err := uc.authRepo.DeleteSession(ctx, userID, sessionID); err != nil {
+		if errors.Is(err, authdomain.ErrSessionNotFound) {
+			return err
+		}
+		return fmt.Errorf("revoke session: %w", err)
+	}
+	if err := uc.revokeSessionAccess(ctx, userID, sessionID); err != nil {
+		return fmt.Errorf("revoke session: %w", err)
+	}
+
+	slog.Info("session revoked", "user_id", userID, "session_id", sessionID)
+	return nil
+}
+
+func (uc *AuthUseCase) RevokeOtherSessions(ctx context.Context, userID, currentSessionID uuid.UUID) (int64, error) {
+	revoked, err := uc.authRepo.DeleteOtherSessions(ctx, userID, currentSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("revoke other sessions: %w", err)
+	}
+	if err := uc.revokeSessionAccess(ctx, userID, revoked...); err != nil {
+		return 0, fmt.Errorf("revoke other sessions: %w", err)
+	}
+
+	slog.Info("other sessions revoked", "user_id", userID, "kept_session_id", currentSessionID, "revoked", len(revoked))
+	return int64(len(revoked)), nil
+}
+
+// revokeSessionAccess denies the access tokens already issued to deleted
+// sessions, which would otherwise stay valid until they expire.
+func (uc *AuthUseCase) revokeSessionAccess(ctx context.Context, userID uuid.UUID, sessionIDs ...uuid.UUID) error {
+	expiresAt := time.Now().UTC().Add(uc.jwtCfg.AccessTokenTTL)
+	return uc.revocations.RevokeSessions(ctx, userID, sessionIDs, expiresAt)
+}
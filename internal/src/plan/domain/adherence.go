@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxAdherenceWeeks bounds how much history is reported for long-running
+// plans; the current week is always the last entry.
+const MaxAdherenceWeeks = 12
+
+// Activity is one day's practice of a single exercise or tool type, as
+// recorded in exercise_attempts and tool_sessions. Date is a civil date in
+// the client's timezone, stored as UTC midnight.
+type Activity struct {
+	Date    time.Time
+	Kind    ItemKind
+	Key     string
+	Count   int
+	Minutes float64
+}
+
+type ItemAdherence struct {
+	ItemID    uuid.UUID
+	Completed int
+	Minutes   float64
+	Percent   float64
+}
+
+// WeekAdherence covers Monday through Sunday. Percent is the mean of the
+// items that were due that week, and nil when none were.
+type WeekAdherence struct {
+	WeekStart time.Time
+	Percent   *float64
+	Items     []ItemAdherence
+}
+
+// WeekStart returns the Monday on or before date.
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
+
+// AdherenceRange returns the first and last civil dates whose activity
+// CalculateAdherence looks at.
+func AdherenceRange(plan *Plan, today time.Time) (time.Time, time.Time) {
+	from := WeekStart(today).AddDate(0, 0, -7*(MaxAdherenceWeeks-1))
+	if plan.StartsOn.After(from) {
+		from = plan.StartsOn
+	}
+	return from, today
+}
+
+// CalculateAdherence scores each week of the plan up to and including the
+// one containing today. An item's weekly score is the share of its target
+// sessions completed, averaged with the share of target minutes when the
+// item has one; both are capped at 100%.
+func CalculateAdherence(plan *Plan, activity []Activity, today time.Time) []WeekAdherence {
+	if today.Before(plan.StartsOn) {
+		return []WeekAdherence{}
+	}
+
+	from, to := AdherenceRange(plan, today)
+	firstWeek := WeekStart(from)
+	weeks := make([]WeekAdherence, 0)
+	for week := firstWeek; !week.After(to); week = week.AddDate(0, 0, 7) {
+		weeks = append(weeks, scoreWeek(plan, activity, week, from, to))
+	}
+	return weeks
+}
+
+func scoreWeek(plan *Plan, activity []Activity, weekStart, from, to time.Time) WeekAdherence {
+	weekEnd := weekStart.AddDate(0, 0, 6)
+	result := WeekAdherence{WeekStart: weekStart, Items: make([]ItemAdherence, 0, len(plan.Items))}
+
+	total := 0.0
+	for _, item := range plan.Items {
+		if item.DueDate != nil && item.DueDate.Before(weekStart) {
+			continue
+		}
+
+		score := ItemAdherence{ItemID: item.ID}
+		for _, a := range activity {
+			if a.Date.Before(weekStart) || a.Date.After(weekEnd) ||
+				a.Date.Before(from) || a.Date.After(to) ||
+				(item.DueDate != nil && a.Date.After(*item.DueDate)) ||
+				!item.matches(a) {
+				continue
+			}
+			score.Completed += a.Count
+			score.Minutes += a.Minutes
+		}
+
+		ratio := math.Min(1, float64(score.Completed)/float64(item.TimesPerWeek))
+		if item.MinutesPerWeek != nil {
+			ratio = (ratio + math.Min(1, score.Minutes/float64(*item.MinutesPerWeek))) / 2
+		}
+		score.Minutes = round1(score.Minutes)
+		score.Percent = round1(ratio * 100)
+
+		total += score.Percent
+		result.Items = append(result.Items, score)
+	}
+
+	if len(result.Items) > 0 {
+		percent := round1(total / float64(len(result.Items)))
+		result.Percent = &percent
+	}
+	return result
+}
+
+func (i Item) matches(a Activity) bool {
+	switch i.Kind {
+	case ItemExercise:
+		return a.Kind == ItemExercise && a.Key == i.ExerciseID
+	case ItemTool:
+		return a.Kind == ItemTool && a.Key == i.ToolType
+	}
+	return false
+}
+
+func round1(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+)
+
+func date(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestWeekStart_IsMonday(t *testing.T) {
+	for _, day := range []string{"2026-03-02", "2026-03-04", "2026-03-08"} {
+		if got := plandomain.WeekStart(date(t, day)); !got.Equal(date(t, "2026-03-02")) {
+			t.Errorf("%s: want 2026-03-02, got %s", day, got.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestCalculateAdherence(t *testing.T) {
+	minutes := 30
+	due := date(t, "2026-03-08")
+	plan := &plandomain.Plan{
+		StartsOn: date(t, "2026-03-04"),
+		Items: []plandomain.Item{
+			{ID: uuid.New(), Kind: plandomain.ItemTool, ToolType: "DAF", TimesPerWeek: 4, MinutesPerWeek: &minutes},
+			{ID: uuid.New(), Kind: plandomain.ItemExercise, ChapterID: "c1", ExerciseID: "e1", TimesPerWeek: 1, DueDate: &due},
+		},
+	}
+	activity := []plandomain.Activity{
+		{Date: date(t, "2026-03-03"), Kind: plandomain.ItemTool, Key: "DAF", Count: 5, Minutes: 50},
+		{Date: date(t, "2026-03-05"), Kind: plandomain.ItemTool, Key: "DAF", Count: 2, Minutes: 30},
+		{Date: date(t, "2026-03-06"), Kind: plandomain.ItemExercise, Key: "e1", Count: 1},
+		{Date: date(t, "2026-03-10"), Kind: plandomain.ItemTool, Key: "FAF", Count: 3, Minutes: 20},
+	}
+
+	weeks := plandomain.CalculateAdherence(plan, activity, date(t, "2026-03-11"))
+	if len(weeks) != 2 {
+		t.Fatalf("want 2 weeks, got %d", len(weeks))
+	}
+
+	// Week one ignores the session before the plan started: DAF is 2/4
+	// sessions and 30/30 minutes (75%), the exercise is done (100%).
+	first := weeks[0]
+	if first.Percent == nil || *first.Percent != 87.5 {
+		t.Errorf("week 1: want 87.5%%, got %v", first.Percent)
+	}
+
+	// Week two only has the DAF item, since the exercise was due earlier.
+	second := weeks[1]
+	if len(second.Items) != 1 || second.Percent == nil || *second.Percent != 0 {
+		t.Errorf("week 2: want a single item at 0%%, got %+v", second)
+	}
+}
+
+func TestCalculateAdherence_NotStarted(t *testing.T) {
+	plan := &plandomain.Plan{StartsOn: date(t, "2026-03-10")}
+	if weeks := plandomain.CalculateAdherence(plan, nil, date(t, "2026-03-09")); len(weeks) != 0 {
+		t.Errorf("want no weeks before the plan starts, got %d", len(weeks))
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "errors"
+
+var (
+	ErrPlanNotFound       = errors.New("practice plan not found")
+	ErrEmptyTitle         = errors.New("title must not be empty")
+	ErrTitleTooLong       = errors.New("title cannot exceed 120 characters")
+	ErrNotesTooLong       = errors.New("notes cannot exceed 2000 characters")
+	ErrNoItems            = errors.New("a plan needs at least one item")
+	ErrTooManyItems       = errors.New("a plan cannot have more than 30 items")
+	ErrInvalidItemKind    = errors.New("item kind must be exercise or tool")
+	ErrInvalidExerciseRef = errors.New("exercise items need a chapter_id and exercise_id")
+	ErrInvalidToolRef     = errors.New("tool items need a valid tool_type")
+	ErrInvalidFrequency   = errors.New("times_per_week must be between 1 and 14")
+	ErrInvalidMinutes     = errors.New("minutes_per_week must be between 1 and 1000 and only applies to tool items")
+	ErrInvalidDueDate     = errors.New("due_date cannot be before the plan starts")
+	ErrInvalidDate        = errors.New("dates must use the YYYY-MM-DD format")
+)
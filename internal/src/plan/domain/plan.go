@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+const (
+	MaxTitleLength   = 120
+	MaxNotesLength   = 2000
+	MaxItems         = 30
+	MaxTimesPerWeek  = 14
+	MaxMinutesWeekly = 1000
+)
+
+type ItemKind string
+
+const (
+	ItemExercise ItemKind = "exercise"
+	ItemTool     ItemKind = "tool"
+)
+
+type PlanStatus string
+
+const (
+	PlanActive   PlanStatus = "active"
+	PlanArchived PlanStatus = "archived"
+)
+
+// Item is one piece of homework. Exercise items point at a chapter
+// exercise; tool items at a tool type, optionally with a weekly minute
+// target. An item with a due date stops counting after that day.
+type Item struct {
+	ID             uuid.UUID
+	Kind           ItemKind
+	ChapterID      string
+	ExerciseID     string
+	ToolType       string
+	TimesPerWeek   int
+	MinutesPerWeek *int
+	DueDate        *time.Time
+}
+
+// Plan is a therapist's practice plan for one client. A therapist has at
+// most one active plan per client; assigning a new one archives the old.
+type Plan struct {
+	ID            uuid.UUID
+	TherapistID   uuid.UUID
+	ClientID      uuid.UUID
+	Title         string
+	Notes         string
+	StartsOn      time.Time
+	Status        PlanStatus
+	Items         []Item
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	TherapistName string
+}
+
+func NewPlan(therapistID, clientID uuid.UUID, title, notes string, startsOn time.Time, items []Item, now time.Time) (*Plan, error) {
+	title = strings.TrimSpace(title)
+	notes = strings.TrimSpace(notes)
+	if title == "" {
+		return nil, ErrEmptyTitle
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return nil, ErrTitleTooLong
+	}
+	if utf8.RuneCountInString(notes) > MaxNotesLength {
+		return nil, ErrNotesTooLong
+	}
+	if len(items) == 0 {
+		return nil, ErrNoItems
+	}
+	if len(items) > MaxItems {
+		return nil, ErrTooManyItems
+	}
+
+	plan := &Plan{
+		ID:          uuid.New(),
+		TherapistID: therapistID,
+		ClientID:    clientID,
+		Title:       title,
+		Notes:       notes,
+		StartsOn:    startsOn,
+		Status:      PlanActive,
+		Items:       make([]Item, 0, len(items)),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	for _, item := range items {
+		if err := item.validate(startsOn); err != nil {
+			return nil, err
+		}
+		item.ID = uuid.New()
+		plan.Items = append(plan.Items, item)
+	}
+	return plan, nil
+}
+
+func (i *Item) validate(startsOn time.Time) error {
+	i.ChapterID = strings.TrimSpace(i.ChapterID)
+	i.ExerciseID = strings.TrimSpace(i.ExerciseID)
+	i.ToolType = strings.TrimSpace(i.ToolType)
+
+	switch i.Kind {
+	case ItemExercise:
+		if i.ChapterID == "" || i.ExerciseID == "" || i.ToolType != "" {
+			return ErrInvalidExerciseRef
+		}
+		if i.MinutesPerWeek != nil {
+			return ErrInvalidMinutes
+		}
+	case ItemTool:
+		if !statsdomain.IsValidToolType(i.ToolType) || i.ChapterID != "" || i.ExerciseID != "" {
+			return ErrInvalidToolRef
+		}
+		if i.MinutesPerWeek != nil && (*i.MinutesPerWeek < 1 || *i.MinutesPerWeek > MaxMinutesWeekly) {
+			return ErrInvalidMinutes
+		}
+	default:
+		return ErrInvalidItemKind
+	}
+
+	if i.TimesPerWeek < 1 || i.TimesPerWeek > MaxTimesPerWeek {
+		return ErrInvalidFrequency
+	}
+	if i.DueDate != nil && i.DueDate.Before(startsOn) {
+		return ErrInvalidDueDate
+	}
+	return nil
+}
+
+// PlanProgress pairs a plan with its weekly adherence, oldest week first.
+type PlanProgress struct {
+	Plan  *Plan
+	Weeks []WeekAdherence
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/plan/usecase"
+)
+
+type GetMyPlansHandler struct {
+	usecase *usecase.PlanUseCase
+}
+
+func NewGetMyPlansHandler(uc *usecase.PlanUseCase) *GetMyPlansHandler {
+	return &GetMyPlansHandler{usecase: uc}
+}
+
+func (h *GetMyPlansHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	plans, err := h.usecase.GetMyPlans(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapPlanError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	resp := plansResponse{Plans: make([]planPayload, 0, len(plans))}
+	for _, progress := range plans {
+		resp.Plans = append(resp.Plans, toPlanPayload(progress))
+	}
+	helper.JSON(w, http.StatusOK, resp)
+}
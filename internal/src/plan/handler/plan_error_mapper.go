@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+func mapPlanError(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, plandomain.ErrEmptyTitle),
+		errors.Is(err, plandomain.ErrTitleTooLong),
+		errors.Is(err, plandomain.ErrNotesTooLong),
+		errors.Is(err, plandomain.ErrNoItems),
+		errors.Is(err, plandomain.ErrTooManyItems),
+		errors.Is(err, plandomain.ErrInvalidItemKind),
+		errors.Is(err, plandomain.ErrInvalidExerciseRef),
+		errors.Is(err, plandomain.ErrInvalidToolRef),
+		errors.Is(err, plandomain.ErrInvalidFrequency),
+		errors.Is(err, plandomain.ErrInvalidMinutes),
+		errors.Is(err, plandomain.ErrInvalidDueDate),
+		errors.Is(err, plandomain.ErrInvalidDate),
+		errors.Is(err, therapydomain.ErrUnknownChapter),
+		errors.Is(err, therapydomain.ErrUnknownExercise),
+		errors.Is(err, therapydomain.ErrExerciseChapterMismatch):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, plandomain.ErrPlanNotFound),
+		errors.Is(err, therapistdomain.ErrClientNotLinked):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrScopeNotGranted):
+		return http.StatusForbidden, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
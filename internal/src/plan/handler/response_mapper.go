@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+type planItemPayload struct {
+	ID             uuid.UUID           `json:"id"`
+	Kind           plandomain.ItemKind `json:"kind"`
+	ChapterID      string              `json:"chapter_id,omitempty"`
+	ExerciseID     string              `json:"exercise_id,omitempty"`
+	ToolType       string              `json:"tool_type,omitempty"`
+	TimesPerWeek   int                 `json:"times_per_week"`
+	MinutesPerWeek *int                `json:"minutes_per_week"`
+	DueDate        *string             `json:"due_date"`
+}
+
+type itemAdherencePayload struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	Completed int       `json:"completed"`
+	Minutes   float64   `json:"minutes"`
+	Percent   float64   `json:"percent"`
+}
+
+type weekAdherencePayload struct {
+	WeekStart string                 `json:"week_start"`
+	Percent   *float64               `json:"percent"`
+	Items     []itemAdherencePayload `json:"items"`
+}
+
+type planPayload struct {
+	ID            uuid.UUID              `json:"id"`
+	TherapistID   uuid.UUID              `json:"therapist_id"`
+	TherapistName string                 `json:"therapist_name"`
+	ClientID      uuid.UUID              `json:"client_id"`
+	Title         string                 `json:"title"`
+	Notes         string                 `json:"notes"`
+	StartsOn      string                 `json:"starts_on"`
+	Items         []planItemPayload      `json:"items"`
+	CurrentWeek   *float64               `json:"current_week_percent"`
+	Adherence     []weekAdherencePayload `json:"adherence"`
+	CreatedAt     time.Time              `json:"created_at"`
+	UpdatedAt     time.Time              `json:"updated_at"`
+}
+
+type planResponse struct {
+	Plan planPayload `json:"plan"`
+}
+
+type plansResponse struct {
+	Plans []planPayload `json:"plans"`
+}
+
+func toPlanPayload(progress *plandomain.PlanProgress) planPayload {
+	plan := progress.Plan
+	payload := planPayload{
+		ID:            plan.ID,
+		TherapistID:   plan.TherapistID,
+		TherapistName: plan.TherapistName,
+		ClientID:      plan.ClientID,
+		Title:         plan.Title,
+		Notes:         plan.Notes,
+		StartsOn:      plan.StartsOn.Format(dateLayout),
+		Items:         make([]planItemPayload, 0, len(plan.Items)),
+		Adherence:     make([]weekAdherencePayload, 0, len(progress.Weeks)),
+		CreatedAt:     plan.CreatedAt,
+		UpdatedAt:     plan.UpdatedAt,
+	}
+
+	for _, item := range plan.Items {
+		var dueDate *string
+		if item.DueDate != nil {
+			formatted := item.DueDate.Format(dateLayout)
+			dueDate = &formatted
+		}
+		payload.Items = append(payload.Items, planItemPayload{
+			ID:             item.ID,
+			Kind:           item.Kind,
+			ChapterID:      item.ChapterID,
+			ExerciseID:     item.ExerciseID,
+			ToolType:       item.ToolType,
+			TimesPerWeek:   item.TimesPerWeek,
+			MinutesPerWeek: item.MinutesPerWeek,
+			DueDate:        dueDate,
+		})
+	}
+
+	for _, week := range progress.Weeks {
+		items := make([]itemAdherencePayload, 0, len(week.Items))
+		for _, item := range week.Items {
+			items = append(items, itemAdherencePayload{
+				ItemID:    item.ItemID,
+				Completed: item.Completed,
+				Minutes:   item.Minutes,
+				Percent:   item.Percent,
+			})
+		}
+		payload.Adherence = append(payload.Adherence, weekAdherencePayload{
+			WeekStart: week.WeekStart.Format(dateLayout),
+			Percent:   week.Percent,
+			Items:     items,
+		})
+	}
+	if n := len(progress.Weeks); n > 0 {
+		payload.CurrentWeek = progress.Weeks[n-1].Percent
+	}
+
+	return payload
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	plandomain "saythis-backend/internal/src/plan/domain"
+	"saythis-backend/internal/src/plan/usecase"
+)
+
+const maxBodySize = 1 << 20
+
+type AssignPlanHandler struct {
+	usecase *usecase.PlanUseCase
+}
+
+func NewAssignPlanHandler(uc *usecase.PlanUseCase) *AssignPlanHandler {
+	return &AssignPlanHandler{usecase: uc}
+}
+
+type planItemRequest struct {
+	Kind           plandomain.ItemKind `json:"kind"`
+	ChapterID      string              `json:"chapter_id"`
+	ExerciseID     string              `json:"exercise_id"`
+	ToolType       string              `json:"tool_type"`
+	TimesPerWeek   int                 `json:"times_per_week"`
+	MinutesPerWeek *int                `json:"minutes_per_week"`
+	DueDate        *string             `json:"due_date"`
+}
+
+type assignPlanRequest struct {
+	Title    string            `json:"title"`
+	Notes    string            `json:"notes"`
+	StartsOn *string           `json:"starts_on"`
+	Items    []planItemRequest `json:"items"`
+}
+
+func (h *AssignPlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	therapistID, clientID, ok := therapistAndClient(w, r)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req assignPlanRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	input, err := req.toInput()
+	if err != nil {
+		status, msg := mapPlanError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	progress, err := h.usecase.AssignPlan(r.Context(), therapistID, clientID, input)
+	if err != nil {
+		status, msg := mapPlanError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, planResponse{Plan: toPlanPayload(progress)})
+}
+
+func (req assignPlanRequest) toInput() (usecase.AssignPlanInput, error) {
+	input := usecase.AssignPlanInput{
+		Title: req.Title,
+		Notes: req.Notes,
+		Items: make([]plandomain.Item, 0, len(req.Items)),
+	}
+
+	var err error
+	if input.StartsOn, err = parseOptionalDate(req.StartsOn); err != nil {
+		return input, err
+	}
+	for _, item := range req.Items {
+		dueDate, err := parseOptionalDate(item.DueDate)
+		if err != nil {
+			return input, err
+		}
+		input.Items = append(input.Items, plandomain.Item{
+			Kind:           item.Kind,
+			ChapterID:      item.ChapterID,
+			ExerciseID:     item.ExerciseID,
+			ToolType:       item.ToolType,
+			TimesPerWeek:   item.TimesPerWeek,
+			MinutesPerWeek: item.MinutesPerWeek,
+			DueDate:        dueDate,
+		})
+	}
+	return input, nil
+}
+
+type GetClientPlanHandler struct {
+	usecase *usecase.PlanUseCase
+}
+
+func NewGetClientPlanHandler(uc *usecase.PlanUseCase) *GetClientPlanHandler {
+	return &GetClientPlanHandler{usecase: uc}
+}
+
+func (h *GetClientPlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	therapistID, clientID, ok := therapistAndClient(w, r)
+	if !ok {
+		return
+	}
+
+	progress, err := h.usecase.GetClientPlan(r.Context(), therapistID, clientID)
+	if err != nil {
+		status, msg := mapPlanError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, planResponse{Plan: toPlanPayload(progress)})
+}
+
+type ArchivePlanHandler struct {
+	usecase *usecase.PlanUseCase
+}
+
+func NewArchivePlanHandler(uc *usecase.PlanUseCase) *ArchivePlanHandler {
+	return &ArchivePlanHandler{usecase: uc}
+}
+
+func (h *ArchivePlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	therapistID, clientID, ok := therapistAndClient(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ArchivePlan(r.Context(), therapistID, clientID); err != nil {
+		status, msg := mapPlanError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// therapistAndClient pulls the therapist from the token and the client from
+// the {clientID} path segment, writing the error response itself on failure.
+func therapistAndClient(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return claims.UserID, clientID, true
+}
+
+func parseOptionalDate(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	parsed, err := time.Parse(dateLayout, *value)
+	if err != nil {
+		return nil, plandomain.ErrInvalidDate
+	}
+	return &parsed, nil
+}
@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+)
+
+var _ PlanRepository = (*PostgresPlanRepo)(nil)
+
+type PostgresPlanRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresPlanRepo(db *pgxpool.Pool) *PostgresPlanRepo {
+	return &PostgresPlanRepo{db: db}
+}
+
+func (r *PostgresPlanRepo) ReplaceActivePlan(ctx context.Context, plan *plandomain.Plan) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	archive := `
+		UPDATE practice_plans
+		SET    status     = 'archived',
+		       updated_at = $3
+		WHERE  therapist_id = $1
+		  AND  client_id    = $2
+		  AND  status       = 'active'
+	`
+	if _, err = tx.Exec(ctx, archive, plan.TherapistID, plan.ClientID, plan.CreatedAt); err != nil {
+		return fmt.Errorf("archive previous plan: %w", err)
+	}
+
+	insertPlan := `
+		INSERT INTO practice_plans (id, therapist_id, client_id, title, notes, starts_on, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	if _, err = tx.Exec(ctx, insertPlan,
+		plan.ID, plan.TherapistID, plan.ClientID, plan.Title, plan.Notes,
+		plan.StartsOn, string(plan.Status), plan.CreatedAt, plan.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("insert plan: %w", err)
+	}
+
+	insertItem := `
+		INSERT INTO practice_plan_items (id, plan_id, position, kind, chapter_id, exercise_id, tool_type, times_per_week, minutes_per_week, due_date)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, ''), $8, $9, $10)
+	`
+	for position, item := range plan.Items {
+		if _, err = tx.Exec(ctx, insertItem,
+			item.ID, plan.ID, position, string(item.Kind),
+			item.ChapterID, item.ExerciseID, item.ToolType,
+			item.TimesPerWeek, item.MinutesPerWeek, item.DueDate,
+		); err != nil {
+			return fmt.Errorf("insert plan item: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+const planSelectColumns = `
+	p.id, p.therapist_id, p.client_id, p.title, p.notes, p.starts_on, p.status,
+	p.created_at, p.updated_at, t.full_name
+`
+
+func (r *PostgresPlanRepo) FindActivePlan(ctx context.Context, therapistID, clientID uuid.UUID) (*plandomain.Plan, error) {
+	query := `
+		SELECT ` + planSelectColumns + `
+		FROM   practice_plans p
+		JOIN   users t ON t.id = p.therapist_id
+		WHERE  p.therapist_id = $1
+		  AND  p.client_id    = $2
+		  AND  p.status       = 'active'
+	`
+	plans, err := r.queryPlans(ctx, query, therapistID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plans) == 0 {
+		return nil, plandomain.ErrPlanNotFound
+	}
+	return plans[0], nil
+}
+
+func (r *PostgresPlanRepo) ListActivePlansForClient(ctx context.Context, clientID uuid.UUID) ([]*plandomain.Plan, error) {
+	query := `
+		SELECT ` + planSelectColumns + `
+		FROM   practice_plans p
+		JOIN   users t ON t.id = p.therapist_id
+		JOIN   therapist_clients l
+		       ON  l.therapist_id = p.therapist_id
+		       AND l.client_id    = p.client_id
+		       AND l.status       = 'active'
+		WHERE  p.client_id = $1
+		  AND  p.status    = 'active'
+		ORDER  BY p.created_at DESC
+	`
+	return r.queryPlans(ctx, query, clientID)
+}
+
+func (r *PostgresPlanRepo) queryPlans(ctx context.Context, query string, args ...any) ([]*plandomain.Plan, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := make([]*plandomain.Plan, 0)
+	byID := make(map[uuid.UUID]*plandomain.Plan)
+	for rows.Next() {
+		var (
+			plan   plandomain.Plan
+			status string
+		)
+		if err := rows.Scan(
+			&plan.ID, &plan.TherapistID, &plan.ClientID, &plan.Title, &plan.Notes, &plan.StartsOn, &status,
+			&plan.CreatedAt, &plan.UpdatedAt, &plan.TherapistName,
+		); err != nil {
+			return nil, fmt.Errorf("scan plan row: %w", err)
+		}
+		plan.Status = plandomain.PlanStatus(status)
+		plan.Items = make([]plandomain.Item, 0)
+		plans = append(plans, &plan)
+		byID[plan.ID] = &plan
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate plan rows: %w", err)
+	}
+	if len(plans) == 0 {
+		return plans, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(plans))
+	for _, plan := range plans {
+		ids = append(ids, plan.ID)
+	}
+	if err := r.loadItems(ctx, ids, byID); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (r *PostgresPlanRepo) loadItems(ctx context.Context, planIDs []uuid.UUID, byID map[uuid.UUID]*plandomain.Plan) error {
+	query := `
+		SELECT plan_id, id, kind, COALESCE(chapter_id, ''), COALESCE(exercise_id, ''), COALESCE(tool_type, ''),
+		       times_per_week, minutes_per_week, due_date
+		FROM   practice_plan_items
+		WHERE  plan_id = ANY ($1)
+		ORDER  BY plan_id, position
+	`
+	rows, err := r.db.Query(ctx, query, planIDs)
+	if err != nil {
+		return fmt.Errorf("query plan items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			planID  uuid.UUID
+			item    plandomain.Item
+			kind    string
+			minutes *int16
+			times   int16
+		)
+		if err := rows.Scan(
+			&planID, &item.ID, &kind, &item.ChapterID, &item.ExerciseID, &item.ToolType,
+			&times, &minutes, &item.DueDate,
+		); err != nil {
+			return fmt.Errorf("scan plan item row: %w", err)
+		}
+		item.Kind = plandomain.ItemKind(kind)
+		item.TimesPerWeek = int(times)
+		if minutes != nil {
+			value := int(*minutes)
+			item.MinutesPerWeek = &value
+		}
+		if plan, ok := byID[planID]; ok {
+			plan.Items = append(plan.Items, item)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate plan item rows: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresPlanRepo) ArchiveActivePlan(ctx context.Context, therapistID, clientID uuid.UUID, archivedAt time.Time) error {
+	query := `
+		UPDATE practice_plans
+		SET    status     = 'archived',
+		       updated_at = $3
+		WHERE  therapist_id = $1
+		  AND  client_id    = $2
+		  AND  status       = 'active'
+	`
+	tag, err := r.db.Exec(ctx, query, therapistID, clientID, archivedAt)
+	if err != nil {
+		return fmt.Errorf("archive plan: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return plandomain.ErrPlanNotFound
+	}
+	return nil
+}
+
+func (r *PostgresPlanRepo) GetActivity(ctx context.Context, clientID uuid.UUID, timezone string, from, to time.Time) ([]plandomain.Activity, error) {
+	// The timestamp bounds are widened by a day on each side so the index
+	// on started_at/completed_at can be used before the exact civil-date
+	// filter is applied.
+	query := `
+		SELECT day, kind, key, SUM(count)::int, SUM(minutes)::float8
+		FROM (
+			SELECT (s.started_at AT TIME ZONE $2)::date AS day,
+			       'tool'                              AS kind,
+			       s.tool_type                         AS key,
+			       COUNT(*)                            AS count,
+			       SUM(s.duration_seconds) / 60.0      AS minutes
+			FROM   tool_sessions s
+			WHERE  s.user_id = $1
+			  AND  s.started_at >= $3::date - INTERVAL '1 day'
+			  AND  s.started_at <  $4::date + INTERVAL '2 days'
+			GROUP  BY 1, 3
+
+			UNION ALL
+
+			SELECT (e.completed_at AT TIME ZONE $2)::date,
+			       'exercise',
+			       e.exercise_id,
+			       COUNT(*),
+			       0
+			FROM   exercise_attempts e
+			WHERE  e.user_id = $1
+			  AND  e.completed_at >= $3::date - INTERVAL '1 day'
+			  AND  e.completed_at <  $4::date + INTERVAL '2 days'
+			GROUP  BY 1, 3
+		) a
+		WHERE  day BETWEEN $3::date AND $4::date
+		GROUP  BY day, kind, key
+		ORDER  BY day
+	`
+	rows, err := r.db.Query(ctx, query, clientID, timezone, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query plan activity: %w", err)
+	}
+	defer rows.Close()
+
+	activity := make([]plandomain.Activity, 0)
+	for rows.Next() {
+		var (
+			a    plandomain.Activity
+			kind string
+		)
+		if err := rows.Scan(&a.Date, &kind, &a.Key, &a.Count, &a.Minutes); err != nil {
+			return nil, fmt.Errorf("scan plan activity row: %w", err)
+		}
+		a.Kind = plandomain.ItemKind(kind)
+		activity = append(activity, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate plan activity rows: %w", err)
+	}
+	return activity, nil
+}
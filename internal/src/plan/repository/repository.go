@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+)
+
+type PlanRepository interface {
+	// ReplaceActivePlan archives the therapist's current plan for the
+	// client, if any, and stores the new one in the same transaction.
+	ReplaceActivePlan(ctx context.Context, plan *plandomain.Plan) error
+
+	FindActivePlan(ctx context.Context, therapistID, clientID uuid.UUID) (*plandomain.Plan, error)
+
+	// ListActivePlansForClient only returns plans from therapists the
+	// client is still linked to.
+	ListActivePlansForClient(ctx context.Context, clientID uuid.UUID) ([]*plandomain.Plan, error)
+
+	ArchiveActivePlan(ctx context.Context, therapistID, clientID uuid.UUID, archivedAt time.Time) error
+
+	// GetActivity groups the client's exercise completions and tool
+	// sessions by civil date in the given timezone.
+	GetActivity(ctx context.Context, clientID uuid.UUID, timezone string, from, to time.Time) ([]plandomain.Activity, error)
+}
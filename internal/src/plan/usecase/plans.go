@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+type AssignPlanInput struct {
+	Title    string
+	Notes    string
+	StartsOn *time.Time
+	Items    []plandomain.Item
+}
+
+// AssignPlan replaces the therapist's active plan for the client. Plans
+// are part of the client's therapy progress, so they need that scope.
+func (uc *PlanUseCase) AssignPlan(ctx context.Context, therapistID, clientID uuid.UUID, input AssignPlanInput) (*plandomain.PlanProgress, error) {
+	if _, err := uc.therapist.RequireClientScope(ctx, therapistID, clientID, therapistdomain.ScopeTherapyProgress); err != nil {
+		return nil, err
+	}
+
+	for _, item := range input.Items {
+		if item.Kind != plandomain.ItemExercise {
+			continue
+		}
+		if _, err := uc.catalog.ValidateExercise(item.ChapterID, item.ExerciseID); err != nil {
+			return nil, err
+		}
+	}
+
+	loc, err := uc.clientLocation(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	startsOn := localDate(time.Now(), loc)
+	if input.StartsOn != nil {
+		startsOn = *input.StartsOn
+	}
+
+	plan, err := plandomain.NewPlan(therapistID, clientID, input.Title, input.Notes, startsOn, input.Items, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err = uc.planRepo.ReplaceActivePlan(ctx, plan); err != nil {
+		return nil, fmt.Errorf("save plan: %w", err)
+	}
+
+	stored, err := uc.planRepo.FindActivePlan(ctx, therapistID, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("reload plan: %w", err)
+	}
+	return uc.withAdherence(ctx, stored, loc)
+}
+
+func (uc *PlanUseCase) GetClientPlan(ctx context.Context, therapistID, clientID uuid.UUID) (*plandomain.PlanProgress, error) {
+	if _, err := uc.therapist.RequireClientScope(ctx, therapistID, clientID, therapistdomain.ScopeTherapyProgress); err != nil {
+		return nil, err
+	}
+
+	plan, err := uc.planRepo.FindActivePlan(ctx, therapistID, clientID)
+	if err != nil {
+		if errors.Is(err, plandomain.ErrPlanNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("find plan: %w", err)
+	}
+
+	loc, err := uc.clientLocation(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.withAdherence(ctx, plan, loc)
+}
+
+func (uc *PlanUseCase) ArchivePlan(ctx context.Context, therapistID, clientID uuid.UUID) error {
+	if _, err := uc.therapist.RequireClientScope(ctx, therapistID, clientID, therapistdomain.ScopeTherapyProgress); err != nil {
+		return err
+	}
+
+	if err := uc.planRepo.ArchiveActivePlan(ctx, therapistID, clientID, time.Now().UTC()); err != nil {
+		if errors.Is(err, plandomain.ErrPlanNotFound) {
+			return err
+		}
+		return fmt.Errorf("archive plan: %w", err)
+	}
+	return nil
+}
+
+// GetMyPlans returns every active plan assigned to the client, one per
+// linked therapist.
+func (uc *PlanUseCase) GetMyPlans(ctx context.Context, clientID uuid.UUID) ([]*plandomain.PlanProgress, error) {
+	plans, err := uc.planRepo.ListActivePlansForClient(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("list plans: %w", err)
+	}
+
+	loc, err := uc.clientLocation(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*plandomain.PlanProgress, 0, len(plans))
+	for _, plan := range plans {
+		progress, err := uc.withAdherence(ctx, plan, loc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, progress)
+	}
+	return out, nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	plandomain "saythis-backend/internal/src/plan/domain"
+	planrepo "saythis-backend/internal/src/plan/repository"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+	userrepo "saythis-backend/internal/src/user/repository"
+)
+
+type PlanUseCase struct {
+	planRepo  planrepo.PlanRepository
+	userRepo  userrepo.UserRepository
+	therapist *therapistusecase.TherapistUseCase
+	catalog   *therapydomain.Catalog
+}
+
+func NewPlanUseCase(
+	planRepo planrepo.PlanRepository,
+	userRepo userrepo.UserRepository,
+	therapist *therapistusecase.TherapistUseCase,
+	catalog *therapydomain.Catalog,
+) *PlanUseCase {
+	return &PlanUseCase{
+		planRepo:  planRepo,
+		userRepo:  userRepo,
+		therapist: therapist,
+		catalog:   catalog,
+	}
+}
+
+func (uc *PlanUseCase) clientLocation(ctx context.Context, clientID uuid.UUID) (*time.Location, error) {
+	user, err := uc.userRepo.GetByID(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("get client timezone: %w", err)
+	}
+	return user.Location(), nil
+}
+
+func (uc *PlanUseCase) withAdherence(ctx context.Context, plan *plandomain.Plan, loc *time.Location) (*plandomain.PlanProgress, error) {
+	today := localDate(time.Now(), loc)
+	if today.Before(plan.StartsOn) {
+		return &plandomain.PlanProgress{Plan: plan, Weeks: []plandomain.WeekAdherence{}}, nil
+	}
+
+	from, to := plandomain.AdherenceRange(plan, today)
+	activity, err := uc.planRepo.GetActivity(ctx, plan.ClientID, loc.String(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get plan activity: %w", err)
+	}
+	return &plandomain.PlanProgress{
+		Plan:  plan,
+		Weeks: plandomain.CalculateAdherence(plan, activity, today),
+	}, nil
+}
+
+func localDate(value time.Time, loc *time.Location) time.Time {
+	year, month, day := value.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
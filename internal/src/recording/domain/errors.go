@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+var (
+	ErrAudioRequired      = errors.New("audio file is required")
+	ErrUnsupportedFormat  = errors.New("only WAV and Ogg Opus recordings are supported")
+	ErrMalformedAudio     = errors.New("audio file is malformed")
+	ErrRecordingTooLarge  = errors.New("recording exceeds 25 MB")
+	ErrRecordingTooShort  = errors.New("recording must be at least half a second long")
+	ErrRecordingTooLong   = errors.New("recording must be at most 10 minutes long")
+	ErrInvalidLinkTarget  = errors.New("link exactly one of daily_stat_id or tool_session_id")
+	ErrLinkTargetNotFound = errors.New("daily stat or tool session not found")
+	ErrRecordingNotFound  = errors.New("recording not found")
+	ErrInvalidSignature   = errors.New("download link is invalid or has expired")
+)
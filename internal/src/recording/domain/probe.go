@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"time"
+)
+
+// sniffLength is how many leading bytes DetectFormat needs.
+const sniffLength = 64
+
+// DetectFormat identifies a recording from its leading bytes rather than
+// the client's declared content type.
+func DetectFormat(head []byte) (Format, error) {
+	switch {
+	case len(head) >= 12 && bytes.Equal(head[0:4], []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return FormatWAV, nil
+	case len(head) >= 27 && bytes.Equal(head[0:4], []byte("OggS")):
+		body := 27 + int(head[26])
+		if len(head) >= body+8 && bytes.Equal(head[body:body+8], []byte("OpusHead")) {
+			return FormatOpus, nil
+		}
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Probe sniffs the format and reads the duration from the container
+// headers without decoding any audio.
+func Probe(r io.ReaderAt, size int64) (Format, time.Duration, error) {
+	head := make([]byte, sniffLength)
+	n, err := r.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", 0, err
+	}
+
+	format, err := DetectFormat(head[:n])
+	if err != nil {
+		return "", 0, err
+	}
+
+	var duration time.Duration
+	switch format {
+	case FormatWAV:
+		var info WAVInfo
+		info, err = ParseWAV(r, size)
+		duration = info.Duration()
+	case FormatOpus:
+		duration, err = opusDuration(r, size)
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	return format, duration, nil
+}
+
+// WAVInfo is the subset of a RIFF/WAVE header needed to locate and
+// interpret the sample data.
+type WAVInfo struct {
+	AudioFormat   uint16
+	Channels      uint16
+	SampleRate    uint32
+	ByteRate      uint32
+	BlockAlign    uint16
+	BitsPerSample uint16
+	DataOffset    int64
+	DataSize      int64
+}
+
+func (w WAVInfo) Duration() time.Duration {
+	if w.ByteRate == 0 {
+		return 0
+	}
+	return time.Duration(float64(w.DataSize) / float64(w.ByteRate) * float64(time.Second))
+}
+
+// ParseWAV walks the RIFF chunks for "fmt " and "data". A data chunk that
+// claims to run past the end of the file (as streaming encoders write it)
+// is clamped to what is actually there. The block alignment and byte rate
+// have to agree with the sample layout, since the duration and the sample
+// reads both rely on them.
+func ParseWAV(r io.ReaderAt, size int64) (WAVInfo, error) {
+	var info WAVInfo
+	var haveFormat, haveData bool
+
+	header := make([]byte, 8)
+	for offset := int64(12); offset+8 <= size && !(haveFormat && haveData); {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return WAVInfo{}, ErrMalformedAudio
+		}
+		chunkSize := int64(binary.LittleEndian.Uint32(header[4:8]))
+		body := offset + 8
+
+		switch string(header[0:4]) {
+		case "fmt ":
+			if chunkSize < 16 {
+				return WAVInfo{}, ErrMalformedAudio
+			}
+			fmtChunk := make([]byte, 16)
+			if _, err := r.ReadAt(fmtChunk, body); err != nil {
+				return WAVInfo{}, ErrMalformedAudio
+			}
+			info.AudioFormat = binary.LittleEndian.Uint16(fmtChunk[0:2])
+			info.Channels = binary.LittleEndian.Uint16(fmtChunk[2:4])
+			info.SampleRate = binary.LittleEndian.Uint32(fmtChunk[4:8])
+			info.ByteRate = binary.LittleEndian.Uint32(fmtChunk[8:12])
+			info.BlockAlign = binary.LittleEndian.Uint16(fmtChunk[12:14])
+			info.BitsPerSample = binary.LittleEndian.Uint16(fmtChunk[14:16])
+			haveFormat = true
+		case "data":
+			info.DataOffset = body
+			info.DataSize = min(chunkSize, size-body)
+			haveData = true
+		}
+
+		offset = body + chunkSize + chunkSize%2
+	}
+
+	if !haveFormat || !haveData || info.Channels == 0 || info.ByteRate == 0 {
+		return WAVInfo{}, ErrMalformedAudio
+	}
+	if uint32(info.BlockAlign) != uint32(info.Channels)*uint32(info.BitsPerSample)/8 ||
+		uint64(info.ByteRate) != uint64(info.SampleRate)*uint64(info.BlockAlign) {
+		return WAVInfo{}, ErrMalformedAudio
+	}
+	return info, nil
+}
+
+// opusDuration reads the pre-skip from the OpusHead packet and the final
+// granule position from the last Ogg page. Opus granules always count
+// 48 kHz samples regardless of the input rate.
+func opusDuration(r io.ReaderAt, size int64) (time.Duration, error) {
+	first := make([]byte, 27+255)
+	n, err := r.ReadAt(first, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, ErrMalformedAudio
+	}
+	first = first[:n]
+	if len(first) < 27 {
+		return 0, ErrMalformedAudio
+	}
+	body := 27 + int(first[26])
+	opusHead := make([]byte, 19)
+	if _, err := r.ReadAt(opusHead, int64(body)); err != nil {
+		return 0, ErrMalformedAudio
+	}
+	preSkip := int64(binary.LittleEndian.Uint16(opusHead[10:12]))
+
+	// An Ogg page is at most 65307 bytes, so the last page header is
+	// always within this window.
+	const tailWindow = 65307 + 27
+	start := max(size-tailWindow, 0)
+	tail := make([]byte, size-start)
+	if _, err := r.ReadAt(tail, start); err != nil && !errors.Is(err, io.EOF) {
+		return 0, ErrMalformedAudio
+	}
+
+	last := bytes.LastIndex(tail, []byte("OggS"))
+	if last < 0 || last+14 > len(tail) {
+		return 0, ErrMalformedAudio
+	}
+	granule := int64(binary.LittleEndian.Uint64(tail[last+6 : last+14]))
+	if granule <= preSkip {
+		return 0, ErrMalformedAudio
+	}
+	return time.Duration(float64(granule-preSkip) / 48000 * float64(time.Second)), nil
+}
@@ -0,0 +1,108 @@
+package domain_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+)
+
+func wavFile(sampleRate, samples int) []byte {
+	var b bytes.Buffer
+	dataSize := samples * 2
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+dataSize))
+	b.WriteString("WAVEfmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))
+	binary.Write(&b, binary.LittleEndian, uint16(1))
+	binary.Write(&b, binary.LittleEndian, uint16(1))
+	binary.Write(&b, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(&b, binary.LittleEndian, uint32(sampleRate*2))
+	binary.Write(&b, binary.LittleEndian, uint16(2))
+	binary.Write(&b, binary.LittleEndian, uint16(16))
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(dataSize))
+	b.Write(make([]byte, dataSize))
+	return b.Bytes()
+}
+
+// patchWAV overwrites a little-endian field of the fmt chunk, at its
+// offset from the start of the file.
+func patchWAV(data []byte, offset int, value any) []byte {
+	var b bytes.Buffer
+	binary.Write(&b, binary.LittleEndian, value)
+	patched := bytes.Clone(data)
+	copy(patched[offset:], b.Bytes())
+	return patched
+}
+
+func oggPage(granule int64, body []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("OggS")
+	b.Write([]byte{0, 0})
+	binary.Write(&b, binary.LittleEndian, granule)
+	b.Write(make([]byte, 12))
+	b.WriteByte(1)
+	b.WriteByte(byte(len(body)))
+	b.Write(body)
+	return b.Bytes()
+}
+
+func opusFile(preSkip uint16, finalGranule int64) []byte {
+	head := []byte("OpusHead")
+	head = append(head, 1, 1)
+	head = binary.LittleEndian.AppendUint16(head, preSkip)
+	head = append(head, make([]byte, 7)...)
+
+	file := oggPage(0, head)
+	file = append(file, oggPage(0, []byte("OpusTags"))...)
+	return append(file, oggPage(finalGranule, make([]byte, 40))...)
+}
+
+func TestProbe_WAV(t *testing.T) {
+	data := wavFile(16000, 24000)
+	format, duration, err := recordingdomain.Probe(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != recordingdomain.FormatWAV || duration != 1500*time.Millisecond {
+		t.Errorf("want 1.5s wav, got %v %v", duration, format)
+	}
+}
+
+func TestProbe_Opus(t *testing.T) {
+	data := opusFile(312, 312+96000)
+	format, duration, err := recordingdomain.Probe(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if format != recordingdomain.FormatOpus || duration != 2*time.Second {
+		t.Errorf("want 2s opus, got %v %v", duration, format)
+	}
+}
+
+func TestProbe_RejectsUnknownAndMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"mp3", append([]byte("ID3\x04"), make([]byte, 60)...), recordingdomain.ErrUnsupportedFormat},
+		{"ogg vorbis", oggPage(0, []byte("\x01vorbis-header-bytes")), recordingdomain.ErrUnsupportedFormat},
+		{"truncated wav", wavFile(16000, 100)[:30], recordingdomain.ErrMalformedAudio},
+		{"wav byte rate off", patchWAV(wavFile(16000, 100), 28, uint32(16000)), recordingdomain.ErrMalformedAudio},
+		{"wav block align off", patchWAV(wavFile(16000, 100), 32, uint16(4)), recordingdomain.ErrMalformedAudio},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := recordingdomain.Probe(bytes.NewReader(tc.data), int64(len(tc.data)))
+			if !errors.Is(err, tc.want) {
+				t.Errorf("want %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
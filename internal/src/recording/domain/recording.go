@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxRecordingBytes    = 25 << 20
+	MinRecordingDuration = 500 * time.Millisecond
+	MaxRecordingDuration = 10 * time.Minute
+
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+type Format string
+
+const (
+	FormatWAV  Format = "wav"
+	FormatOpus Format = "opus"
+)
+
+func (f Format) ContentType() string {
+	if f == FormatOpus {
+		return "audio/ogg"
+	}
+	return "audio/wav"
+}
+
+func (f Format) Extension() string {
+	if f == FormatOpus {
+		return ".opus"
+	}
+	return ".wav"
+}
+
+// Recording is a stored speech sample. It is linked to exactly one daily
+// stat or tool session when uploaded; the link is cleared if that record
+// is later deleted.
+type Recording struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	DailyStatID   *uuid.UUID
+	ToolSessionID *uuid.UUID
+	Format        Format
+	SizeBytes     int64
+	Duration      time.Duration
+	StorageKey    string
+	CreatedAt     time.Time
+}
+
+func NewRecording(
+	userID uuid.UUID,
+	dailyStatID, toolSessionID *uuid.UUID,
+	format Format,
+	sizeBytes int64,
+	duration time.Duration,
+	now time.Time,
+) (*Recording, error) {
+	if (dailyStatID == nil) == (toolSessionID == nil) {
+		return nil, ErrInvalidLinkTarget
+	}
+	if sizeBytes > MaxRecordingBytes {
+		return nil, ErrRecordingTooLarge
+	}
+	if duration < MinRecordingDuration {
+		return nil, ErrRecordingTooShort
+	}
+	if duration > MaxRecordingDuration {
+		return nil, ErrRecordingTooLong
+	}
+
+	id := uuid.New()
+	return &Recording{
+		ID:            id,
+		UserID:        userID,
+		DailyStatID:   dailyStatID,
+		ToolSessionID: toolSessionID,
+		Format:        format,
+		SizeBytes:     sizeBytes,
+		Duration:      duration,
+		StorageKey:    "recordings/" + userID.String() + "/" + id.String() + format.Extension(),
+		CreatedAt:     now,
+	}, nil
+}
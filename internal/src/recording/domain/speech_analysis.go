@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"sort"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+const (
+	frameSeconds = 0.01
+
+	// Runs of speech shorter than this are clicks or breaths, and gaps
+	// shorter than a pause are the closures inside normal articulation.
+	minSpeechFrames = 6
+	minPauseFrames  = int(statsdomain.MinPauseSeconds / frameSeconds)
+
+	// silenceFloorDB is the level below which a frame is always silent,
+	// and flatRangeDB the loudness spread under which the recording is
+	// treated as a single level rather than speech over background.
+	silenceFloorDB = -60.0
+	flatRangeDB    = 6.0
+	flatSpeechDB   = -45.0
+)
+
+// ErrNotAnalyzable means the audio is valid but not in a form the speech
+// analyzer reads. Uploads still succeed; they just have no metrics.
+var ErrNotAnalyzable = errors.New("only 16-bit PCM WAV recordings can be analyzed")
+
+// AnalyzeSpeech runs an energy-based voice activity detector over 16-bit
+// PCM samples in 10 ms frames. The speech threshold adapts to the
+// recording: it sits a third of the way from the background level (10th
+// percentile) to the speech level (95th percentile).
+func AnalyzeSpeech(r io.ReaderAt, info WAVInfo) (statsdomain.SpeechMetrics, error) {
+	if info.AudioFormat != 1 || info.BitsPerSample != 16 || info.SampleRate == 0 {
+		return statsdomain.SpeechMetrics{}, ErrNotAnalyzable
+	}
+
+	levels, err := frameLevels(r, info)
+	if err != nil {
+		return statsdomain.SpeechMetrics{}, err
+	}
+
+	voiced := classifyFrames(levels)
+	smoothRuns(voiced, true, minSpeechFrames)
+
+	first, last := -1, -1
+	for i, v := range voiced {
+		if v {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first >= 0 {
+		smoothRuns(voiced[first:last+1], false, minPauseFrames)
+	}
+
+	return measureRuns(voiced, first, last, info.Duration().Seconds()), nil
+}
+
+// frameLevels returns the RMS level of each frame in dBFS, with all
+// channels mixed together.
+func frameLevels(r io.ReaderAt, info WAVInfo) ([]float64, error) {
+	channels := int(info.Channels)
+	frameSamples := max(int(float64(info.SampleRate)*frameSeconds), 1) * channels
+
+	reader := bufio.NewReaderSize(io.NewSectionReader(r, info.DataOffset, info.DataSize), 64<<10)
+	sample := make([]byte, 2)
+	levels := make([]float64, 0, int(info.Duration().Seconds()/frameSeconds)+1)
+
+	var sumSquares float64
+	count := 0
+	for {
+		if _, err := io.ReadFull(reader, sample); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				break
+			}
+			return nil, ErrMalformedAudio
+		}
+		value := float64(int16(binary.LittleEndian.Uint16(sample))) / 32768
+		sumSquares += value * value
+		count++
+
+		if count == frameSamples {
+			levels = append(levels, toDB(sumSquares/float64(count)))
+			sumSquares, count = 0, 0
+		}
+	}
+	if count >= frameSamples/2 {
+		levels = append(levels, toDB(sumSquares/float64(count)))
+	}
+	return levels, nil
+}
+
+func toDB(meanSquare float64) float64 {
+	if meanSquare <= 0 {
+		return -120
+	}
+	return 10 * math.Log10(meanSquare)
+}
+
+func classifyFrames(levels []float64) []bool {
+	voiced := make([]bool, len(levels))
+	if len(levels) == 0 {
+		return voiced
+	}
+
+	sorted := append([]float64(nil), levels...)
+	sort.Float64s(sorted)
+	background := sorted[len(sorted)/10]
+	speech := sorted[len(sorted)*95/100]
+
+	threshold := background + (speech-background)/3
+	if speech-background < flatRangeDB {
+		threshold = flatSpeechDB
+	}
+	threshold = max(threshold, silenceFloorDB)
+
+	for i, level := range levels {
+		voiced[i] = level > threshold
+	}
+	return voiced
+}
+
+// smoothRuns flips runs of value shorter than minFrames, so short speech
+// blips become silence and short gaps become speech.
+func smoothRuns(frames []bool, value bool, minFrames int) {
+	for start := 0; start < len(frames); {
+		end := start
+		for end < len(frames) && frames[end] == frames[start] {
+			end++
+		}
+		if frames[start] == value && end-start < minFrames {
+			for i := start; i < end; i++ {
+				frames[i] = !value
+			}
+		}
+		start = end
+	}
+}
+
+// measureRuns only counts silences between the first and last speech as
+// pauses; lead-in and trailing silence are not part of the utterance.
+func measureRuns(voiced []bool, first, last int, duration float64) statsdomain.SpeechMetrics {
+	metrics := statsdomain.SpeechMetrics{DurationSeconds: round2(duration)}
+	if first < 0 {
+		return metrics
+	}
+
+	var speechFrames int
+	var pauseTotal float64
+	for start := first; start <= last; {
+		end := start
+		for end <= last && voiced[end] == voiced[start] {
+			end++
+		}
+		seconds := float64(end-start) * frameSeconds
+
+		if voiced[start] {
+			speechFrames += end - start
+			metrics.LongestSpeechSeconds = max(metrics.LongestSpeechSeconds, seconds)
+		} else {
+			metrics.PauseCount++
+			pauseTotal += seconds
+			metrics.LongestPauseSeconds = max(metrics.LongestPauseSeconds, seconds)
+			switch {
+			case seconds < statsdomain.MediumPauseSeconds:
+				metrics.ShortPauses++
+			case seconds < statsdomain.LongPauseSeconds:
+				metrics.MediumPauses++
+			default:
+				metrics.LongPauses++
+			}
+		}
+		start = end
+	}
+
+	metrics.SpeechSeconds = round2(min(float64(speechFrames)*frameSeconds, duration))
+	metrics.LongestSpeechSeconds = round2(metrics.LongestSpeechSeconds)
+	metrics.LongestPauseSeconds = round2(metrics.LongestPauseSeconds)
+	if metrics.PauseCount > 0 {
+		metrics.MeanPauseSeconds = round2(pauseTotal / float64(metrics.PauseCount))
+	}
+	return metrics
+}
+
+func round2(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,105 @@
+package domain_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+)
+
+// segment is a stretch of either a 200 Hz tone or near-silent noise.
+type segment struct {
+	seconds float64
+	speech  bool
+}
+
+func synthesizeWAV(t *testing.T, sampleRate int, segments ...segment) ([]byte, recordingdomain.WAVInfo) {
+	t.Helper()
+
+	var samples []int16
+	for _, seg := range segments {
+		n := int(seg.seconds * float64(sampleRate))
+		for i := 0; i < n; i++ {
+			value := float64((i*7919)%13 - 6)
+			if seg.speech {
+				value = 8000 * math.Sin(2*math.Pi*200*float64(i)/float64(sampleRate))
+			}
+			samples = append(samples, int16(value))
+		}
+	}
+
+	data := wavFile(sampleRate, len(samples))
+	pcm := data[44:]
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(s))
+	}
+
+	info, err := recordingdomain.ParseWAV(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data, info
+}
+
+func TestAnalyzeSpeech_PausesAndRuns(t *testing.T) {
+	data, info := synthesizeWAV(t, 16000,
+		segment{0.4, false},
+		segment{1.0, true},
+		segment{0.8, false},
+		segment{0.5, true},
+		segment{0.3, false},
+		segment{1.0, true},
+		segment{0.1, false},
+		segment{0.6, true},
+		segment{2.4, false},
+		segment{0.5, true},
+		segment{0.4, false},
+	)
+
+	m, err := recordingdomain.AnalyzeSpeech(bytes.NewReader(data), info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.DurationSeconds != 8 {
+		t.Errorf("want 8s duration, got %v", m.DurationSeconds)
+	}
+	// The 0.1s gap is below the pause threshold and counts as speech.
+	if m.SpeechSeconds != 3.7 {
+		t.Errorf("want 3.7s of speech, got %v", m.SpeechSeconds)
+	}
+	if m.PauseCount != 3 || m.ShortPauses != 1 || m.MediumPauses != 1 || m.LongPauses != 1 {
+		t.Errorf("want one short, one medium and one long pause, got %+v", m)
+	}
+	if m.LongestPauseSeconds != 2.4 || m.LongestSpeechSeconds != 1.7 {
+		t.Errorf("want longest pause 2.4s and speech run 1.7s, got %+v", m)
+	}
+
+	words := 10
+	if rate := m.ArticulationRate(&words); rate == nil || *rate != 162.2 {
+		t.Errorf("want 162.2 words per speaking minute, got %v", rate)
+	}
+}
+
+func TestAnalyzeSpeech_SilentRecording(t *testing.T) {
+	data, info := synthesizeWAV(t, 8000, segment{2, false})
+
+	m, err := recordingdomain.AnalyzeSpeech(bytes.NewReader(data), info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.SpeechSeconds != 0 || m.PauseCount != 0 {
+		t.Errorf("want no speech and no pauses, got %+v", m)
+	}
+}
+
+func TestAnalyzeSpeech_RejectsNonPCM16(t *testing.T) {
+	info := recordingdomain.WAVInfo{AudioFormat: 3, Channels: 1, SampleRate: 16000, BitsPerSample: 32}
+	_, err := recordingdomain.AnalyzeSpeech(bytes.NewReader(nil), info)
+	if !errors.Is(err, recordingdomain.ErrNotAnalyzable) {
+		t.Errorf("want ErrNotAnalyzable, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/recording/usecase"
+)
+
+// DownloadRecordingHandler streams a recording to anyone holding a valid,
+// unexpired signed link. It takes no bearer token so audio elements can
+// load it directly, and it supports range requests for seeking.
+type DownloadRecordingHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewDownloadRecordingHandler(uc *usecase.RecordingUseCase) *DownloadRecordingHandler {
+	return &DownloadRecordingHandler{usecase: uc}
+}
+
+func (h *DownloadRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	recordingID, err := uuid.Parse(r.PathValue("recordingID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid recording id")
+		return
+	}
+
+	query := r.URL.Query()
+	rec, body, err := h.usecase.OpenDownload(r.Context(), recordingID, query.Get("expires"), query.Get("signature"))
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+	defer body.Close()
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(transferTimeout))
+
+	filename := rec.ID.String() + rec.Format.Extension()
+	w.Header().Set("Content-Type", rec.Format.ContentType())
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeContent(w, r, filename, rec.CreatedAt, body)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+var errInvalidPagination = errors.New("limit and offset must be integers")
+
+func mapRecordingError(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, recordingdomain.ErrAudioRequired),
+		errors.Is(err, recordingdomain.ErrMalformedAudio),
+		errors.Is(err, recordingdomain.ErrRecordingTooShort),
+		errors.Is(err, recordingdomain.ErrRecordingTooLong),
+		errors.Is(err, recordingdomain.ErrInvalidLinkTarget),
+		errors.Is(err, errInvalidPagination):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, recordingdomain.ErrUnsupportedFormat):
+		return http.StatusUnsupportedMediaType, err.Error()
+
+	case errors.Is(err, recordingdomain.ErrRecordingTooLarge):
+		return http.StatusRequestEntityTooLarge, err.Error()
+
+	case errors.Is(err, recordingdomain.ErrLinkTargetNotFound),
+		errors.Is(err, recordingdomain.ErrRecordingNotFound),
+		errors.Is(err, therapistdomain.ErrClientNotLinked):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, recordingdomain.ErrInvalidSignature),
+		errors.Is(err, therapistdomain.ErrScopeNotGranted):
+		return http.StatusForbidden, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
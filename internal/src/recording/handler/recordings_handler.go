@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/recording/usecase"
+)
+
+type ListRecordingsHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewListRecordingsHandler(uc *usecase.RecordingUseCase) *ListRecordingsHandler {
+	return &ListRecordingsHandler{usecase: uc}
+}
+
+func (h *ListRecordingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	page, err := h.usecase.ListRecordings(r.Context(), claims.UserID, limit, offset)
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, toListRecordingsResponse(page))
+}
+
+type GetRecordingHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewGetRecordingHandler(uc *usecase.RecordingUseCase) *GetRecordingHandler {
+	return &GetRecordingHandler{usecase: uc}
+}
+
+func (h *GetRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	recordingID, err := uuid.Parse(r.PathValue("recordingID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid recording id")
+		return
+	}
+
+	signed, err := h.usecase.GetRecording(r.Context(), claims.UserID, recordingID)
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, recordingResponse{Recording: toRecordingPayload(signed)})
+}
+
+type DeleteRecordingHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewDeleteRecordingHandler(uc *usecase.RecordingUseCase) *DeleteRecordingHandler {
+	return &DeleteRecordingHandler{usecase: uc}
+}
+
+func (h *DeleteRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	recordingID, err := uuid.Parse(r.PathValue("recordingID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid recording id")
+		return
+	}
+
+	if err := h.usecase.DeleteRecording(r.Context(), claims.UserID, recordingID); err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListClientRecordingsHandler serves a linked client's recordings to their
+// therapist, each with a signed download link.
+type ListClientRecordingsHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewListClientRecordingsHandler(uc *usecase.RecordingUseCase) *ListClientRecordingsHandler {
+	return &ListClientRecordingsHandler{usecase: uc}
+}
+
+func (h *ListClientRecordingsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	limit, offset, err := parsePagination(r)
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	page, err := h.usecase.ListClientRecordings(r.Context(), claims.UserID, clientID, limit, offset)
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, toListRecordingsResponse(page))
+}
+
+func parsePagination(r *http.Request) (limit, offset int, err error) {
+	query := r.URL.Query()
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil {
+			return 0, 0, errInvalidPagination
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if offset, err = strconv.Atoi(value); err != nil {
+			return 0, 0, errInvalidPagination
+		}
+	}
+	return limit, offset, nil
+}
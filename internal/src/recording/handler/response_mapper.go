@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/src/recording/usecase"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+type recordingPayload struct {
+	ID                uuid.UUID             `json:"id"`
+	DailyStatID       *uuid.UUID            `json:"daily_stat_id"`
+	ToolSessionID     *uuid.UUID            `json:"tool_session_id"`
+	Format            string                `json:"format"`
+	ContentType       string                `json:"content_type"`
+	SizeBytes         int64                 `json:"size_bytes"`
+	DurationSeconds   float64               `json:"duration_seconds"`
+	DownloadURL       string                `json:"download_url"`
+	DownloadExpiresAt time.Time             `json:"download_expires_at"`
+	Speech            *speechMetricsPayload `json:"speech,omitempty"`
+	CreatedAt         time.Time             `json:"created_at"`
+}
+
+type speechMetricsPayload struct {
+	DurationSeconds      float64 `json:"duration_seconds"`
+	SpeechSeconds        float64 `json:"speech_seconds"`
+	SpeechRatio          float64 `json:"speech_ratio"`
+	PauseCount           int     `json:"pause_count"`
+	PausesPerMinute      float64 `json:"pauses_per_minute"`
+	ShortPauses          int     `json:"short_pauses"`
+	MediumPauses         int     `json:"medium_pauses"`
+	LongPauses           int     `json:"long_pauses"`
+	MeanPauseSeconds     float64 `json:"mean_pause_seconds"`
+	LongestPauseSeconds  float64 `json:"longest_pause_seconds"`
+	LongestSpeechSeconds float64 `json:"longest_speech_seconds"`
+}
+
+type recordingResponse struct {
+	Recording recordingPayload `json:"recording"`
+}
+
+type listRecordingsResponse struct {
+	Recordings []recordingPayload `json:"recordings"`
+	Total      int                `json:"total"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+}
+
+func toRecordingPayload(signed *usecase.SignedRecording) recordingPayload {
+	rec := signed.Recording
+	return recordingPayload{
+		ID:                rec.ID,
+		DailyStatID:       rec.DailyStatID,
+		ToolSessionID:     rec.ToolSessionID,
+		Format:            string(rec.Format),
+		ContentType:       rec.Format.ContentType(),
+		SizeBytes:         rec.SizeBytes,
+		DurationSeconds:   rec.Duration.Seconds(),
+		DownloadURL:       signed.Download.URL,
+		DownloadExpiresAt: signed.Download.ExpiresAt,
+		Speech:            toSpeechMetricsPayload(signed.Speech),
+		CreatedAt:         rec.CreatedAt,
+	}
+}
+
+func toSpeechMetricsPayload(m *statsdomain.SpeechMetrics) *speechMetricsPayload {
+	if m == nil {
+		return nil
+	}
+	return &speechMetricsPayload{
+		DurationSeconds:      m.DurationSeconds,
+		SpeechSeconds:        m.SpeechSeconds,
+		SpeechRatio:          m.SpeechRatio(),
+		PauseCount:           m.PauseCount,
+		PausesPerMinute:      m.PausesPerMinute(),
+		ShortPauses:          m.ShortPauses,
+		MediumPauses:         m.MediumPauses,
+		LongPauses:           m.LongPauses,
+		MeanPauseSeconds:     m.MeanPauseSeconds,
+		LongestPauseSeconds:  m.LongestPauseSeconds,
+		LongestSpeechSeconds: m.LongestSpeechSeconds,
+	}
+}
+
+func toListRecordingsResponse(page *usecase.RecordingPage) listRecordingsResponse {
+	items := make([]recordingPayload, 0, len(page.Recordings))
+	for _, signed := range page.Recordings {
+		items = append(items, toRecordingPayload(signed))
+	}
+	return listRecordingsResponse{
+		Recordings: items,
+		Total:      page.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+	}
+}
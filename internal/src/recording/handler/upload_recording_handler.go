@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+	"saythis-backend/internal/src/recording/usecase"
+)
+
+// multipartOverhead leaves room for the form fields and part headers on
+// top of the audio itself.
+const multipartOverhead = 1 << 20
+
+// transferTimeout replaces the server's short read and write deadlines for
+// audio uploads and downloads, which can take a while on mobile networks.
+const transferTimeout = 2 * time.Minute
+
+type UploadRecordingHandler struct {
+	usecase *usecase.RecordingUseCase
+}
+
+func NewUploadRecordingHandler(uc *usecase.RecordingUseCase) *UploadRecordingHandler {
+	return &UploadRecordingHandler{usecase: uc}
+}
+
+// ServeHTTP accepts multipart/form-data with an "audio" file and exactly
+// one of "daily_stat_id" or "tool_session_id". The declared content type is
+// ignored; the format is sniffed from the file header.
+func (h *UploadRecordingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	_ = http.NewResponseController(w).SetReadDeadline(time.Now().Add(transferTimeout))
+	r.Body = http.MaxBytesReader(w, r.Body, recordingdomain.MaxRecordingBytes+multipartOverhead)
+
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := r.ParseMultipartForm(8 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			status, msg := mapRecordingError(recordingdomain.ErrRecordingTooLarge)
+			helper.Error(w, status, msg)
+			return
+		}
+		helper.Error(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, header, err := r.FormFile("audio")
+	if err != nil {
+		status, msg := mapRecordingError(recordingdomain.ErrAudioRequired)
+		helper.Error(w, status, msg)
+		return
+	}
+	defer file.Close()
+
+	dailyStatID, err := optionalUUIDField(r, "daily_stat_id")
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid daily_stat_id")
+		return
+	}
+	toolSessionID, err := optionalUUIDField(r, "tool_session_id")
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid tool_session_id")
+		return
+	}
+
+	signed, err := h.usecase.Upload(r.Context(), claims.UserID, usecase.UploadInput{
+		File:          file,
+		Size:          header.Size,
+		DailyStatID:   dailyStatID,
+		ToolSessionID: toolSessionID,
+	})
+	if err != nil {
+		status, msg := mapRecordingError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusCreated, recordingResponse{Recording: toRecordingPayload(signed)})
+}
+
+func optionalUUIDField(r *http.Request, field string) (*uuid.UUID, error) {
+	value := r.FormValue(field)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	statsrepo "saythis-backend/internal/src/stats/repository"
+)
+
+var _ RecordingRepository = (*PostgresRecordingRepo)(nil)
+
+type PostgresRecordingRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresRecordingRepo(db *pgxpool.Pool) *PostgresRecordingRepo {
+	return &PostgresRecordingRepo{db: db}
+}
+
+const recordingColumns = `
+	id, user_id, daily_stat_id, tool_session_id, format, size_bytes, duration_ms, storage_key, created_at
+`
+
+func (r *PostgresRecordingRepo) LinkTargetExists(ctx context.Context, userID uuid.UUID, dailyStatID, toolSessionID *uuid.UUID) (bool, error) {
+	query := `
+		SELECT EXISTS (SELECT 1 FROM user_daily_stats WHERE id = $2 AND user_id = $1)
+		    OR EXISTS (SELECT 1 FROM tool_sessions    WHERE id = $3 AND user_id = $1)
+	`
+	var exists bool
+	if err := r.db.QueryRow(ctx, query, userID, dailyStatID, toolSessionID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check recording link target: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *PostgresRecordingRepo) Create(ctx context.Context, rec *recordingdomain.Recording, metrics *statsdomain.SpeechMetrics) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO recordings (` + recordingColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.Exec(ctx, query,
+		rec.ID, rec.UserID, rec.DailyStatID, rec.ToolSessionID, string(rec.Format),
+		rec.SizeBytes, rec.Duration.Milliseconds(), rec.StorageKey, rec.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert recording: %w", err)
+	}
+
+	if metrics != nil && rec.DailyStatID != nil {
+		update := `
+			UPDATE user_daily_stats
+			SET    speech_recording_id    = $3,
+			       audio_duration_seconds = $4,
+			       speech_seconds         = $5,
+			       pause_count            = $6,
+			       short_pause_count      = $7,
+			       medium_pause_count     = $8,
+			       long_pause_count       = $9,
+			       mean_pause_seconds     = $10,
+			       longest_pause_seconds  = $11,
+			       longest_speech_seconds = $12,
+			       updated_at             = NOW()
+			WHERE  id = $1 AND user_id = $2
+		`
+		_, err = tx.Exec(ctx, update,
+			*rec.DailyStatID, rec.UserID, rec.ID,
+			metrics.DurationSeconds, metrics.SpeechSeconds, metrics.PauseCount,
+			metrics.ShortPauses, metrics.MediumPauses, metrics.LongPauses,
+			metrics.MeanPauseSeconds, metrics.LongestPauseSeconds, metrics.LongestSpeechSeconds,
+		)
+		if err != nil {
+			return fmt.Errorf("store speech metrics: %w", err)
+		}
+		if err := statsrepo.BumpDataVersion(ctx, tx, []uuid.UUID{rec.UserID}); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresRecordingRepo) FindByID(ctx context.Context, id uuid.UUID) (*recordingdomain.Recording, error) {
+	query := `SELECT ` + recordingColumns + ` FROM recordings WHERE id = $1`
+
+	rec, err := scanRecording(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, recordingdomain.ErrRecordingNotFound
+		}
+		return nil, fmt.Errorf("find recording: %w", err)
+	}
+	return rec, nil
+}
+
+func (r *PostgresRecordingRepo) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*recordingdomain.Recording, int, error) {
+	query := `
+		SELECT ` + recordingColumns + `, COUNT(*) OVER ()
+		FROM   recordings
+		WHERE  user_id = $1
+		ORDER  BY created_at DESC, id DESC
+		LIMIT  $2 OFFSET $3
+	`
+	rows, err := r.db.Query(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query recordings: %w", err)
+	}
+	defer rows.Close()
+
+	recordings := make([]*recordingdomain.Recording, 0)
+	total := 0
+	for rows.Next() {
+		rec, err := scanRecording(rows, &total)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan recording: %w", err)
+		}
+		recordings = append(recordings, rec)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate recordings: %w", err)
+	}
+
+	if len(recordings) == 0 && offset > 0 {
+		countQuery := `SELECT COUNT(*) FROM recordings WHERE user_id = $1`
+		if err := r.db.QueryRow(ctx, countQuery, userID).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count recordings: %w", err)
+		}
+	}
+	return recordings, total, nil
+}
+
+func (r *PostgresRecordingRepo) Delete(ctx context.Context, userID, id uuid.UUID) (*recordingdomain.Recording, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	clear := `
+		UPDATE user_daily_stats
+		SET    speech_recording_id    = NULL,
+		       audio_duration_seconds = NULL,
+		       speech_seconds         = NULL,
+		       pause_count            = NULL,
+		       short_pause_count      = NULL,
+		       medium_pause_count     = NULL,
+		       long_pause_count       = NULL,
+		       mean_pause_seconds     = NULL,
+		       longest_pause_seconds  = NULL,
+		       longest_speech_seconds = NULL,
+		       updated_at             = NOW()
+		WHERE  speech_recording_id = $1 AND user_id = $2
+	`
+	tag, err := tx.Exec(ctx, clear, id, userID)
+	if err != nil {
+		return nil, fmt.Errorf("clear speech metrics: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		if err := statsrepo.BumpDataVersion(ctx, tx, []uuid.UUID{userID}); err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		DELETE FROM recordings
+		WHERE  id = $1 AND user_id = $2
+		RETURNING ` + recordingColumns
+
+	rec, err := scanRecording(tx.QueryRow(ctx, query, id, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, recordingdomain.ErrRecordingNotFound
+		}
+		return nil, fmt.Errorf("delete recording: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+	return rec, nil
+}
+
+func scanRecording(row pgx.Row, extra ...any) (*recordingdomain.Recording, error) {
+	var (
+		rec        recordingdomain.Recording
+		format     string
+		durationMS int64
+	)
+	dest := []any{
+		&rec.ID, &rec.UserID, &rec.DailyStatID, &rec.ToolSessionID, &format,
+		&rec.SizeBytes, &durationMS, &rec.StorageKey, &rec.CreatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	rec.Format = recordingdomain.Format(format)
+	rec.Duration = time.Duration(durationMS) * time.Millisecond
+	return &rec, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+type RecordingRepository interface {
+	// LinkTargetExists reports whether the daily stat or tool session
+	// exists and belongs to the user.
+	LinkTargetExists(ctx context.Context, userID uuid.UUID, dailyStatID, toolSessionID *uuid.UUID) (bool, error)
+
+	// Create stores the recording and, when metrics are given, writes them
+	// onto the linked daily stat in the same transaction.
+	Create(ctx context.Context, recording *recordingdomain.Recording, metrics *statsdomain.SpeechMetrics) error
+	FindByID(ctx context.Context, id uuid.UUID) (*recordingdomain.Recording, error)
+
+	// ListByUser returns one page of recordings, newest first, and the
+	// total across all pages.
+	ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*recordingdomain.Recording, int, error)
+
+	// Delete removes the user's recording, clears any speech metrics taken
+	// from it, and returns it so the caller can clean up the stored blob.
+	Delete(ctx context.Context, userID, id uuid.UUID) (*recordingdomain.Recording, error)
+}
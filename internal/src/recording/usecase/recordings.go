@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+// AudioFile is an uploaded file that can be probed in place and then
+// rewound for storage. multipart.File satisfies it.
+type AudioFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+type UploadInput struct {
+	File          AudioFile
+	Size          int64
+	DailyStatID   *uuid.UUID
+	ToolSessionID *uuid.UUID
+}
+
+// SignedRecording pairs a recording with a fresh download link. Speech is
+// only set on upload, when the audio was analyzed for a daily stat.
+type SignedRecording struct {
+	Recording *recordingdomain.Recording
+	Download  SignedURL
+	Speech    *statsdomain.SpeechMetrics
+}
+
+func (uc *RecordingUseCase) Upload(ctx context.Context, userID uuid.UUID, input UploadInput) (*SignedRecording, error) {
+	if input.File == nil || input.Size <= 0 {
+		return nil, recordingdomain.ErrAudioRequired
+	}
+	if input.Size > recordingdomain.MaxRecordingBytes {
+		return nil, recordingdomain.ErrRecordingTooLarge
+	}
+
+	format, duration, err := recordingdomain.Probe(input.File, input.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	rec, err := recordingdomain.NewRecording(
+		userID, input.DailyStatID, input.ToolSessionID, format, input.Size, duration, now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := uc.recordingRepo.LinkTargetExists(ctx, userID, rec.DailyStatID, rec.ToolSessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, recordingdomain.ErrLinkTargetNotFound
+	}
+
+	speech, err := analyzeForDailyStat(rec, input.File, input.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := input.File.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("rewind upload: %w", err)
+	}
+	if err := uc.blobs.Put(ctx, rec.StorageKey, input.File); err != nil {
+		return nil, fmt.Errorf("store recording: %w", err)
+	}
+	if err := uc.recordingRepo.Create(ctx, rec, speech); err != nil {
+		uc.removeBlob(ctx, rec.StorageKey)
+		return nil, err
+	}
+
+	return &SignedRecording{Recording: rec, Download: uc.signer.Sign(rec.ID, now), Speech: speech}, nil
+}
+
+// analyzeForDailyStat measures speech in recordings linked to a daily
+// stat, where the metrics are stored. Audio the analyzer cannot read, such
+// as Opus or 24-bit WAV, is kept without metrics.
+func analyzeForDailyStat(rec *recordingdomain.Recording, file io.ReaderAt, size int64) (*statsdomain.SpeechMetrics, error) {
+	if rec.DailyStatID == nil || rec.Format != recordingdomain.FormatWAV {
+		return nil, nil
+	}
+
+	info, err := recordingdomain.ParseWAV(file, size)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := recordingdomain.AnalyzeSpeech(file, info)
+	if errors.Is(err, recordingdomain.ErrNotAnalyzable) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordingID = rec.ID
+	return &metrics, nil
+}
+
+func (uc *RecordingUseCase) GetRecording(ctx context.Context, userID, recordingID uuid.UUID) (*SignedRecording, error) {
+	rec, err := uc.recordingRepo.FindByID(ctx, recordingID)
+	if err != nil {
+		return nil, err
+	}
+	if rec.UserID != userID {
+		return nil, recordingdomain.ErrRecordingNotFound
+	}
+	return &SignedRecording{Recording: rec, Download: uc.signer.Sign(rec.ID, time.Now())}, nil
+}
+
+type RecordingPage struct {
+	Recordings []*SignedRecording
+	Total      int
+	Limit      int
+	Offset     int
+}
+
+func (uc *RecordingUseCase) ListRecordings(ctx context.Context, userID uuid.UUID, limit, offset int) (*RecordingPage, error) {
+	if limit <= 0 {
+		limit = recordingdomain.DefaultPageSize
+	}
+	if limit > recordingdomain.MaxPageSize {
+		limit = recordingdomain.MaxPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	recordings, total, err := uc.recordingRepo.ListByUser(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	signed := make([]*SignedRecording, 0, len(recordings))
+	for _, rec := range recordings {
+		signed = append(signed, &SignedRecording{Recording: rec, Download: uc.signer.Sign(rec.ID, now)})
+	}
+	return &RecordingPage{Recordings: signed, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// ListClientRecordings lets a therapist listen to a client's speech
+// samples. Recordings are as sensitive as transcripts, so they need the
+// same scope.
+func (uc *RecordingUseCase) ListClientRecordings(ctx context.Context, therapistID, clientID uuid.UUID, limit, offset int) (*RecordingPage, error) {
+	if _, err := uc.therapist.RequireClientScope(ctx, therapistID, clientID, therapistdomain.ScopeTranscripts); err != nil {
+		return nil, err
+	}
+	return uc.ListRecordings(ctx, clientID, limit, offset)
+}
+
+func (uc *RecordingUseCase) DeleteRecording(ctx context.Context, userID, recordingID uuid.UUID) error {
+	rec, err := uc.recordingRepo.Delete(ctx, userID, recordingID)
+	if err != nil {
+		return err
+	}
+	uc.removeBlob(ctx, rec.StorageKey)
+	return nil
+}
+
+// OpenDownload checks a signed link and opens the audio. The caller must
+// close the returned reader.
+func (uc *RecordingUseCase) OpenDownload(ctx context.Context, recordingID uuid.UUID, expires, signature string) (*recordingdomain.Recording, io.ReadSeekCloser, error) {
+	if err := uc.signer.Verify(recordingID, expires, signature, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	rec, err := uc.recordingRepo.FindByID(ctx, recordingID)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := uc.blobs.Open(ctx, rec.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open recording: %w", err)
+	}
+	return rec, body, nil
+}
+
+// removeBlob is best effort: the row is already gone or was never
+// written, so an orphaned file is only wasted space.
+func (uc *RecordingUseCase) removeBlob(ctx context.Context, key string) {
+	if err := uc.blobs.Delete(ctx, key); err != nil {
+		slog.Warn("failed to remove recording blob", "key", key, "error", err)
+	}
+}
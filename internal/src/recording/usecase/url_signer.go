@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	recordingdomain "saythis-backend/internal/src/recording/domain"
+)
+
+// URLSigner issues download links that work without a bearer token, so
+// audio elements can stream them directly, but only until they expire.
+type URLSigner struct {
+	key []byte
+	ttl time.Duration
+}
+
+// NewURLSigner derives its key from secret so the same secret can be
+// shared with other signers without their signatures being interchangeable.
+func NewURLSigner(secret string, ttl time.Duration) *URLSigner {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("saythis recording download"))
+	return &URLSigner{key: mac.Sum(nil), ttl: ttl}
+}
+
+type SignedURL struct {
+	URL       string
+	ExpiresAt time.Time
+}
+
+func (s *URLSigner) Sign(recordingID uuid.UUID, now time.Time) SignedURL {
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	query := url.Values{
+		"expires":   {strconv.FormatInt(expiresAt.Unix(), 10)},
+		"signature": {s.signature(recordingID, expiresAt.Unix())},
+	}
+	return SignedURL{
+		URL:       fmt.Sprintf("/api/v1/recordings/%s/download?%s", recordingID, query.Encode()),
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *URLSigner) Verify(recordingID uuid.UUID, expires, signature string, now time.Time) error {
+	expiresUnix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > expiresUnix {
+		return recordingdomain.ErrInvalidSignature
+	}
+	want := s.signature(recordingID, expiresUnix)
+	if !hmac.Equal([]byte(signature), []byte(want)) {
+		return recordingdomain.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *URLSigner) signature(recordingID uuid.UUID, expiresUnix int64) string {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s:%d", recordingID, expiresUnix)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	recordingrepo "saythis-backend/internal/src/recording/repository"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+)
+
+type RecordingUseCase struct {
+	recordingRepo recordingrepo.RecordingRepository
+	blobs         BlobStore
+	signer        *URLSigner
+	therapist     *therapistusecase.TherapistUseCase
+}
+
+func NewRecordingUseCase(
+	recordingRepo recordingrepo.RecordingRepository,
+	blobs BlobStore,
+	signer *URLSigner,
+	therapist *therapistusecase.TherapistUseCase,
+) *RecordingUseCase {
+	return &RecordingUseCase{
+		recordingRepo: recordingRepo,
+		blobs:         blobs,
+		signer:        signer,
+		therapist:     therapist,
+	}
+}
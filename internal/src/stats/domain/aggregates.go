@@ -0,0 +1,103 @@
+package domain
+
+import "time"
+
+// Total is a sum and the number of values in it, so averages can be
+// combined across rows before rounding.
+type Total struct {
+	Sum   float64
+	Count int
+}
+
+// DailyStatAggregate summarises the daily stats in a range.
+type DailyStatAggregate struct {
+	SleepHours     Total
+	StressLevel    Total
+	MindfulHours   Total
+	StutterScore   Total
+	MoodCounts     map[string]int
+	JournalEntries int
+	DaysTracked    int
+	BestScore      *float64
+	WorstScore     *float64
+	LatestScore    *float64
+}
+
+// ToolTypeTotals aggregates every session of one tool type. Metric totals
+// the tool's headline metadata value, see ToolMetrics.
+type ToolTypeTotals struct {
+	ToolType         string
+	Sessions         int
+	Seconds          int
+	SessionsThisWeek int
+	Rating           Total
+	Metric           Total
+	LastStartedAt    time.Time
+}
+
+type MetricKind string
+
+const (
+	// MetricValue averages the metadata number as is.
+	MetricValue MetricKind = "value"
+	// MetricPerMinute divides the number by the session's minutes first.
+	MetricPerMinute MetricKind = "per_minute"
+	// MetricCompletion scores a true/false value as 100 or 0.
+	MetricCompletion MetricKind = "completion"
+)
+
+type ToolMetric struct {
+	Key  string
+	Kind MetricKind
+}
+
+// ToolMetrics names the metadata value averaged for each tool type.
+var ToolMetrics = map[string]ToolMetric{
+	ToolDAF:                {MetaDelayMS, MetricValue},
+	ToolFAF:                {MetaPitchSemitones, MetricValue},
+	ToolGentleOnset:        {MetaAverageScore, MetricValue},
+	ToolProlongedSpeech:    {MetaEstimatedWPM, MetricValue},
+	ToolStutterTapCounter:  {MetaTotalTaps, MetricPerMinute},
+	ToolTimedReadingWPM:    {MetaActualWPM, MetricValue},
+	ToolVirtualCoffeeOrder: {MetaCompleted, MetricCompletion},
+	ToolPhoneCallSimulator: {MetaCompleted, MetricCompletion},
+}
+
+// ToolLabelKeys names the free-text metadata value counted for each tool
+// type.
+var ToolLabelKeys = map[string]string{
+	ToolFAF:       MetaPitchDirection,
+	ToolPreSpeech: MetaSituation,
+}
+
+type ToolLabelCount struct {
+	ToolType string
+	Label    string
+	Count    int
+}
+
+// Streak describes the consecutive practice days of a group of tools.
+type Streak struct {
+	Current    int
+	Best       int
+	ActiveDays int
+}
+
+// ToolActivityDay totals the sessions started on one civil date.
+type ToolActivityDay struct {
+	Date     time.Time
+	Sessions int
+	Seconds  int
+}
+
+// DataVersion identifies the state of the rows a user's overview is
+// computed from. Writes counts every write to them, rollups and streaks
+// included, in commit order; the counts and latest timestamps of the raw
+// rows are kept as well.
+type DataVersion struct {
+	DailyStats          int
+	DailyStatsUpdatedAt *time.Time
+	ToolSessions        int
+	ToolSessionsAddedAt *time.Time
+	Writes              int64
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type CompareMode string
+
+const (
+	CompareNone     CompareMode = ""
+	ComparePrevious CompareMode = "previous"
+	CompareYearAgo  CompareMode = "year_ago"
+)
+
+func (m CompareMode) Valid() bool {
+	switch m {
+	case CompareNone, ComparePrevious, CompareYearAgo:
+		return true
+	default:
+		return false
+	}
+}
+
+// Window returns the range the given one is compared against: the same
+// number of days right before it, or the same dates a year earlier.
+func (m CompareMode) Window(from, to time.Time) (time.Time, time.Time) {
+	if m == CompareYearAgo {
+		return from.AddDate(-1, 0, 0), to.AddDate(-1, 0, 0)
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	prevTo := from.AddDate(0, 0, -1)
+	return prevTo.AddDate(0, 0, -(days - 1)), prevTo
+}
+
+// PeriodMetrics are the aggregates compared between two ranges. Tool
+// figures only count sessions inside the range, unlike ToolStats.
+type PeriodMetrics struct {
+	AvgSleepHours   *float64
+	AvgStressLevel  *float64
+	AvgMindfulHours *float64
+	AvgMood         *float64
+	DaysTracked     int
+	JournalEntries  int
+	AvgStutterScore *float64
+	StutterAnalyses int
+	ToolSessions    int
+	ToolMinutes     float64
+	ActiveToolDays  int
+}
+
+type MetricDirection string
+
+const (
+	DirectionImproved  MetricDirection = "improved"
+	DirectionWorsened  MetricDirection = "worsened"
+	DirectionUnchanged MetricDirection = "unchanged"
+	DirectionUnknown   MetricDirection = "unknown"
+)
+
+// MetricDelta compares one metric across the two ranges. PercentDelta is
+// nil when the earlier value is zero, and Direction is unknown when
+// either range has no value.
+type MetricDelta struct {
+	Metric        string
+	LowerIsBetter bool
+	Current       *float64
+	Previous      *float64
+	Delta         *float64
+	PercentDelta  *float64
+	Direction     MetricDirection
+}
+
+type PeriodComparison struct {
+	Mode    CompareMode
+	From    time.Time
+	To      time.Time
+	Metrics []MetricDelta
+}
+
+type periodMetric struct {
+	name          string
+	lowerIsBetter bool
+	value         func(PeriodMetrics) *float64
+}
+
+var periodMetrics = []periodMetric{
+	{"avg_sleep_hours", false, func(p PeriodMetrics) *float64 { return p.AvgSleepHours }},
+	{"avg_stress_level", true, func(p PeriodMetrics) *float64 { return p.AvgStressLevel }},
+	{"avg_mindful_hours", false, func(p PeriodMetrics) *float64 { return p.AvgMindfulHours }},
+	{"avg_mood", false, func(p PeriodMetrics) *float64 { return p.AvgMood }},
+	{"days_tracked", false, func(p PeriodMetrics) *float64 { return countPtr(p.DaysTracked) }},
+	{"journal_entries", false, func(p PeriodMetrics) *float64 { return countPtr(p.JournalEntries) }},
+	{"avg_stutter_score", true, func(p PeriodMetrics) *float64 { return p.AvgStutterScore }},
+	{"stutter_analyses", false, func(p PeriodMetrics) *float64 { return countPtr(p.StutterAnalyses) }},
+	{"tool_sessions", false, func(p PeriodMetrics) *float64 { return countPtr(p.ToolSessions) }},
+	{"tool_minutes", false, func(p PeriodMetrics) *float64 { return &p.ToolMinutes }},
+	{"active_tool_days", false, func(p PeriodMetrics) *float64 { return countPtr(p.ActiveToolDays) }},
+}
+
+// ComparePeriods returns a delta for every metric, in a fixed order.
+func ComparePeriods(current, previous PeriodMetrics) []MetricDelta {
+	deltas := make([]MetricDelta, 0, len(periodMetrics))
+	for _, metric := range periodMetrics {
+		deltas = append(deltas, newMetricDelta(metric.name, metric.lowerIsBetter, metric.value(current), metric.value(previous)))
+	}
+	return deltas
+}
+
+func newMetricDelta(name string, lowerIsBetter bool, current, previous *float64) MetricDelta {
+	d := MetricDelta{
+		Metric:        name,
+		LowerIsBetter: lowerIsBetter,
+		Current:       current,
+		Previous:      previous,
+		Direction:     DirectionUnknown,
+	}
+	if current == nil || previous == nil {
+		return d
+	}
+
+	delta := math.Round((*current-*previous)*10) / 10
+	d.Delta = &delta
+	if *previous != 0 {
+		percent := math.Round((*current-*previous)/math.Abs(*previous)*1000) / 10
+		d.PercentDelta = &percent
+	}
+
+	switch {
+	case delta == 0:
+		d.Direction = DirectionUnchanged
+	case (delta < 0) == lowerIsBetter:
+		d.Direction = DirectionImproved
+	default:
+		d.Direction = DirectionWorsened
+	}
+	return d
+}
+
+func countPtr(count int) *float64 {
+	value := float64(count)
+	return &value
+}
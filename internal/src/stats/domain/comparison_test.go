@@ -0,0 +1,93 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func TestCompareMode_Window(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		mode     statsdomain.CompareMode
+		wantFrom string
+		wantTo   string
+	}{
+		{statsdomain.ComparePrevious, "2026-02-19", "2026-02-28"},
+		{statsdomain.CompareYearAgo, "2025-03-01", "2025-03-10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			gotFrom, gotTo := tt.mode.Window(from, to)
+			if got := gotFrom.Format("2006-01-02"); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := gotTo.Format("2006-01-02"); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	current := statsdomain.PeriodMetrics{
+		AvgSleepHours:   ptr(7.5),
+		AvgStressLevel:  ptr(2.5),
+		AvgStutterScore: ptr(8),
+		DaysTracked:     12,
+		ToolMinutes:     30,
+	}
+	previous := statsdomain.PeriodMetrics{
+		AvgSleepHours:   ptr(7.5),
+		AvgStressLevel:  ptr(2),
+		AvgStutterScore: ptr(10),
+		DaysTracked:     10,
+	}
+
+	byMetric := make(map[string]statsdomain.MetricDelta)
+	for _, delta := range statsdomain.ComparePeriods(current, previous) {
+		byMetric[delta.Metric] = delta
+	}
+
+	tests := []struct {
+		metric    string
+		delta     *float64
+		percent   *float64
+		direction statsdomain.MetricDirection
+	}{
+		{"avg_sleep_hours", ptr(0), ptr(0), statsdomain.DirectionUnchanged},
+		{"avg_stress_level", ptr(0.5), ptr(25), statsdomain.DirectionWorsened},
+		{"avg_stutter_score", ptr(-2), ptr(-20), statsdomain.DirectionImproved},
+		{"days_tracked", ptr(2), ptr(20), statsdomain.DirectionImproved},
+		{"tool_minutes", ptr(30), nil, statsdomain.DirectionImproved},
+		{"avg_mindful_hours", nil, nil, statsdomain.DirectionUnknown},
+	}
+
+	equal := func(a, b *float64) bool {
+		return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+	}
+	for _, tt := range tests {
+		got, ok := byMetric[tt.metric]
+		if !ok {
+			t.Errorf("%s missing from comparison", tt.metric)
+			continue
+		}
+		if !equal(got.Delta, tt.delta) || !equal(got.PercentDelta, tt.percent) || got.Direction != tt.direction {
+			t.Errorf("%s = delta %v, percent %v, %s; want %v, %v, %s",
+				tt.metric, deref(got.Delta), deref(got.PercentDelta), got.Direction,
+				deref(tt.delta), deref(tt.percent), tt.direction)
+		}
+	}
+}
+
+func deref(v *float64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -24,6 +24,10 @@ type DailyStatPatch struct {
 	FillerCount       Optional[int]
 	TotalWords        Optional[int]
 	StutterTranscript Optional[string]
+
+	// AnalyzeTranscript fills in the stutter counts and score from
+	// StutterTranscript when the client leaves them all out.
+	AnalyzeTranscript bool
 }
 
 type DailyStat struct {
@@ -41,6 +45,7 @@ type DailyStat struct {
 	FillerCount       *int
 	TotalWords        *int
 	StutterTranscript *string
+	SpeechMetrics     *SpeechMetrics
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
 }
@@ -56,5 +61,6 @@ func (s *DailyStat) HasTrackedData() bool {
 		s.RepetitionCount != nil ||
 		s.FillerCount != nil ||
 		s.TotalWords != nil ||
-		s.StutterTranscript != nil
+		s.StutterTranscript != nil ||
+		s.SpeechMetrics != nil
 }
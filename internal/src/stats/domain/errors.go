@@ -7,6 +7,7 @@ var (
 	ErrInvalidDate            = errors.New("invalid date")
 	ErrFutureDate             = errors.New("date cannot be in the future")
 	ErrInvalidDateRange       = errors.New("invalid date range")
+	ErrInvalidCompareMode     = errors.New("compare must be previous or year_ago")
 	ErrInvalidMood            = errors.New("invalid mood value")
 	ErrInvalidSleepHours      = errors.New("sleep_hours must be 0-12")
 	ErrInvalidJournalEntry    = errors.New("journal_entry is too long")
@@ -19,5 +20,15 @@ var (
 	ErrInvalidTotalWords      = errors.New("total_words must be non-negative")
 	ErrInvalidTranscript      = errors.New("stutter_transcript is too long")
 	ErrIncompleteStutterData  = errors.New("incomplete stutter data")
+	ErrTranscriptRequired     = errors.New("stutter_transcript is required for analysis")
 	ErrDailyStatNotFound      = errors.New("daily stat not found")
+
+	ErrNoToolSessions      = errors.New("at least one session is required")
+	ErrTooManyToolSessions = errors.New("too many sessions in one request")
+	ErrInvalidToolType     = errors.New("invalid tool_type")
+	ErrInvalidStartedAt    = errors.New("started_at is required and cannot be in the future")
+	ErrInvalidDuration     = errors.New("duration_seconds must be 1-14400")
+	ErrInvalidSelfRating   = errors.New("self_rating must be 1-5")
+	ErrInvalidMetadata     = errors.New("metadata is too large")
+	ErrInvalidToolMetadata = errors.New("invalid metadata")
 )
@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Factors compared against the daily stutter score.
+const (
+	FactorSleepHours   = "sleep_hours"
+	FactorStressLevel  = "stress_level"
+	FactorMindfulHours = "mindful_hours"
+	FactorMood         = "mood"
+	FactorToolMinutes  = "tool_minutes"
+)
+
+// MinCorrelationSamples is the number of paired days needed before a
+// correlation is reported. Fewer points make the coefficient mostly noise.
+const MinCorrelationSamples = 7
+
+// MoodScores orders moods from worst to best so they can be correlated.
+var MoodScores = map[string]float64{
+	"Dizzy":   1,
+	"Sad":     2,
+	"Neutral": 3,
+	"Happy":   4,
+	"Great":   5,
+}
+
+type CorrelationStatus string
+
+const (
+	CorrelationOK               CorrelationStatus = "ok"
+	CorrelationInsufficientData CorrelationStatus = "insufficient_data"
+	CorrelationNoVariation      CorrelationStatus = "no_variation"
+)
+
+type CorrelationStrength string
+
+const (
+	StrengthNegligible CorrelationStrength = "negligible"
+	StrengthWeak       CorrelationStrength = "weak"
+	StrengthModerate   CorrelationStrength = "moderate"
+	StrengthStrong     CorrelationStrength = "strong"
+)
+
+// Correlation relates one factor to the stutter score LagDays later. The
+// strength and summary follow the Spearman coefficient, which copes better
+// with the 1-5 scales and a few outlying days.
+type Correlation struct {
+	Factor   string
+	LagDays  int
+	Samples  int
+	Pearson  *float64
+	Spearman *float64
+	Strength *CorrelationStrength
+	Status   CorrelationStatus
+	Summary  string
+}
+
+type Insights struct {
+	From         time.Time
+	To           time.Time
+	MinSamples   int
+	Correlations []Correlation
+}
+
+// factorPhrases holds the noun used when there is no clear link and the
+// comparative used when there is one.
+var factorPhrases = map[string]struct{ noun, more string }{
+	FactorSleepHours:   {"sleep", "more sleep"},
+	FactorStressLevel:  {"stress", "higher stress"},
+	FactorMindfulHours: {"mindful time", "more mindful time"},
+	FactorMood:         {"mood", "a better mood"},
+	FactorToolMinutes:  {"tool practice", "more tool practice"},
+}
+
+// NewCorrelation compares factor values with the stutter scores paired to
+// them; xs[i] and ys[i] must come from the same pair of days.
+func NewCorrelation(factor string, lagDays int, xs, ys []float64) Correlation {
+	c := Correlation{Factor: factor, LagDays: lagDays, Samples: len(xs)}
+
+	switch {
+	case c.Samples < MinCorrelationSamples:
+		c.Status = CorrelationInsufficientData
+	case isConstant(xs) || isConstant(ys):
+		c.Status = CorrelationNoVariation
+	default:
+		pearson, _ := Pearson(xs, ys)
+		spearman, _ := Spearman(xs, ys)
+		strength := strengthOf(spearman)
+		c.Pearson = roundedPtr(pearson)
+		c.Spearman = roundedPtr(spearman)
+		c.Strength = &strength
+		c.Status = CorrelationOK
+	}
+
+	c.Summary = c.describe()
+	return c
+}
+
+// Pearson returns the linear correlation coefficient of xs and ys. It
+// reports false when there are fewer than two points or either series has
+// no variance.
+func Pearson(xs, ys []float64) (float64, bool) {
+	n := len(xs)
+	if n < 2 || n != len(ys) {
+		return 0, false
+	}
+
+	var meanX, meanY float64
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+	meanX /= float64(n)
+	meanY /= float64(n)
+
+	var cov, varX, varY float64
+	for i := range xs {
+		dx := xs[i] - meanX
+		dy := ys[i] - meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0, false
+	}
+
+	r := cov / math.Sqrt(varX*varY)
+	return math.Max(-1, math.Min(1, r)), true
+}
+
+// Spearman returns the rank correlation of xs and ys, giving tied values
+// their average rank.
+func Spearman(xs, ys []float64) (float64, bool) {
+	if len(xs) != len(ys) {
+		return 0, false
+	}
+	return Pearson(ranks(xs), ranks(ys))
+}
+
+func ranks(values []float64) []float64 {
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return values[order[a]] < values[order[b]]
+	})
+
+	out := make([]float64, len(values))
+	for start := 0; start < len(order); {
+		end := start + 1
+		for end < len(order) && values[order[end]] == values[order[start]] {
+			end++
+		}
+		// Ranks are 1-based; ties share the mean of the ranks they span.
+		rank := float64(start+end+1) / 2
+		for _, idx := range order[start:end] {
+			out[idx] = rank
+		}
+		start = end
+	}
+	return out
+}
+
+func strengthOf(r float64) CorrelationStrength {
+	switch abs := math.Abs(r); {
+	case abs < 0.1:
+		return StrengthNegligible
+	case abs < 0.3:
+		return StrengthWeak
+	case abs < 0.5:
+		return StrengthModerate
+	default:
+		return StrengthStrong
+	}
+}
+
+func (c Correlation) describe() string {
+	phrase, ok := factorPhrases[c.Factor]
+	if !ok {
+		phrase.noun, phrase.more = c.Factor, "higher "+c.Factor
+	}
+	when := "on the same day"
+	if c.LagDays > 0 {
+		when = "the next day"
+	}
+
+	switch c.Status {
+	case CorrelationInsufficientData:
+		return fmt.Sprintf(
+			"Not enough data yet: log %s and a stutter score %s on at least %d days to see this (%d so far).",
+			phrase.noun, pairingPhrase(c.LagDays), MinCorrelationSamples, c.Samples,
+		)
+	case CorrelationNoVariation:
+		return fmt.Sprintf("Your %s and stutter score did not vary enough over this period to compare.", phrase.noun)
+	}
+
+	if *c.Strength == StrengthNegligible {
+		return fmt.Sprintf("No clear link between %s and your stutter score %s (%d days).", phrase.noun, when, c.Samples)
+	}
+
+	direction := "higher"
+	if *c.Spearman < 0 {
+		direction = "lower"
+	}
+	lead := "On days with " + phrase.more + ", your stutter score"
+	if c.LagDays > 0 {
+		lead = "After days with " + phrase.more + ", your stutter score the next day"
+	}
+	return fmt.Sprintf("%s tended to be %s (%s link over %d days).", lead, direction, *c.Strength, c.Samples)
+}
+
+func pairingPhrase(lagDays int) string {
+	if lagDays > 0 {
+		return "the following day"
+	}
+	return "on the same day"
+}
+
+func isConstant(values []float64) bool {
+	for _, v := range values[1:] {
+		if v != values[0] {
+			return false
+		}
+	}
+	return true
+}
+
+func roundedPtr(value float64) *float64 {
+	rounded := math.Round(value*1000) / 1000
+	return &rounded
+}
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func TestPearsonAndSpearman(t *testing.T) {
+	tests := []struct {
+		name         string
+		xs, ys       []float64
+		wantPearson  float64
+		wantSpearman float64
+	}{
+		{
+			name:         "perfect linear",
+			xs:           []float64{1, 2, 3, 4, 5},
+			ys:           []float64{10, 20, 30, 40, 50},
+			wantPearson:  1,
+			wantSpearman: 1,
+		},
+		{
+			name:         "monotonic but not linear",
+			xs:           []float64{1, 2, 3, 4, 5},
+			ys:           []float64{1, 4, 9, 16, 100},
+			wantPearson:  0.7952,
+			wantSpearman: 1,
+		},
+		{
+			name:         "ties share ranks",
+			xs:           []float64{1, 2, 2, 3},
+			ys:           []float64{4, 3, 2, 1},
+			wantPearson:  -0.9487,
+			wantSpearman: -0.9487,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pearson, ok := statsdomain.Pearson(tt.xs, tt.ys)
+			if !ok || math.Abs(pearson-tt.wantPearson) > 1e-4 {
+				t.Errorf("Pearson = %.4f, %v; want %.4f", pearson, ok, tt.wantPearson)
+			}
+			spearman, ok := statsdomain.Spearman(tt.xs, tt.ys)
+			if !ok || math.Abs(spearman-tt.wantSpearman) > 1e-4 {
+				t.Errorf("Spearman = %.4f, %v; want %.4f", spearman, ok, tt.wantSpearman)
+			}
+		})
+	}
+
+	if _, ok := statsdomain.Pearson([]float64{3, 3, 3}, []float64{1, 2, 3}); ok {
+		t.Error("Pearson of a constant series should not be defined")
+	}
+}
+
+func TestNewCorrelation(t *testing.T) {
+	sleep := []float64{5, 6, 7, 8, 6.5, 7.5, 5.5, 8.5}
+	scores := []float64{12, 10, 7, 5, 9, 6, 11, 4}
+
+	c := statsdomain.NewCorrelation(statsdomain.FactorSleepHours, 1, sleep, scores)
+	if c.Status != statsdomain.CorrelationOK {
+		t.Fatalf("Status = %q, want ok", c.Status)
+	}
+	if c.Spearman == nil || *c.Spearman != -1 {
+		t.Errorf("Spearman = %v, want -1", c.Spearman)
+	}
+	if c.Strength == nil || *c.Strength != statsdomain.StrengthStrong {
+		t.Errorf("Strength = %v, want strong", c.Strength)
+	}
+	want := "After days with more sleep, your stutter score the next day tended to be lower (strong link over 8 days)."
+	if c.Summary != want {
+		t.Errorf("Summary = %q\nwant      %q", c.Summary, want)
+	}
+
+	short := statsdomain.NewCorrelation(statsdomain.FactorStressLevel, 0, sleep[:3], scores[:3])
+	if short.Status != statsdomain.CorrelationInsufficientData || short.Pearson != nil || short.Spearman != nil {
+		t.Errorf("short series = %+v, want insufficient data without coefficients", short)
+	}
+	if !strings.Contains(short.Summary, "(3 so far)") {
+		t.Errorf("Summary = %q, want the sample count", short.Summary)
+	}
+
+	flat := statsdomain.NewCorrelation(statsdomain.FactorMood, 0, []float64{4, 4, 4, 4, 4, 4, 4}, scores[:7])
+	if flat.Status != statsdomain.CorrelationNoVariation {
+		t.Errorf("Status = %q, want no_variation", flat.Status)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Tool groups shown in the overview. Rollups and streaks are stored per
+// group, since a day counts towards a group when any of its tools was used.
+const (
+	ToolGroupCombined    = "combined"
+	ToolGroupDAF         = "daf"
+	ToolGroupFAF         = "faf"
+	ToolGroupBreathing   = "breathing"
+	ToolGroupDrills      = "drills"
+	ToolGroupBiofeedback = "biofeedback"
+	ToolGroupSimulation  = "simulation"
+)
+
+// ToolGroups lists the tool types in each group. Rollups have to be
+// rebuilt after a group changes, see cmd/rebuild-rollups.
+var ToolGroups = map[string][]string{
+	ToolGroupCombined:    ToolTypes(),
+	ToolGroupDAF:         {ToolDAF},
+	ToolGroupFAF:         {ToolFAF},
+	ToolGroupBreathing:   {ToolBoxBreathing, ToolDiaphragmatic, ToolPreSpeech},
+	ToolGroupDrills:      {ToolGentleOnset, ToolProlongedSpeech},
+	ToolGroupBiofeedback: {ToolStutterTapCounter, ToolTimedReadingWPM},
+	ToolGroupSimulation:  {ToolVirtualCoffeeOrder, ToolPhoneCallSimulator},
+}
+
+// StreakJournal names the journaling streak, stored next to the tool
+// groups' streaks.
+const StreakJournal = "journal"
+
+// StreakState is a stored streak: the latest run of consecutive days, the
+// longest run and the number of active days overall.
+type StreakState struct {
+	CurrentStart time.Time
+	CurrentEnd   time.Time
+	Best         int
+	ActiveDays   int
+}
+
+// At resolves the streak on the given civil date. The latest run is only
+// current while it reaches today, so a missed day ends it without a write.
+func (s StreakState) At(today time.Time) Streak {
+	streak := Streak{Best: s.Best, ActiveDays: s.ActiveDays}
+	if !today.Before(s.CurrentStart) && !today.After(s.CurrentEnd) {
+		streak.Current = int(today.Sub(s.CurrentStart).Hours()/24) + 1
+	}
+	return streak
+}
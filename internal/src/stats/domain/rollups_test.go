@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func TestStreakState_At(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+	}
+	state := statsdomain.StreakState{
+		CurrentStart: day(10),
+		CurrentEnd:   day(14),
+		Best:         8,
+		ActiveDays:   20,
+	}
+
+	tests := []struct {
+		name        string
+		today       time.Time
+		wantCurrent int
+	}{
+		{"before the run", day(9), 0},
+		{"first day", day(10), 1},
+		{"last day", day(14), 5},
+		{"day after the run", day(15), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := state.At(tt.today)
+			if got.Current != tt.wantCurrent {
+				t.Errorf("current = %d, want %d", got.Current, tt.wantCurrent)
+			}
+			if got.Best != 8 || got.ActiveDays != 20 {
+				t.Errorf("best, active days = %d, %d, want 8, 20", got.Best, got.ActiveDays)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// Pauses are bucketed by length: short pauses are ordinary phrasing,
+// long ones are more likely blocks or avoidance.
+const (
+	MinPauseSeconds    = 0.25
+	MediumPauseSeconds = 0.5
+	LongPauseSeconds   = 2.0
+)
+
+// SpeechMetrics are measured from a recording's audio rather than self
+// reported. They belong to the daily stat the recording was linked to.
+type SpeechMetrics struct {
+	RecordingID          uuid.UUID
+	DurationSeconds      float64
+	SpeechSeconds        float64
+	PauseCount           int
+	ShortPauses          int
+	MediumPauses         int
+	LongPauses           int
+	MeanPauseSeconds     float64
+	LongestPauseSeconds  float64
+	LongestSpeechSeconds float64
+}
+
+// SpeechRatio is the share of the recording spent speaking, 0-1.
+func (m SpeechMetrics) SpeechRatio() float64 {
+	if m.DurationSeconds <= 0 {
+		return 0
+	}
+	return math.Round(m.SpeechSeconds/m.DurationSeconds*1000) / 1000
+}
+
+// ArticulationRate is words per minute of speaking time, excluding pauses.
+// It needs the transcript's word count, so it is nil without one.
+func (m SpeechMetrics) ArticulationRate(totalWords *int) *float64 {
+	if totalWords == nil || *totalWords <= 0 || m.SpeechSeconds <= 0 {
+		return nil
+	}
+	rate := math.Round(float64(*totalWords)/(m.SpeechSeconds/60)*10) / 10
+	return &rate
+}
+
+// PausesPerMinute is measured against the whole recording length.
+func (m SpeechMetrics) PausesPerMinute() float64 {
+	if m.DurationSeconds <= 0 {
+		return 0
+	}
+	return math.Round(float64(m.PauseCount)/(m.DurationSeconds/60)*10) / 10
+}
@@ -12,6 +12,9 @@ type StatsOverview struct {
 	WeeklyActivity  []WeeklyActivityDay
 	WeeklyTrend     []WeeklyTrendWeek
 	RecentSessions  []*ToolSession
+
+	// Comparison is only set when a compare mode was requested.
+	Comparison *PeriodComparison
 }
 
 type WellnessSummary struct {
@@ -30,6 +33,22 @@ type StutterSummary struct {
 	TotalAnalyses int
 	ScoreTrend    []ScoreTrendPoint
 	LatestScore   *float64
+	Speech        SpeechSummary
+}
+
+// SpeechSummary aggregates the audio-derived metrics of days that have an
+// analyzed recording.
+type SpeechSummary struct {
+	AnalyzedDays         int
+	AvgSpeechRatio       *float64
+	AvgArticulationRate  *float64
+	AvgPausesPerMinute   *float64
+	AvgMeanPauseSeconds  *float64
+	LongestPauseSeconds  *float64
+	LongestSpeechSeconds *float64
+	ShortPauses          int
+	MediumPauses         int
+	LongPauses           int
 }
 
 type ScoreTrendPoint struct {
@@ -128,3 +147,30 @@ type WeeklyTrendWeek struct {
 	WeekLabel    string
 	TotalMinutes float64
 }
+
+// Redacted returns a copy of the overview with free-text journal entries
+// and speech transcripts removed unless explicitly kept. Used when the
+// overview is shown to someone other than its owner.
+func (o *StatsOverview) Redacted(keepJournal, keepTranscripts bool) *StatsOverview {
+	out := *o
+	redact := func(stat *DailyStat) *DailyStat {
+		if stat == nil {
+			return nil
+		}
+		copied := *stat
+		if !keepJournal {
+			copied.JournalEntry = nil
+		}
+		if !keepTranscripts {
+			copied.StutterTranscript = nil
+		}
+		return &copied
+	}
+
+	out.DailyStats = make([]*DailyStat, 0, len(o.DailyStats))
+	for _, stat := range o.DailyStats {
+		out.DailyStats = append(out.DailyStats, redact(stat))
+	}
+	out.Today = redact(o.Today)
+	return &out
+}
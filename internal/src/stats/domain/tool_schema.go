@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	MetaDelayMS        = "delay_ms"
+	MetaPitchSemitones = "pitch_semitones"
+	MetaPitchDirection = "pitch_direction"
+	MetaSituation      = "situation"
+	MetaAverageScore   = "average_score"
+	MetaEstimatedWPM   = "estimated_wpm"
+	MetaTotalTaps      = "total_taps"
+	MetaActualWPM      = "actual_wpm"
+	MetaCompleted      = "completed"
+)
+
+type MetadataFieldType string
+
+const (
+	FieldNumber  MetadataFieldType = "number"
+	FieldInteger MetadataFieldType = "integer"
+	FieldString  MetadataFieldType = "string"
+	FieldBoolean MetadataFieldType = "boolean"
+)
+
+type MetadataField struct {
+	Name          string
+	Type          MetadataFieldType
+	Required      bool
+	Min           *float64
+	Max           *float64
+	MaxLength     int
+	AllowedValues []string
+}
+
+type ToolSchema struct {
+	ToolType string
+	Fields   []MetadataField
+}
+
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+type MetadataValidationError struct {
+	Index    int
+	ToolType string
+	Fields   []FieldError
+}
+
+func (e *MetadataValidationError) Error() string {
+	return fmt.Sprintf("invalid metadata for %s", e.ToolType)
+}
+
+func (e *MetadataValidationError) Unwrap() error { return ErrInvalidToolMetadata }
+
+var toolSchemas = map[string]ToolSchema{
+	ToolDAF: {ToolType: ToolDAF, Fields: []MetadataField{
+		{Name: MetaDelayMS, Type: FieldNumber, Required: true, Min: bound(0), Max: bound(1000)},
+	}},
+	ToolFAF: {ToolType: ToolFAF, Fields: []MetadataField{
+		{Name: MetaPitchSemitones, Type: FieldNumber, Required: true, Min: bound(-12), Max: bound(12)},
+		{Name: MetaPitchDirection, Type: FieldString, AllowedValues: []string{"up", "down"}},
+	}},
+	ToolBoxBreathing:  {ToolType: ToolBoxBreathing},
+	ToolDiaphragmatic: {ToolType: ToolDiaphragmatic},
+	ToolPreSpeech: {ToolType: ToolPreSpeech, Fields: []MetadataField{
+		{Name: MetaSituation, Type: FieldString, MaxLength: 50},
+	}},
+	ToolGentleOnset: {ToolType: ToolGentleOnset, Fields: []MetadataField{
+		{Name: MetaAverageScore, Type: FieldNumber, Min: bound(0), Max: bound(100)},
+	}},
+	ToolProlongedSpeech: {ToolType: ToolProlongedSpeech, Fields: []MetadataField{
+		{Name: MetaEstimatedWPM, Type: FieldNumber, Min: bound(0), Max: bound(400)},
+	}},
+	ToolStutterTapCounter: {ToolType: ToolStutterTapCounter, Fields: []MetadataField{
+		{Name: MetaTotalTaps, Type: FieldInteger, Required: true, Min: bound(0), Max: bound(10000)},
+	}},
+	ToolTimedReadingWPM: {ToolType: ToolTimedReadingWPM, Fields: []MetadataField{
+		{Name: MetaActualWPM, Type: FieldNumber, Required: true, Min: bound(0), Max: bound(400)},
+	}},
+	ToolVirtualCoffeeOrder: {ToolType: ToolVirtualCoffeeOrder, Fields: []MetadataField{
+		{Name: MetaCompleted, Type: FieldBoolean, Required: true},
+	}},
+	ToolPhoneCallSimulator: {ToolType: ToolPhoneCallSimulator, Fields: []MetadataField{
+		{Name: MetaCompleted, Type: FieldBoolean, Required: true},
+	}},
+}
+
+func ToolSchemas() []ToolSchema {
+	schemas := make([]ToolSchema, 0, len(toolTypes))
+	for _, toolType := range toolTypes {
+		schemas = append(schemas, toolSchemas[toolType])
+	}
+	return schemas
+}
+
+func ToolSchemaFor(toolType string) (ToolSchema, bool) {
+	schema, ok := toolSchemas[toolType]
+	return schema, ok
+}
+
+func (s ToolSchema) Validate(metadata map[string]any) []FieldError {
+	fieldErrors := make([]FieldError, 0)
+	known := make(map[string]struct{}, len(s.Fields))
+
+	for _, field := range s.Fields {
+		known[field.Name] = struct{}{}
+		value, ok := metadata[field.Name]
+		if !ok || value == nil {
+			if field.Required {
+				fieldErrors = append(fieldErrors, FieldError{Field: field.Name, Message: "is required"})
+			}
+			continue
+		}
+		if msg := field.check(value); msg != "" {
+			fieldErrors = append(fieldErrors, FieldError{Field: field.Name, Message: msg})
+		}
+	}
+
+	unknown := make([]string, 0)
+	for key := range metadata {
+		if _, ok := known[key]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	for _, key := range unknown {
+		fieldErrors = append(fieldErrors, FieldError{Field: key, Message: "is not a recognised field"})
+	}
+
+	return fieldErrors
+}
+
+func (f MetadataField) check(value any) string {
+	switch f.Type {
+	case FieldNumber, FieldInteger:
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return "must be a " + string(f.Type)
+		}
+		if f.Type == FieldInteger && math.Trunc(number) != number {
+			return "must be an integer"
+		}
+		if f.Min != nil && number < *f.Min {
+			return fmt.Sprintf("must be at least %g", *f.Min)
+		}
+		if f.Max != nil && number > *f.Max {
+			return fmt.Sprintf("must be at most %g", *f.Max)
+		}
+
+	case FieldString:
+		text, ok := value.(string)
+		if !ok {
+			return "must be a string"
+		}
+		if f.MaxLength > 0 && len(text) > f.MaxLength {
+			return fmt.Sprintf("must be at most %d characters", f.MaxLength)
+		}
+		if len(f.AllowedValues) > 0 && !containsString(f.AllowedValues, text) {
+			return "is not an allowed value"
+		}
+
+	case FieldBoolean:
+		if _, ok := value.(bool); !ok {
+			return "must be a boolean"
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func bound(value float64) *float64 {
+	return &value
+}
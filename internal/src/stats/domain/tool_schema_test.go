@@ -0,0 +1,76 @@
+package domain_test
+
+import (
+	"testing"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func schemaFor(t *testing.T, toolType string) statsdomain.ToolSchema {
+	t.Helper()
+	schema, ok := statsdomain.ToolSchemaFor(toolType)
+	if !ok {
+		t.Fatalf("no schema registered for %s", toolType)
+	}
+	return schema
+}
+
+func TestToolSchemas_CoverEveryToolType(t *testing.T) {
+	for _, toolType := range statsdomain.ToolTypes() {
+		schemaFor(t, toolType)
+	}
+	if got, want := len(statsdomain.ToolSchemas()), len(statsdomain.ToolTypes()); got != want {
+		t.Errorf("want %d schemas, got %d", want, got)
+	}
+}
+
+func TestToolSchema_ValidMetadata(t *testing.T) {
+	errs := schemaFor(t, statsdomain.ToolFAF).Validate(map[string]any{
+		"pitch_semitones": -3.5,
+		"pitch_direction": "down",
+	})
+	if len(errs) != 0 {
+		t.Errorf("want no errors, got %+v", errs)
+	}
+}
+
+func TestToolSchema_MissingRequiredField(t *testing.T) {
+	errs := schemaFor(t, statsdomain.ToolDAF).Validate(map[string]any{})
+	if len(errs) != 1 || errs[0].Field != "delay_ms" {
+		t.Fatalf("want a single delay_ms error, got %+v", errs)
+	}
+}
+
+func TestToolSchema_UnknownFieldRejected(t *testing.T) {
+	errs := schemaFor(t, statsdomain.ToolDAF).Validate(map[string]any{
+		"delay_ms": 120.0,
+		"dealy_ms": 120.0,
+	})
+	if len(errs) != 1 || errs[0].Field != "dealy_ms" {
+		t.Fatalf("want a single dealy_ms error, got %+v", errs)
+	}
+}
+
+func TestToolSchema_TypeAndRangeChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		toolType string
+		metadata map[string]any
+		field    string
+	}{
+		{"out of range", statsdomain.ToolDAF, map[string]any{"delay_ms": 5000.0}, "delay_ms"},
+		{"wrong type", statsdomain.ToolDAF, map[string]any{"delay_ms": "120"}, "delay_ms"},
+		{"non-integer", statsdomain.ToolStutterTapCounter, map[string]any{"total_taps": 2.5}, "total_taps"},
+		{"disallowed value", statsdomain.ToolFAF, map[string]any{"pitch_semitones": 2.0, "pitch_direction": "sideways"}, "pitch_direction"},
+		{"not a boolean", statsdomain.ToolPhoneCallSimulator, map[string]any{"completed": "yes"}, "completed"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := schemaFor(t, tc.toolType).Validate(tc.metadata)
+			if len(errs) != 1 || errs[0].Field != tc.field {
+				t.Fatalf("want a single %s error, got %+v", tc.field, errs)
+			}
+		})
+	}
+}
@@ -6,6 +6,49 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	ToolDAF                = "DAF"
+	ToolFAF                = "FAF"
+	ToolBoxBreathing       = "BOX_BREATHING"
+	ToolDiaphragmatic      = "DIAPHRAGMATIC"
+	ToolPreSpeech          = "PRE_SPEECH"
+	ToolGentleOnset        = "GENTLE_ONSET"
+	ToolProlongedSpeech    = "PROLONGED_SPEECH"
+	ToolStutterTapCounter  = "STUTTER_TAP_COUNTER"
+	ToolTimedReadingWPM    = "TIMED_READING_WPM"
+	ToolVirtualCoffeeOrder = "VIRTUAL_COFFEE_ORDER"
+	ToolPhoneCallSimulator = "PHONE_CALL_SIMULATOR"
+)
+
+var toolTypes = []string{
+	ToolDAF,
+	ToolFAF,
+	ToolBoxBreathing,
+	ToolDiaphragmatic,
+	ToolPreSpeech,
+	ToolGentleOnset,
+	ToolProlongedSpeech,
+	ToolStutterTapCounter,
+	ToolTimedReadingWPM,
+	ToolVirtualCoffeeOrder,
+	ToolPhoneCallSimulator,
+}
+
+func ToolTypes() []string {
+	out := make([]string, len(toolTypes))
+	copy(out, toolTypes)
+	return out
+}
+
+func IsValidToolType(toolType string) bool {
+	for _, t := range toolTypes {
+		if t == toolType {
+			return true
+		}
+	}
+	return false
+}
+
 type ToolSession struct {
 	ID              uuid.UUID
 	UserID          uuid.UUID
@@ -14,4 +57,5 @@ type ToolSession struct {
 	DurationSeconds int
 	SelfRating      *int
 	Metadata        map[string]any
+	CreatedAt       time.Time
 }
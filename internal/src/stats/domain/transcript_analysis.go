@@ -0,0 +1,271 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultFillerLexicon is used when no filler lexicon is configured.
+var DefaultFillerLexicon = []string{"um", "uh", "like", "you know"}
+
+// ambiguousFillers are lexicon words that are just as often ordinary words,
+// as in "I would like a coffee". They only count as fillers when set off by
+// a comma or next to another filler.
+var ambiguousFillers = map[string]bool{
+	"like":      true,
+	"so":        true,
+	"well":      true,
+	"right":     true,
+	"actually":  true,
+	"basically": true,
+	"literally": true,
+}
+
+// TranscriptAnalysis holds the disfluency counts derived from a transcript.
+// StutterCount covers repetitions and prolongations; fillers are counted
+// separately and do not add to the score.
+type TranscriptAnalysis struct {
+	TotalWords           int
+	StutterCount         int
+	RepetitionCount      int
+	PartWordRepetitions  int
+	WholeWordRepetitions int
+	Prolongations        int
+	FillerCount          int
+	StutterScore         float64
+}
+
+// TranscriptAnalyzer detects disfluencies in free-text transcripts using
+// the usual clinical transcription markers:
+//
+//   - part-word repetitions as "b-b-ball" or "st- st- stop"
+//   - whole-word repetitions as "I I I want", one per run
+//   - prolongations as a letter held three or more times ("sssso") or a
+//     colon inside the word ("s:o")
+//   - fillers from the lexicon, including multi-word ones like "you know";
+//     ambiguous words such as "like" need a comma or another filler next
+//     to them
+type TranscriptAnalyzer struct {
+	fillers [][]string
+}
+
+func NewTranscriptAnalyzer(lexicon []string) *TranscriptAnalyzer {
+	if len(lexicon) == 0 {
+		lexicon = DefaultFillerLexicon
+	}
+
+	seen := make(map[string]struct{}, len(lexicon))
+	fillers := make([][]string, 0, len(lexicon))
+	for _, entry := range lexicon {
+		words, _ := tokenizeTranscript(entry)
+		key := strings.Join(words, " ")
+		if len(words) == 0 {
+			continue
+		}
+		if _, dup := seen[key]; dup {
+			continue
+		}
+		seen[key] = struct{}{}
+		fillers = append(fillers, words)
+	}
+
+	// Longest phrases first so "you know" wins over a bare "you".
+	sort.Slice(fillers, func(i, j int) bool {
+		if len(fillers[i]) != len(fillers[j]) {
+			return len(fillers[i]) > len(fillers[j])
+		}
+		return strings.Join(fillers[i], " ") < strings.Join(fillers[j], " ")
+	})
+	return &TranscriptAnalyzer{fillers: fillers}
+}
+
+// Analyze is deterministic: the same transcript and lexicon always give
+// the same counts. The score is stuttered words per hundred words, capped
+// at 100.
+func (a *TranscriptAnalyzer) Analyze(transcript string) TranscriptAnalysis {
+	tokens, commaAfter := tokenizeTranscript(transcript)
+	var result TranscriptAnalysis
+
+	var previous string
+	inRun, afterFiller := false, false
+	for i := 0; i < len(tokens); {
+		if filler := a.matchFiller(tokens[i:]); filler != nil && a.isFillerUse(filler, tokens, commaAfter, i, afterFiller) {
+			n := len(filler)
+			result.FillerCount++
+			result.TotalWords += n
+			previous, inRun, afterFiller = "", false, true
+			i += n
+			continue
+		}
+		afterFiller = false
+
+		token := tokens[i]
+		if isFragment(token) {
+			j := i
+			for j < len(tokens) && isFragment(tokens[j]) {
+				j++
+			}
+			if j < len(tokens) && fragmentsPrefix(tokens[i:j], tokens[j]) {
+				result.PartWordRepetitions++
+			}
+			i = j
+			continue
+		}
+
+		word := strings.ReplaceAll(token, ":", "")
+		if last, ok := partWordRepetition(word); ok {
+			result.PartWordRepetitions++
+			word = last
+		}
+		if isProlongation(token) {
+			result.Prolongations++
+		}
+		if word == previous {
+			if !inRun {
+				result.WholeWordRepetitions++
+				inRun = true
+			}
+		} else {
+			inRun = false
+		}
+
+		previous = word
+		result.TotalWords++
+		i++
+	}
+
+	result.RepetitionCount = result.PartWordRepetitions + result.WholeWordRepetitions
+	result.StutterCount = result.RepetitionCount + result.Prolongations
+	if result.TotalWords > 0 {
+		score := float64(result.StutterCount) / float64(result.TotalWords) * 100
+		result.StutterScore = math.Round(math.Min(score, 100)*10) / 10
+	}
+	return result
+}
+
+func (a *TranscriptAnalyzer) matchFiller(tokens []string) []string {
+	for _, filler := range a.fillers {
+		if len(filler) > len(tokens) {
+			continue
+		}
+		matched := true
+		for k, word := range filler {
+			if tokens[k] != word && collapseHeld(tokens[k]) != collapseHeld(word) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return filler
+		}
+	}
+	return nil
+}
+
+// isFillerUse decides whether a matched filler at tokens[i] is used as
+// one. Only ambiguous single words need the surrounding context.
+func (a *TranscriptAnalyzer) isFillerUse(filler, tokens []string, commaAfter []bool, i int, afterFiller bool) bool {
+	if len(filler) > 1 || !ambiguousFillers[filler[0]] {
+		return true
+	}
+	if afterFiller || commaAfter[i] || (i > 0 && commaAfter[i-1]) {
+		return true
+	}
+	if i+1 < len(tokens) {
+		if next := a.matchFiller(tokens[i+1:]); next != nil && (len(next) > 1 || !ambiguousFillers[next[0]]) {
+			return true
+		}
+	}
+	return false
+}
+
+// tokenizeTranscript lowercases and splits on whitespace, trimming
+// punctuation but keeping the hyphen and colon markers. commaAfter marks
+// the tokens followed by a comma.
+func tokenizeTranscript(text string) ([]string, []bool) {
+	fields := strings.Fields(strings.ToLower(text))
+	tokens := make([]string, 0, len(fields))
+	commaAfter := make([]bool, 0, len(fields))
+	for _, field := range fields {
+		token := strings.TrimFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != ':'
+		})
+		token = strings.TrimLeft(token, "-:")
+		if strings.IndexFunc(token, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			if strings.Contains(field, ",") && len(commaAfter) > 0 {
+				commaAfter[len(commaAfter)-1] = true
+			}
+			continue
+		}
+		tokens = append(tokens, token)
+		trailing := field[strings.Index(field, token)+len(token):]
+		commaAfter = append(commaAfter, strings.Contains(trailing, ","))
+	}
+	return tokens, commaAfter
+}
+
+func isFragment(token string) bool {
+	return strings.HasSuffix(token, "-")
+}
+
+func fragmentsPrefix(fragments []string, word string) bool {
+	word = strings.ReplaceAll(word, ":", "")
+	for _, fragment := range fragments {
+		if !strings.HasPrefix(word, strings.TrimRight(fragment, "-")) {
+			return false
+		}
+	}
+	return true
+}
+
+// partWordRepetition reports whether a hyphenated token is a stuttered
+// word such as "b-b-ball" rather than a compound like "well-known" or a
+// reduplication like "bye-bye", returning the completed word.
+func partWordRepetition(token string) (string, bool) {
+	parts := strings.Split(token, "-")
+	if len(parts) < 2 {
+		return token, false
+	}
+	last := parts[len(parts)-1]
+	for _, part := range parts[:len(parts)-1] {
+		if part == "" || len(part) >= len(last) || !strings.HasPrefix(last, part) {
+			return token, false
+		}
+	}
+	return last, true
+}
+
+func isProlongation(token string) bool {
+	if strings.Contains(strings.Trim(token, "-"), ":") {
+		return true
+	}
+	var prev rune
+	held := 0
+	for _, r := range token {
+		if r == prev && unicode.IsLetter(r) {
+			held++
+			if held >= 3 {
+				return true
+			}
+			continue
+		}
+		prev, held = r, 1
+	}
+	return false
+}
+
+// collapseHeld folds held letters and colons so "ummm" matches "um".
+func collapseHeld(word string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range word {
+		if r == ':' || r == prev {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
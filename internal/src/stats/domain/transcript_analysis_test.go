@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"testing"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func TestTranscriptAnalyzer_Analyze(t *testing.T) {
+	analyzer := statsdomain.NewTranscriptAnalyzer(nil)
+
+	tests := []struct {
+		name       string
+		transcript string
+		want       statsdomain.TranscriptAnalysis
+	}{
+		{
+			name:       "fluent speech",
+			transcript: "I would like a coffee, please.",
+			want:       statsdomain.TranscriptAnalysis{TotalWords: 6},
+		},
+		{
+			name:       "like set off by commas or next to a filler",
+			transcript: "It was, like, fine. Um like I said, it looks like rain",
+			want:       statsdomain.TranscriptAnalysis{TotalWords: 12, FillerCount: 3},
+		},
+		{
+			name:       "part-word repetitions",
+			transcript: "The b-b-ball went st- st- straight over.",
+			want: statsdomain.TranscriptAnalysis{
+				TotalWords: 5, StutterCount: 2, RepetitionCount: 2,
+				PartWordRepetitions: 2, StutterScore: 40,
+			},
+		},
+		{
+			name:       "whole-word run counts once",
+			transcript: "I, I, I want to go",
+			want: statsdomain.TranscriptAnalysis{
+				TotalWords: 6, StutterCount: 1, RepetitionCount: 1,
+				WholeWordRepetitions: 1, StutterScore: 16.7,
+			},
+		},
+		{
+			name:       "prolongations and held fillers",
+			transcript: "ummm sssso you know m:aybe",
+			want: statsdomain.TranscriptAnalysis{
+				TotalWords: 5, StutterCount: 2, Prolongations: 2,
+				FillerCount: 2, StutterScore: 40,
+			},
+		},
+		{
+			name:       "compounds are not repetitions",
+			transcript: "a well-known bye-bye",
+			want:       statsdomain.TranscriptAnalysis{TotalWords: 3},
+		},
+		{
+			name:       "empty",
+			transcript: "  -- ...  ",
+			want:       statsdomain.TranscriptAnalysis{},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := analyzer.Analyze(tc.transcript); got != tc.want {
+				t.Errorf("want %+v, got %+v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTranscriptAnalyzer_CustomLexicon(t *testing.T) {
+	analyzer := statsdomain.NewTranscriptAnalyzer([]string{"so", "I mean", "so"})
+
+	got := analyzer.Analyze("So I mean um it works")
+	if got.FillerCount != 2 || got.TotalWords != 6 {
+		t.Errorf("want 2 fillers in 6 words, got %+v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/stats/usecase"
+)
+
+type AnalyzeTranscriptHandler struct {
+	usecase *usecase.StatsUseCase
+}
+
+func NewAnalyzeTranscriptHandler(uc *usecase.StatsUseCase) *AnalyzeTranscriptHandler {
+	return &AnalyzeTranscriptHandler{usecase: uc}
+}
+
+type analyzeTranscriptRequest struct {
+	Transcript string `json:"transcript"`
+}
+
+type transcriptAnalysisResponse struct {
+	TotalWords           int     `json:"total_words"`
+	StutterScore         float64 `json:"stutter_score"`
+	StutterCount         int     `json:"stutter_count"`
+	RepetitionCount      int     `json:"repetition_count"`
+	PartWordRepetitions  int     `json:"part_word_repetitions"`
+	WholeWordRepetitions int     `json:"whole_word_repetitions"`
+	ProlongationCount    int     `json:"prolongation_count"`
+	FillerCount          int     `json:"filler_count"`
+}
+
+func (h *AnalyzeTranscriptHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	if _, ok := auth.ClaimsFromContext(r.Context()); !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req analyzeTranscriptRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	analysis, err := h.usecase.AnalyzeTranscript(req.Transcript)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, transcriptAnalysisResponse{
+		TotalWords:           analysis.TotalWords,
+		StutterScore:         analysis.StutterScore,
+		StutterCount:         analysis.StutterCount,
+		RepetitionCount:      analysis.RepetitionCount,
+		PartWordRepetitions:  analysis.PartWordRepetitions,
+		WholeWordRepetitions: analysis.WholeWordRepetitions,
+		ProlongationCount:    analysis.Prolongations,
+		FillerCount:          analysis.FillerCount,
+	})
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	"saythis-backend/internal/src/stats/usecase"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+)
+
+// GetClientStatsHandler serves a linked client's stats overview to their
+// therapist. Journal entries and transcripts are only included when the
+// client has granted those scopes as well.
+type GetClientStatsHandler struct {
+	usecase   *usecase.StatsUseCase
+	therapist *therapistusecase.TherapistUseCase
+}
+
+func NewGetClientStatsHandler(uc *usecase.StatsUseCase, therapist *therapistusecase.TherapistUseCase) *GetClientStatsHandler {
+	return &GetClientStatsHandler{usecase: uc, therapist: therapist}
+}
+
+func (h *GetClientStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	from, err := parseOptionalDateQuery(r, "from")
+	if err != nil {
+		status, msg := mapStatsError(statsdomain.ErrInvalidDate)
+		helper.Error(w, status, msg)
+		return
+	}
+	to, err := parseOptionalDateQuery(r, "to")
+	if err != nil {
+		status, msg := mapStatsError(statsdomain.ErrInvalidDate)
+		helper.Error(w, status, msg)
+		return
+	}
+	compare := statsdomain.CompareMode(r.URL.Query().Get("compare"))
+
+	link, err := h.therapist.RequireClientScope(r.Context(), claims.UserID, clientID, therapistdomain.ScopeDailyStats)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	stats, err := h.usecase.GetStats(r.Context(), clientID, from, to, compare)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	stats = stats.Redacted(link.HasScope(therapistdomain.ScopeJournal), link.HasScope(therapistdomain.ScopeTranscripts))
+	helper.JSON(w, http.StatusOK, toStatsResponse(stats))
+}
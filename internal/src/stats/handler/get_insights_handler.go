@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	"saythis-backend/internal/src/stats/usecase"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+)
+
+type GetInsightsHandler struct {
+	usecase *usecase.StatsUseCase
+}
+
+func NewGetInsightsHandler(uc *usecase.StatsUseCase) *GetInsightsHandler {
+	return &GetInsightsHandler{usecase: uc}
+}
+
+func (h *GetInsightsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.serveInsights(w, r, claims.UserID)
+}
+
+func (h *GetInsightsHandler) serveInsights(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	from, err := parseOptionalDateQuery(r, "from")
+	if err != nil {
+		status, msg := mapStatsError(statsdomain.ErrInvalidDate)
+		helper.Error(w, status, msg)
+		return
+	}
+	to, err := parseOptionalDateQuery(r, "to")
+	if err != nil {
+		status, msg := mapStatsError(statsdomain.ErrInvalidDate)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	insights, err := h.usecase.GetInsights(r.Context(), userID, from, to)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, toInsightsResponse(insights))
+}
+
+// GetClientInsightsHandler serves a linked client's correlation insights
+// to their therapist. The insights hold no free text, so the daily stats
+// scope is all that is needed.
+type GetClientInsightsHandler struct {
+	insights  *GetInsightsHandler
+	therapist *therapistusecase.TherapistUseCase
+}
+
+func NewGetClientInsightsHandler(uc *usecase.StatsUseCase, therapist *therapistusecase.TherapistUseCase) *GetClientInsightsHandler {
+	return &GetClientInsightsHandler{insights: NewGetInsightsHandler(uc), therapist: therapist}
+}
+
+func (h *GetClientInsightsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	if _, err := h.therapist.RequireClientScope(r.Context(), claims.UserID, clientID, therapistdomain.ScopeDailyStats); err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	h.insights.serveInsights(w, r, clientID)
+}
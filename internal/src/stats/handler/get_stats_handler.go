@@ -37,14 +37,29 @@ func (h *GetStatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		helper.Error(w, status, msg)
 		return
 	}
+	compare := statsdomain.CompareMode(r.URL.Query().Get("compare"))
 
-	stats, err := h.usecase.GetStats(r.Context(), claims.UserID, from, to)
+	// The tag is taken before the overview is computed, so a write in
+	// between only costs the client one more full response.
+	version, err := h.usecase.StatsVersion(r.Context(), claims.UserID, from, to, compare)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+	etag := helper.ETag(version)
+	if helper.NotModified(w, r, etag) {
+		return
+	}
+
+	stats, err := h.usecase.GetStats(r.Context(), claims.UserID, from, to, compare)
 	if err != nil {
 		status, msg := mapStatsError(err)
 		helper.Error(w, status, msg)
 		return
 	}
 
+	helper.SetETag(w, etag)
 	helper.JSON(w, http.StatusOK, toStatsResponse(stats))
 }
 
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/stats/usecase"
+)
+
+type GetToolsHandler struct {
+	usecase *usecase.StatsUseCase
+}
+
+func NewGetToolsHandler(uc *usecase.StatsUseCase) *GetToolsHandler {
+	return &GetToolsHandler{usecase: uc}
+}
+
+type metadataFieldResponse struct {
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	Min           *float64 `json:"min,omitempty"`
+	Max           *float64 `json:"max,omitempty"`
+	MaxLength     int      `json:"max_length,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
+
+type toolSchemaResponse struct {
+	ToolType string                  `json:"tool_type"`
+	Metadata []metadataFieldResponse `json:"metadata"`
+}
+
+type getToolsResponse struct {
+	Tools []toolSchemaResponse `json:"tools"`
+}
+
+func (h *GetToolsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	schemas := h.usecase.ToolSchemas()
+
+	tools := make([]toolSchemaResponse, 0, len(schemas))
+	for _, schema := range schemas {
+		fields := make([]metadataFieldResponse, 0, len(schema.Fields))
+		for _, field := range schema.Fields {
+			fields = append(fields, metadataFieldResponse{
+				Name:          field.Name,
+				Type:          string(field.Type),
+				Required:      field.Required,
+				Min:           field.Min,
+				Max:           field.Max,
+				MaxLength:     field.MaxLength,
+				AllowedValues: field.AllowedValues,
+			})
+		}
+		tools = append(tools, toolSchemaResponse{ToolType: schema.ToolType, Metadata: fields})
+	}
+
+	helper.JSON(w, http.StatusOK, getToolsResponse{Tools: tools})
+}
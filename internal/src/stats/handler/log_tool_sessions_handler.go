@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	"saythis-backend/internal/src/stats/usecase"
+)
+
+type LogToolSessionsHandler struct {
+	usecase *usecase.StatsUseCase
+}
+
+func NewLogToolSessionsHandler(uc *usecase.StatsUseCase) *LogToolSessionsHandler {
+	return &LogToolSessionsHandler{usecase: uc}
+}
+
+type logToolSessionResponse struct {
+	Session toolSessionResponse `json:"session"`
+}
+
+type logToolSessionsResponse struct {
+	Sessions []toolSessionResponse `json:"sessions"`
+}
+
+type fieldErrorResponse struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type metadataErrorResponse struct {
+	Error  string               `json:"error"`
+	Fields []fieldErrorResponse `json:"fields"`
+}
+
+func (h *LogToolSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const maxBodySize = 1 << 20
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	sessions, batch, err := decodeToolSessions(r)
+	if err != nil {
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	created, err := h.usecase.LogToolSessions(r.Context(), claims.UserID, sessions)
+	if err != nil {
+		var metaErr *statsdomain.MetadataValidationError
+		if errors.As(err, &metaErr) {
+			helper.JSON(w, http.StatusBadRequest, toMetadataErrorResponse(metaErr, batch))
+			return
+		}
+		status, msg := mapStatsError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	if !batch {
+		helper.JSON(w, http.StatusCreated, logToolSessionResponse{Session: toToolSessionResponse(created[0])})
+		return
+	}
+
+	response := make([]toolSessionResponse, 0, len(created))
+	for _, session := range created {
+		response = append(response, toToolSessionResponse(session))
+	}
+	helper.JSON(w, http.StatusCreated, logToolSessionsResponse{Sessions: response})
+}
+
+func toMetadataErrorResponse(err *statsdomain.MetadataValidationError, batch bool) metadataErrorResponse {
+	prefix := "metadata."
+	if batch {
+		prefix = fmt.Sprintf("sessions[%d].metadata.", err.Index)
+	}
+
+	fields := make([]fieldErrorResponse, 0, len(err.Fields))
+	for _, field := range err.Fields {
+		fields = append(fields, fieldErrorResponse{Field: prefix + field.Field, Message: field.Message})
+	}
+	return metadataErrorResponse{Error: err.Error(), Fields: fields}
+}
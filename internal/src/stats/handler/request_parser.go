@@ -28,6 +28,7 @@ var allowedDailyPatchFields = map[string]struct{}{
 	"filler_count":       {},
 	"total_words":        {},
 	"stutter_transcript": {},
+	"analyze_transcript": {},
 }
 
 func decodeDailyStatPatch(r *http.Request) (statsdomain.DailyStatPatch, error) {
@@ -98,6 +99,10 @@ func decodeDailyStatPatch(r *http.Request) (statsdomain.DailyStatPatch, error) {
 	if err != nil {
 		return statsdomain.DailyStatPatch{}, err
 	}
+	analyzeTranscript, err := optionalBool(payload, "analyze_transcript")
+	if err != nil {
+		return statsdomain.DailyStatPatch{}, err
+	}
 
 	return statsdomain.DailyStatPatch{
 		Date:              date,
@@ -112,9 +117,74 @@ func decodeDailyStatPatch(r *http.Request) (statsdomain.DailyStatPatch, error) {
 		FillerCount:       fillerCount,
 		TotalWords:        totalWords,
 		StutterTranscript: stutterTranscript,
+		AnalyzeTranscript: analyzeTranscript,
 	}, nil
 }
 
+type toolSessionRequest struct {
+	ToolType        string         `json:"tool_type"`
+	StartedAt       time.Time      `json:"started_at"`
+	DurationSeconds int            `json:"duration_seconds"`
+	SelfRating      *int           `json:"self_rating"`
+	Metadata        map[string]any `json:"metadata"`
+}
+
+type toolSessionBatchRequest struct {
+	Sessions []toolSessionRequest `json:"sessions"`
+}
+
+func decodeToolSessions(r *http.Request) ([]statsdomain.ToolSession, bool, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return nil, false, fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+
+	_, batch := probe["sessions"]
+	requests := make([]toolSessionRequest, 0, 1)
+	if batch {
+		var payload toolSessionBatchRequest
+		if err := decodeStrict(body, &payload); err != nil {
+			return nil, true, err
+		}
+		requests = payload.Sessions
+	} else {
+		var payload toolSessionRequest
+		if err := decodeStrict(body, &payload); err != nil {
+			return nil, false, err
+		}
+		requests = append(requests, payload)
+	}
+
+	sessions := make([]statsdomain.ToolSession, 0, len(requests))
+	for _, req := range requests {
+		sessions = append(sessions, statsdomain.ToolSession{
+			ToolType:        req.ToolType,
+			StartedAt:       req.StartedAt,
+			DurationSeconds: req.DurationSeconds,
+			SelfRating:      req.SelfRating,
+			Metadata:        req.Metadata,
+		})
+	}
+	return sessions, batch, nil
+}
+
+func decodeStrict(body []byte, dst any) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errInvalidRequestBody
+	}
+	return nil
+}
+
 func requiredDate(payload map[string]json.RawMessage, field string) (time.Time, error) {
 	raw, ok := payload[field]
 	if !ok || isJSONNull(raw) {
@@ -181,6 +251,19 @@ func optionalInt(payload map[string]json.RawMessage, field string) (statsdomain.
 	return statsdomain.Optional[int]{Present: true, Value: &intValue}, nil
 }
 
+func optionalBool(payload map[string]json.RawMessage, field string) (bool, error) {
+	raw, ok := payload[field]
+	if !ok || isJSONNull(raw) {
+		return false, nil
+	}
+
+	var value bool
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return false, errInvalidRequestBody
+	}
+	return value, nil
+}
+
 func isJSONNull(raw json.RawMessage) bool {
 	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
 }
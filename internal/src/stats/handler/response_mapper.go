@@ -9,36 +9,54 @@ import (
 )
 
 type dailyStatResponse struct {
-	ID                uuid.UUID `json:"id"`
-	Date              string    `json:"date"`
-	Mood              *string   `json:"mood"`
-	SleepHours        *float64  `json:"sleep_hours"`
-	JournalEntry      *string   `json:"journal_entry"`
-	StressLevel       *int      `json:"stress_level"`
-	MindfulHours      *float64  `json:"mindful_hours"`
-	StutterScore      *float64  `json:"stutter_score"`
-	StutterCount      *int      `json:"stutter_count"`
-	RepetitionCount   *int      `json:"repetition_count"`
-	FillerCount       *int      `json:"filler_count"`
-	TotalWords        *int      `json:"total_words"`
-	StutterTranscript *string   `json:"stutter_transcript"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                uuid.UUID              `json:"id"`
+	Date              string                 `json:"date"`
+	Mood              *string                `json:"mood"`
+	SleepHours        *float64               `json:"sleep_hours"`
+	JournalEntry      *string                `json:"journal_entry"`
+	StressLevel       *int                   `json:"stress_level"`
+	MindfulHours      *float64               `json:"mindful_hours"`
+	StutterScore      *float64               `json:"stutter_score"`
+	StutterCount      *int                   `json:"stutter_count"`
+	RepetitionCount   *int                   `json:"repetition_count"`
+	FillerCount       *int                   `json:"filler_count"`
+	TotalWords        *int                   `json:"total_words"`
+	StutterTranscript *string                `json:"stutter_transcript"`
+	SpeechMetrics     *speechMetricsResponse `json:"speech_metrics"`
+	CreatedAt         time.Time              `json:"created_at"`
+	UpdatedAt         time.Time              `json:"updated_at"`
 }
 
 type dailySnapshotResponse struct {
-	Date              string   `json:"date"`
-	Mood              *string  `json:"mood"`
-	SleepHours        *float64 `json:"sleep_hours"`
-	JournalEntry      *string  `json:"journal_entry"`
-	StressLevel       *int     `json:"stress_level"`
-	MindfulHours      *float64 `json:"mindful_hours"`
-	StutterScore      *float64 `json:"stutter_score"`
-	StutterCount      *int     `json:"stutter_count"`
-	RepetitionCount   *int     `json:"repetition_count"`
-	FillerCount       *int     `json:"filler_count"`
-	TotalWords        *int     `json:"total_words"`
-	StutterTranscript *string  `json:"stutter_transcript"`
+	Date              string                 `json:"date"`
+	Mood              *string                `json:"mood"`
+	SleepHours        *float64               `json:"sleep_hours"`
+	JournalEntry      *string                `json:"journal_entry"`
+	StressLevel       *int                   `json:"stress_level"`
+	MindfulHours      *float64               `json:"mindful_hours"`
+	StutterScore      *float64               `json:"stutter_score"`
+	StutterCount      *int                   `json:"stutter_count"`
+	RepetitionCount   *int                   `json:"repetition_count"`
+	FillerCount       *int                   `json:"filler_count"`
+	TotalWords        *int                   `json:"total_words"`
+	StutterTranscript *string                `json:"stutter_transcript"`
+	SpeechMetrics     *speechMetricsResponse `json:"speech_metrics"`
+}
+
+type speechMetricsResponse struct {
+	RecordingID          uuid.UUID `json:"recording_id"`
+	DurationSeconds      float64   `json:"duration_seconds"`
+	SpeechSeconds        float64   `json:"speech_seconds"`
+	SpeechRatio          float64   `json:"speech_ratio"`
+	ArticulationRate     *float64  `json:"articulation_rate"`
+	PauseCount           int       `json:"pause_count"`
+	PausesPerMinute      float64   `json:"pauses_per_minute"`
+	ShortPauses          int       `json:"short_pauses"`
+	MediumPauses         int       `json:"medium_pauses"`
+	LongPauses           int       `json:"long_pauses"`
+	MeanPauseSeconds     float64   `json:"mean_pause_seconds"`
+	LongestPauseSeconds  float64   `json:"longest_pause_seconds"`
+	LongestSpeechSeconds float64   `json:"longest_speech_seconds"`
 }
 
 type statsResponse struct {
@@ -51,6 +69,24 @@ type statsResponse struct {
 	WeeklyActivity  []weeklyActivityResponse `json:"weekly_activity"`
 	WeeklyTrend     []weeklyTrendResponse    `json:"weekly_trend"`
 	RecentSessions  []recentSessionResponse  `json:"recent_sessions"`
+	Comparison      *comparisonResponse      `json:"comparison,omitempty"`
+}
+
+type metricDeltaResponse struct {
+	Metric        string                      `json:"metric"`
+	LowerIsBetter bool                        `json:"lower_is_better"`
+	Current       *float64                    `json:"current"`
+	Previous      *float64                    `json:"previous"`
+	Delta         *float64                    `json:"delta"`
+	PercentDelta  *float64                    `json:"percent_delta"`
+	Direction     statsdomain.MetricDirection `json:"direction"`
+}
+
+type comparisonResponse struct {
+	Mode    statsdomain.CompareMode `json:"mode"`
+	From    string                  `json:"from"`
+	To      string                  `json:"to"`
+	Metrics []metricDeltaResponse   `json:"metrics"`
 }
 
 type wellnessSummaryResponse struct {
@@ -63,12 +99,26 @@ type wellnessSummaryResponse struct {
 }
 
 type stutterSummaryResponse struct {
-	AvgScore      *float64             `json:"avg_score"`
-	BestScore     *float64             `json:"best_score"`
-	WorstScore    *float64             `json:"worst_score"`
-	TotalAnalyses int                  `json:"total_analyses"`
-	ScoreTrend    []scoreTrendResponse `json:"score_trend"`
-	LatestScore   *float64             `json:"latest_score"`
+	AvgScore      *float64              `json:"avg_score"`
+	BestScore     *float64              `json:"best_score"`
+	WorstScore    *float64              `json:"worst_score"`
+	TotalAnalyses int                   `json:"total_analyses"`
+	ScoreTrend    []scoreTrendResponse  `json:"score_trend"`
+	LatestScore   *float64              `json:"latest_score"`
+	Speech        speechSummaryResponse `json:"speech"`
+}
+
+type speechSummaryResponse struct {
+	AnalyzedDays         int      `json:"analyzed_days"`
+	AvgSpeechRatio       *float64 `json:"avg_speech_ratio"`
+	AvgArticulationRate  *float64 `json:"avg_articulation_rate"`
+	AvgPausesPerMinute   *float64 `json:"avg_pauses_per_minute"`
+	AvgMeanPauseSeconds  *float64 `json:"avg_mean_pause_seconds"`
+	LongestPauseSeconds  *float64 `json:"longest_pause_seconds"`
+	LongestSpeechSeconds *float64 `json:"longest_speech_seconds"`
+	ShortPauses          int      `json:"short_pauses"`
+	MediumPauses         int      `json:"medium_pauses"`
+	LongPauses           int      `json:"long_pauses"`
 }
 
 type scoreTrendResponse struct {
@@ -176,6 +226,16 @@ type recentSessionResponse struct {
 	SelfRating      *int      `json:"self_rating"`
 }
 
+type toolSessionResponse struct {
+	ID              uuid.UUID      `json:"id"`
+	ToolType        string         `json:"tool_type"`
+	StartedAt       time.Time      `json:"started_at"`
+	DurationSeconds int            `json:"duration_seconds"`
+	SelfRating      *int           `json:"self_rating"`
+	Metadata        map[string]any `json:"metadata"`
+	CreatedAt       time.Time      `json:"created_at"`
+}
+
 func toStatsResponse(stats *statsdomain.StatsOverview) statsResponse {
 	dailyStats := make([]dailySnapshotResponse, 0, len(stats.DailyStats))
 	for _, stat := range stats.DailyStats {
@@ -198,6 +258,23 @@ func toStatsResponse(stats *statsdomain.StatsOverview) statsResponse {
 		WeeklyActivity:  toWeeklyActivityResponse(stats.WeeklyActivity),
 		WeeklyTrend:     toWeeklyTrendResponse(stats.WeeklyTrend),
 		RecentSessions:  toRecentSessionResponse(stats.RecentSessions),
+		Comparison:      toComparisonResponse(stats.Comparison),
+	}
+}
+
+func toComparisonResponse(comparison *statsdomain.PeriodComparison) *comparisonResponse {
+	if comparison == nil {
+		return nil
+	}
+	metrics := make([]metricDeltaResponse, 0, len(comparison.Metrics))
+	for _, delta := range comparison.Metrics {
+		metrics = append(metrics, metricDeltaResponse(delta))
+	}
+	return &comparisonResponse{
+		Mode:    comparison.Mode,
+		From:    formatDate(comparison.From),
+		To:      formatDate(comparison.To),
+		Metrics: metrics,
 	}
 }
 
@@ -216,6 +293,7 @@ func toDailyStatResponse(stat *statsdomain.DailyStat) dailyStatResponse {
 		FillerCount:       stat.FillerCount,
 		TotalWords:        stat.TotalWords,
 		StutterTranscript: stat.StutterTranscript,
+		SpeechMetrics:     toSpeechMetricsResponse(stat.SpeechMetrics, stat.TotalWords),
 		CreatedAt:         stat.CreatedAt,
 		UpdatedAt:         stat.UpdatedAt,
 	}
@@ -235,6 +313,28 @@ func toDailySnapshotResponse(stat *statsdomain.DailyStat) dailySnapshotResponse
 		FillerCount:       stat.FillerCount,
 		TotalWords:        stat.TotalWords,
 		StutterTranscript: stat.StutterTranscript,
+		SpeechMetrics:     toSpeechMetricsResponse(stat.SpeechMetrics, stat.TotalWords),
+	}
+}
+
+func toSpeechMetricsResponse(m *statsdomain.SpeechMetrics, totalWords *int) *speechMetricsResponse {
+	if m == nil {
+		return nil
+	}
+	return &speechMetricsResponse{
+		RecordingID:          m.RecordingID,
+		DurationSeconds:      m.DurationSeconds,
+		SpeechSeconds:        m.SpeechSeconds,
+		SpeechRatio:          m.SpeechRatio(),
+		ArticulationRate:     m.ArticulationRate(totalWords),
+		PauseCount:           m.PauseCount,
+		PausesPerMinute:      m.PausesPerMinute(),
+		ShortPauses:          m.ShortPauses,
+		MediumPauses:         m.MediumPauses,
+		LongPauses:           m.LongPauses,
+		MeanPauseSeconds:     m.MeanPauseSeconds,
+		LongestPauseSeconds:  m.LongestPauseSeconds,
+		LongestSpeechSeconds: m.LongestSpeechSeconds,
 	}
 }
 
@@ -262,6 +362,7 @@ func toStutterSummaryResponse(summary statsdomain.StutterSummary) stutterSummary
 		TotalAnalyses: summary.TotalAnalyses,
 		ScoreTrend:    trend,
 		LatestScore:   summary.LatestScore,
+		Speech:        speechSummaryResponse(summary.Speech),
 	}
 }
 
@@ -314,6 +415,49 @@ func toRecentSessionResponse(sessions []*statsdomain.ToolSession) []recentSessio
 	return response
 }
 
+func toToolSessionResponse(session *statsdomain.ToolSession) toolSessionResponse {
+	return toolSessionResponse{
+		ID:              session.ID,
+		ToolType:        session.ToolType,
+		StartedAt:       session.StartedAt,
+		DurationSeconds: session.DurationSeconds,
+		SelfRating:      session.SelfRating,
+		Metadata:        session.Metadata,
+		CreatedAt:       session.CreatedAt,
+	}
+}
+
+type correlationResponse struct {
+	Factor   string                           `json:"factor"`
+	LagDays  int                              `json:"lag_days"`
+	Samples  int                              `json:"samples"`
+	Pearson  *float64                         `json:"pearson"`
+	Spearman *float64                         `json:"spearman"`
+	Strength *statsdomain.CorrelationStrength `json:"strength"`
+	Status   statsdomain.CorrelationStatus    `json:"status"`
+	Summary  string                           `json:"summary"`
+}
+
+type insightsResponse struct {
+	From         string                `json:"from"`
+	To           string                `json:"to"`
+	MinSamples   int                   `json:"min_samples"`
+	Correlations []correlationResponse `json:"correlations"`
+}
+
+func toInsightsResponse(insights *statsdomain.Insights) insightsResponse {
+	correlations := make([]correlationResponse, 0, len(insights.Correlations))
+	for _, c := range insights.Correlations {
+		correlations = append(correlations, correlationResponse(c))
+	}
+	return insightsResponse{
+		From:         formatDate(insights.From),
+		To:           formatDate(insights.To),
+		MinSamples:   insights.MinSamples,
+		Correlations: correlations,
+	}
+}
+
 func formatDate(date time.Time) string {
 	return date.UTC().Format(dateLayout)
 }
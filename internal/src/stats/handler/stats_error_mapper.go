@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	statsdomain "saythis-backend/internal/src/stats/domain"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
 )
 
 var errInvalidRequestBody = errors.New("invalid request body")
@@ -21,6 +22,8 @@ func mapStatsError(err error) (int, string) {
 		return http.StatusBadRequest, "date cannot be in the future"
 	case errors.Is(err, statsdomain.ErrInvalidDateRange):
 		return http.StatusBadRequest, "invalid date range"
+	case errors.Is(err, statsdomain.ErrInvalidCompareMode):
+		return http.StatusBadRequest, err.Error()
 	case errors.Is(err, statsdomain.ErrInvalidMood):
 		return http.StatusBadRequest, "Invalid mood value"
 	case errors.Is(err, statsdomain.ErrInvalidSleepHours):
@@ -45,6 +48,21 @@ func mapStatsError(err error) (int, string) {
 		return http.StatusBadRequest, "stutter_transcript is too long"
 	case errors.Is(err, statsdomain.ErrIncompleteStutterData):
 		return http.StatusBadRequest, "Incomplete stutter data"
+	case errors.Is(err, statsdomain.ErrTranscriptRequired):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, statsdomain.ErrNoToolSessions),
+		errors.Is(err, statsdomain.ErrTooManyToolSessions),
+		errors.Is(err, statsdomain.ErrInvalidToolType),
+		errors.Is(err, statsdomain.ErrInvalidStartedAt),
+		errors.Is(err, statsdomain.ErrInvalidDuration),
+		errors.Is(err, statsdomain.ErrInvalidSelfRating),
+		errors.Is(err, statsdomain.ErrInvalidMetadata),
+		errors.Is(err, statsdomain.ErrInvalidToolMetadata):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, therapistdomain.ErrClientNotLinked):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, therapistdomain.ErrScopeNotGranted):
+		return http.StatusForbidden, err.Error()
 	default:
 		return http.StatusInternalServerError, "Internal server error"
 	}
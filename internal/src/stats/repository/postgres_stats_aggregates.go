@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+// The metadata readers below accept the same shapes as the usecase's
+// metadataNumber, metadataBool and metadataString: JSON values of the
+// right type, or strings that parse as one. Older sessions were stored
+// before metadata was validated, so strings still turn up.
+// pg_input_is_valid needs Postgres 16.
+const (
+	metadataNumberSQL = `
+		CASE jsonb_typeof(value)
+			WHEN 'number' THEN (value #>> '{}')::float8
+			WHEN 'string' THEN CASE WHEN pg_input_is_valid(value #>> '{}', 'float8') THEN (value #>> '{}')::float8 END
+		END`
+
+	metadataBoolSQL = `
+		CASE jsonb_typeof(value)
+			WHEN 'boolean' THEN (value #>> '{}')::boolean
+			WHEN 'string'  THEN CASE lower(btrim(value #>> '{}', E' \t\n\r\f\v')) WHEN 'true' THEN true WHEN 'false' THEN false END
+		END`
+)
+
+func (r *PostgresStatsRepo) GetDailyStatAggregate(ctx context.Context, userID uuid.UUID, from, to time.Time) (*statsdomain.DailyStatAggregate, error) {
+	query := `
+		WITH stats AS (
+			SELECT *
+			FROM   user_daily_stats
+			WHERE  user_id = $1 AND date BETWEEN $2 AND $3
+		),
+		moods AS (
+			SELECT mood, COUNT(*)::int AS days
+			FROM   stats
+			WHERE  mood IS NOT NULL
+			GROUP  BY mood
+		)
+		SELECT COALESCE(SUM(sleep_hours), 0)::float8,   COUNT(sleep_hours)::int,
+		       COALESCE(SUM(stress_level), 0)::float8,  COUNT(stress_level)::int,
+		       COALESCE(SUM(mindful_hours), 0)::float8, COUNT(mindful_hours)::int,
+		       COALESCE(SUM(stutter_score), 0)::float8, COUNT(stutter_score)::int,
+		       MIN(stutter_score)::float8,
+		       MAX(stutter_score)::float8,
+		       ((ARRAY_AGG(stutter_score ORDER BY date DESC) FILTER (WHERE stutter_score IS NOT NULL))[1])::float8,
+		       COUNT(journal_entry)::int,
+		       COUNT(*) FILTER (WHERE num_nonnulls(
+		           mood, sleep_hours, journal_entry, stress_level, mindful_hours, stutter_score,
+		           stutter_count, repetition_count, filler_count, total_words, stutter_transcript,
+		           speech_recording_id
+		       ) > 0)::int,
+		       (SELECT COALESCE(jsonb_object_agg(mood, days), '{}') FROM moods)
+		FROM   stats
+	`
+
+	var (
+		agg                           statsdomain.DailyStatAggregate
+		bestScore, worstScore, latest pgtype.Float8
+	)
+	err := r.db.QueryRow(ctx, query, userID, from, to).Scan(
+		&agg.SleepHours.Sum, &agg.SleepHours.Count,
+		&agg.StressLevel.Sum, &agg.StressLevel.Count,
+		&agg.MindfulHours.Sum, &agg.MindfulHours.Count,
+		&agg.StutterScore.Sum, &agg.StutterScore.Count,
+		&bestScore, &worstScore, &latest,
+		&agg.JournalEntries, &agg.DaysTracked, &agg.MoodCounts,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate daily stats: %w", err)
+	}
+
+	agg.BestScore = float8Ptr(bestScore)
+	agg.WorstScore = float8Ptr(worstScore)
+	agg.LatestScore = float8Ptr(latest)
+	return &agg, nil
+}
+
+func (r *PostgresStatsRepo) GetRecentToolSessions(ctx context.Context, userID uuid.UUID, limit int) ([]*statsdomain.ToolSession, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tool_sessions
+		WHERE user_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2
+	`, toolSessionSelectColumns())
+
+	rows, err := r.db.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query recent tool sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := make([]*statsdomain.ToolSession, 0, limit)
+	for rows.Next() {
+		session, err := scanToolSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan tool session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tool sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *PostgresStatsRepo) GetDataVersion(ctx context.Context, userID uuid.UUID) (statsdomain.DataVersion, error) {
+	query := `
+		SELECT (SELECT COUNT(*)::int FROM user_daily_stats WHERE user_id = $1),
+		       (SELECT MAX(updated_at) FROM user_daily_stats WHERE user_id = $1),
+		       (SELECT COUNT(*)::int FROM tool_sessions WHERE user_id = $1),
+		       (SELECT MAX(created_at) FROM tool_sessions WHERE user_id = $1),
+		       COALESCE((SELECT writes FROM user_stats_versions WHERE user_id = $1), 0)
+	`
+
+	var version statsdomain.DataVersion
+	err := r.db.QueryRow(ctx, query, userID).Scan(
+		&version.DailyStats, &version.DailyStatsUpdatedAt,
+		&version.ToolSessions, &version.ToolSessionsAddedAt,
+		&version.Writes,
+	)
+	if err != nil {
+		return statsdomain.DataVersion{}, fmt.Errorf("get stats data version: %w", err)
+	}
+	return version, nil
+}
+
+// BumpDataVersion counts a write to the users' stats, rollups included.
+// Every write behind the overview calls it in its own transaction. The
+// bumps queue on each user's version row, so unlike timestamps taken when
+// a transaction starts they cannot be overtaken by an earlier write that
+// commits late.
+func BumpDataVersion(ctx context.Context, tx pgx.Tx, userIDs []uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO user_stats_versions (user_id, writes)
+		SELECT id, 1 FROM unnest($1::uuid[]) AS u(id)
+		ORDER  BY id
+		ON CONFLICT (user_id) DO UPDATE
+		SET writes = user_stats_versions.writes + 1
+	`, userIDs)
+	if err != nil {
+		return fmt.Errorf("bump stats data version: %w", err)
+	}
+	return nil
+}
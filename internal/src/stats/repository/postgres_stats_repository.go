@@ -54,10 +54,29 @@ func (r *PostgresStatsRepo) UpsertDailyStat(ctx context.Context, userID uuid.UUI
 		RETURNING %s
 	`, strings.Join(columns, ", "), strings.Join(placeholders, ", "), strings.Join(updates, ", "), dailyStatSelectColumns())
 
-	stat, err := scanDailyStat(r.db.QueryRow(ctx, query, args...))
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	stat, err := scanDailyStat(tx.QueryRow(ctx, query, args...))
 	if err != nil {
 		return nil, fmt.Errorf("upsert daily stat: %w", err)
 	}
+
+	if patch.JournalEntry.Present {
+		if err := replaceStreaks(ctx, tx, []uuid.UUID{userID}, []string{statsdomain.StreakJournal}, journalStreakDaysSQL); err != nil {
+			return nil, err
+		}
+	}
+	if err := BumpDataVersion(ctx, tx, []uuid.UUID{userID}); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
 	return stat, nil
 }
 
@@ -106,76 +125,70 @@ func (r *PostgresStatsRepo) GetDailyStatByDate(ctx context.Context, userID uuid.
 	return stat, nil
 }
 
-func (r *PostgresStatsRepo) GetJournalEntryDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
-	query := `
-		SELECT date
-		FROM user_daily_stats
-		WHERE user_id = $1 AND journal_entry IS NOT NULL
-		ORDER BY date DESC
-	`
-
-	rows, err := r.db.Query(ctx, query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("query journal dates: %w", err)
-	}
-	defer rows.Close()
-
-	dates := make([]time.Time, 0)
-	for rows.Next() {
-		var date time.Time
-		if err := rows.Scan(&date); err != nil {
-			return nil, fmt.Errorf("scan journal date: %w", err)
+func (r *PostgresStatsRepo) CreateToolSessions(ctx context.Context, sessions []*statsdomain.ToolSession) ([]*statsdomain.ToolSession, error) {
+	userIDs := make([]uuid.UUID, 0, 1)
+	seen := make(map[uuid.UUID]bool)
+	for _, session := range sessions {
+		if !seen[session.UserID] {
+			seen[session.UserID] = true
+			userIDs = append(userIDs, session.UserID)
 		}
-		dates = append(dates, date)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate journal dates: %w", err)
-	}
-	return dates, nil
-}
 
-func (r *PostgresStatsRepo) GetToolSessions(ctx context.Context, userID uuid.UUID) ([]*statsdomain.ToolSession, error) {
-	exists, err := r.toolSessionsTableExists(ctx)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("begin transaction: %w", err)
 	}
-	if !exists {
-		return []*statsdomain.ToolSession{}, nil
+	defer tx.Rollback(ctx)
+
+	if err := lockUsers(ctx, tx, userIDs); err != nil {
+		return nil, err
 	}
 
-	query := `
-		SELECT id, user_id, tool_type, started_at, duration_seconds, self_rating, metadata
-		FROM tool_sessions
-		WHERE user_id = $1
-		ORDER BY started_at DESC
-	`
+	query := fmt.Sprintf(`
+		INSERT INTO tool_sessions (id, user_id, tool_type, started_at, duration_seconds, self_rating, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING %s
+	`, toolSessionSelectColumns())
 
-	rows, err := r.db.Query(ctx, query, userID)
-	if err != nil {
-		return nil, fmt.Errorf("query tool sessions: %w", err)
-	}
-	defer rows.Close()
+	created := make([]*statsdomain.ToolSession, 0, len(sessions))
+	for _, session := range sessions {
+		metadataJSON, err := json.Marshal(session.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("marshal tool session metadata: %w", err)
+		}
 
-	sessions := make([]*statsdomain.ToolSession, 0)
-	for rows.Next() {
-		session, err := scanToolSession(rows)
+		stored, err := scanToolSession(tx.QueryRow(ctx, query,
+			session.ID, session.UserID, session.ToolType, session.StartedAt,
+			session.DurationSeconds, session.SelfRating, metadataJSON,
+		))
 		if err != nil {
-			return nil, fmt.Errorf("scan tool session: %w", err)
+			return nil, fmt.Errorf("insert tool session: %w", err)
 		}
-		sessions = append(sessions, session)
+		created = append(created, stored)
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterate tool sessions: %w", err)
+
+	sessionIDs := make([]uuid.UUID, 0, len(created))
+	for _, session := range created {
+		sessionIDs = append(sessionIDs, session.ID)
+	}
+	if err := addSessionsToRollups(ctx, tx, sessionsByIDSQL, sessionIDs); err != nil {
+		return nil, err
+	}
+	if err := addSessionsToToolTotals(ctx, tx, sessionsByIDSQL, sessionIDs); err != nil {
+		return nil, err
+	}
+	if err := replaceStreaks(ctx, tx, userIDs, toolGroupNames(), toolStreakDaysSQL); err != nil {
+		return nil, err
+	}
+	if err := BumpDataVersion(ctx, tx, userIDs); err != nil {
+		return nil, err
 	}
-	return sessions, nil
-}
 
-func (r *PostgresStatsRepo) toolSessionsTableExists(ctx context.Context) (bool, error) {
-	var tableName pgtype.Text
-	if err := r.db.QueryRow(ctx, `SELECT to_regclass('public.tool_sessions')::text`).Scan(&tableName); err != nil {
-		return false, fmt.Errorf("check tool_sessions table: %w", err)
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
-	return tableName.Valid, nil
+	return created, nil
 }
 
 func addOptionalColumn[T any](columns, placeholders, updates *[]string, args *[]any, column string, value statsdomain.Optional[T]) {
@@ -196,9 +209,16 @@ func addOptionalColumn[T any](columns, placeholders, updates *[]string, args *[]
 func dailyStatSelectColumns() string {
 	return `id, user_id, date, mood, sleep_hours::float8, journal_entry, stress_level, mindful_hours::float8,
 		stutter_score::float8, stutter_count, repetition_count, filler_count, total_words, stutter_transcript,
+		speech_recording_id, audio_duration_seconds::float8, speech_seconds::float8, pause_count,
+		short_pause_count, medium_pause_count, long_pause_count, mean_pause_seconds::float8,
+		longest_pause_seconds::float8, longest_speech_seconds::float8,
 		created_at, updated_at`
 }
 
+func toolSessionSelectColumns() string {
+	return `id, user_id, tool_type, started_at, duration_seconds, self_rating, metadata, created_at`
+}
+
 type rowScanner interface {
 	Scan(dest ...any) error
 }
@@ -217,6 +237,16 @@ func scanDailyStat(row rowScanner) (*statsdomain.DailyStat, error) {
 		fillerCount       pgtype.Int4
 		totalWords        pgtype.Int4
 		stutterTranscript pgtype.Text
+		speechRecordingID pgtype.UUID
+		audioDuration     pgtype.Float8
+		speechSeconds     pgtype.Float8
+		pauseCount        pgtype.Int4
+		shortPauses       pgtype.Int4
+		mediumPauses      pgtype.Int4
+		longPauses        pgtype.Int4
+		meanPause         pgtype.Float8
+		longestPause      pgtype.Float8
+		longestSpeech     pgtype.Float8
 	)
 
 	if err := row.Scan(
@@ -234,6 +264,16 @@ func scanDailyStat(row rowScanner) (*statsdomain.DailyStat, error) {
 		&fillerCount,
 		&totalWords,
 		&stutterTranscript,
+		&speechRecordingID,
+		&audioDuration,
+		&speechSeconds,
+		&pauseCount,
+		&shortPauses,
+		&mediumPauses,
+		&longPauses,
+		&meanPause,
+		&longestPause,
+		&longestSpeech,
 		&stat.CreatedAt,
 		&stat.UpdatedAt,
 	); err != nil {
@@ -252,6 +292,23 @@ func scanDailyStat(row rowScanner) (*statsdomain.DailyStat, error) {
 	stat.TotalWords = int4Ptr(totalWords)
 	stat.StutterTranscript = textPtr(stutterTranscript)
 
+	// The metrics are written and cleared together, keyed on the source
+	// recording.
+	if speechRecordingID.Valid {
+		stat.SpeechMetrics = &statsdomain.SpeechMetrics{
+			RecordingID:          speechRecordingID.Bytes,
+			DurationSeconds:      audioDuration.Float64,
+			SpeechSeconds:        speechSeconds.Float64,
+			PauseCount:           int(pauseCount.Int32),
+			ShortPauses:          int(shortPauses.Int32),
+			MediumPauses:         int(mediumPauses.Int32),
+			LongPauses:           int(longPauses.Int32),
+			MeanPauseSeconds:     meanPause.Float64,
+			LongestPauseSeconds:  longestPause.Float64,
+			LongestSpeechSeconds: longestSpeech.Float64,
+		}
+	}
+
 	return &stat, nil
 }
 
@@ -270,6 +327,7 @@ func scanToolSession(row rowScanner) (*statsdomain.ToolSession, error) {
 		&session.DurationSeconds,
 		&selfRating,
 		&metadataJSON,
+		&session.CreatedAt,
 	); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,433 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+// Streaks are computed from one of these day lists. Each returns the
+// (user_id, streak, date) rows of the users in $1; the streaks wanted are
+// in $2.
+const (
+	toolStreakDaysSQL = `
+		SELECT user_id, tool_group, date
+		FROM   user_activity_rollups
+		WHERE  user_id = ANY($1::uuid[]) AND tool_group = ANY($2::text[])`
+
+	journalStreakDaysSQL = `
+		SELECT user_id, '` + statsdomain.StreakJournal + `', date
+		FROM   user_daily_stats
+		WHERE  user_id = ANY($1::uuid[]) AND journal_entry IS NOT NULL`
+)
+
+// The sessions added to the rollups and tool totals are picked from
+// tool_sessions s by one of these filters on $1.
+const (
+	sessionsByIDSQL   = `s.id = ANY($1::uuid[])`
+	sessionsByUserSQL = `s.user_id = ANY($1::uuid[])`
+)
+
+// addSessionsToRollups adds the sessions matching sessionsSQL to their
+// civil dates' rollups, once for every group their tool type is in and
+// once under the tool type itself. Dates are taken in the users' stored
+// timezones, which the caller has locked, so a timezone change cannot slip
+// in between and leave the rollups in the old zone.
+func addSessionsToRollups(ctx context.Context, tx pgx.Tx, sessionsSQL string, ids []uuid.UUID) error {
+	names, toolTypes := rollupMembers()
+	query := `
+		INSERT INTO user_activity_rollups (user_id, tool_group, date, sessions, seconds)
+		SELECT s.user_id, g.name, (s.started_at AT TIME ZONE u.timezone)::date,
+		       COUNT(*), SUM(s.duration_seconds)
+		FROM   tool_sessions s
+		JOIN   users u ON u.id = s.user_id
+		JOIN   unnest($2::text[], $3::text[]) AS g(name, tool_type)
+		       ON g.tool_type = s.tool_type
+		WHERE  ` + sessionsSQL + `
+		GROUP  BY 1, 2, 3
+		ON CONFLICT (user_id, tool_group, date) DO UPDATE
+		SET sessions = user_activity_rollups.sessions + EXCLUDED.sessions,
+		    seconds  = user_activity_rollups.seconds + EXCLUDED.seconds
+	`
+
+	if _, err := tx.Exec(ctx, query, ids, names, toolTypes); err != nil {
+		return fmt.Errorf("add sessions to rollups: %w", err)
+	}
+	return nil
+}
+
+// addSessionsToToolTotals adds the ratings, headline metrics and labels of
+// the sessions matching sessionsSQL to their tool type's totals.
+func addSessionsToToolTotals(ctx context.Context, tx pgx.Tx, sessionsSQL string, ids []uuid.UUID) error {
+	toolTypes := make([]string, 0, len(statsdomain.ToolMetrics))
+	keys := make([]string, 0, len(statsdomain.ToolMetrics))
+	kinds := make([]string, 0, len(statsdomain.ToolMetrics))
+	for toolType, metric := range statsdomain.ToolMetrics {
+		toolTypes = append(toolTypes, toolType)
+		keys = append(keys, metric.Key)
+		kinds = append(kinds, string(metric.Kind))
+	}
+
+	_, err := tx.Exec(ctx, `
+		WITH sessions AS (
+			SELECT s.user_id, s.tool_type, s.started_at, s.duration_seconds, s.self_rating,
+			       m.kind,
+			       s.metadata -> m.key AS value
+			FROM   tool_sessions s
+			LEFT   JOIN unnest($2::text[], $3::text[], $4::text[]) AS m(tool_type, key, kind)
+			       ON m.tool_type = s.tool_type
+			WHERE  `+sessionsSQL+`
+		),
+		metrics AS (
+			SELECT user_id, tool_type, started_at, self_rating,
+			       CASE kind
+			           WHEN '`+string(statsdomain.MetricValue)+`' THEN `+metadataNumberSQL+`
+			           WHEN '`+string(statsdomain.MetricPerMinute)+`' THEN CASE WHEN duration_seconds > 0
+			               THEN (`+metadataNumberSQL+`) / (duration_seconds::float8 / 60) END
+			           WHEN '`+string(statsdomain.MetricCompletion)+`' THEN CASE `+metadataBoolSQL+`
+			               WHEN true THEN 100::float8 WHEN false THEN 0::float8 END
+			       END AS metric
+			FROM   sessions
+		)
+		INSERT INTO user_tool_totals (user_id, tool_type, rating_sum, rating_count, metric_sum, metric_count, last_started_at)
+		SELECT user_id, tool_type,
+		       COALESCE(SUM(self_rating), 0), COUNT(self_rating),
+		       COALESCE(SUM(metric), 0),      COUNT(metric),
+		       MAX(started_at)
+		FROM   metrics
+		GROUP  BY user_id, tool_type
+		ON CONFLICT (user_id, tool_type) DO UPDATE
+		SET rating_sum      = user_tool_totals.rating_sum + EXCLUDED.rating_sum,
+		    rating_count    = user_tool_totals.rating_count + EXCLUDED.rating_count,
+		    metric_sum      = user_tool_totals.metric_sum + EXCLUDED.metric_sum,
+		    metric_count    = user_tool_totals.metric_count + EXCLUDED.metric_count,
+		    last_started_at = GREATEST(user_tool_totals.last_started_at, EXCLUDED.last_started_at)
+	`, ids, toolTypes, keys, kinds)
+	if err != nil {
+		return fmt.Errorf("add sessions to tool totals: %w", err)
+	}
+
+	labelTypes := make([]string, 0, len(statsdomain.ToolLabelKeys))
+	labelKeys := make([]string, 0, len(statsdomain.ToolLabelKeys))
+	for toolType, key := range statsdomain.ToolLabelKeys {
+		labelTypes = append(labelTypes, toolType)
+		labelKeys = append(labelKeys, key)
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO user_tool_labels (user_id, tool_type, label, sessions)
+		SELECT user_id, tool_type, label, COUNT(*)
+		FROM (
+			SELECT s.user_id, s.tool_type,
+			       NULLIF(btrim(s.metadata ->> m.key, E' \t\n\r\f\v'), '') AS label
+			FROM   tool_sessions s
+			JOIN   unnest($2::text[], $3::text[]) AS m(tool_type, key)
+			       ON m.tool_type = s.tool_type
+			WHERE  `+sessionsSQL+`
+			  AND  jsonb_typeof(s.metadata -> m.key) = 'string'
+		) l
+		WHERE  label IS NOT NULL
+		GROUP  BY user_id, tool_type, label
+		ON CONFLICT (user_id, tool_type, label) DO UPDATE
+		SET sessions = user_tool_labels.sessions + EXCLUDED.sessions
+	`, ids, labelTypes, labelKeys)
+	if err != nil {
+		return fmt.Errorf("add sessions to tool labels: %w", err)
+	}
+	return nil
+}
+
+// lockUsers serialises the rollup and streak writes of the given users
+// until the transaction ends. Rows are locked in id order so that batches
+// covering several users cannot deadlock. FOR NO KEY UPDATE leaves the
+// foreign key checks of concurrent inserts unblocked.
+func lockUsers(ctx context.Context, tx pgx.Tx, userIDs []uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		SELECT id FROM users WHERE id = ANY($1::uuid[]) ORDER BY id FOR NO KEY UPDATE
+	`, userIDs)
+	if err != nil {
+		return fmt.Errorf("lock users: %w", err)
+	}
+	return nil
+}
+
+// replaceStreaks recomputes the named streaks of the given users from
+// daysSQL. A backdated day can join or split runs anywhere in the history,
+// so the state is rebuilt from the days rather than adjusted in place.
+// The users are locked first: under READ COMMITTED two concurrent
+// replacements would otherwise both insert after their deletes.
+func replaceStreaks(ctx context.Context, tx pgx.Tx, userIDs []uuid.UUID, names []string, daysSQL string) error {
+	if err := lockUsers(ctx, tx, userIDs); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(ctx, `
+		DELETE FROM user_activity_streaks
+		WHERE  user_id = ANY($1::uuid[]) AND streak = ANY($2::text[])
+	`, userIDs, names)
+	if err != nil {
+		return fmt.Errorf("clear streaks: %w", err)
+	}
+
+	// Gaps and islands: consecutive days share the same anchor once their
+	// row number is subtracted. The latest run is kept as the current one.
+	query := `
+		WITH days AS (` + daysSQL + `
+		),
+		islands AS (
+			SELECT user_id, streak, date,
+			       date - ROW_NUMBER() OVER (PARTITION BY user_id, streak ORDER BY date)::int AS anchor
+			FROM   days AS d(user_id, streak, date)
+		),
+		runs AS (
+			SELECT user_id, streak, MIN(date) AS run_start, MAX(date) AS run_end, COUNT(*) AS length
+			FROM   islands
+			GROUP  BY user_id, streak, anchor
+		)
+		INSERT INTO user_activity_streaks (user_id, streak, current_start, current_end, best_days, active_days)
+		SELECT DISTINCT ON (user_id, streak)
+		       user_id, streak, run_start, run_end,
+		       (MAX(length) OVER w)::int,
+		       (SUM(length) OVER w)::int
+		FROM   runs
+		WHERE  streak = ANY($2::text[])
+		WINDOW w AS (PARTITION BY user_id, streak)
+		ORDER  BY user_id, streak, run_end DESC
+	`
+
+	if _, err := tx.Exec(ctx, query, userIDs, names); err != nil {
+		return fmt.Errorf("compute streaks: %w", err)
+	}
+	return nil
+}
+
+// rollupMembers pairs each rollup with a tool type counted in it: the
+// tool groups, and every tool type on its own for the per-tool totals.
+// Group names are lower case, so they cannot clash with a tool type.
+func rollupMembers() ([]string, []string) {
+	names := make([]string, 0)
+	toolTypes := make([]string, 0)
+	for name, members := range statsdomain.ToolGroups {
+		for _, toolType := range members {
+			names = append(names, name)
+			toolTypes = append(toolTypes, toolType)
+		}
+	}
+	for _, toolType := range statsdomain.ToolTypes() {
+		names = append(names, toolType)
+		toolTypes = append(toolTypes, toolType)
+	}
+	return names, toolTypes
+}
+
+func toolGroupNames() []string {
+	names := make([]string, 0, len(statsdomain.ToolGroups))
+	for name := range statsdomain.ToolGroups {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r *PostgresStatsRepo) GetStreaks(ctx context.Context, userID uuid.UUID) (map[string]statsdomain.StreakState, error) {
+	query := `
+		SELECT streak, current_start, current_end, best_days, active_days
+		FROM   user_activity_streaks
+		WHERE  user_id = $1
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query streaks: %w", err)
+	}
+	defer rows.Close()
+
+	streaks := make(map[string]statsdomain.StreakState)
+	for rows.Next() {
+		var (
+			name  string
+			state statsdomain.StreakState
+		)
+		if err := rows.Scan(&name, &state.CurrentStart, &state.CurrentEnd, &state.Best, &state.ActiveDays); err != nil {
+			return nil, fmt.Errorf("scan streak: %w", err)
+		}
+		streaks[name] = state
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate streaks: %w", err)
+	}
+	return streaks, nil
+}
+
+func (r *PostgresStatsRepo) GetToolActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]statsdomain.ToolActivityDay, error) {
+	query := `
+		SELECT date, sessions, seconds
+		FROM   user_activity_rollups
+		WHERE  user_id = $1 AND tool_group = $2 AND date BETWEEN $3 AND $4
+		ORDER  BY date
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, statsdomain.ToolGroupCombined, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query tool activity: %w", err)
+	}
+	defer rows.Close()
+
+	days := make([]statsdomain.ToolActivityDay, 0)
+	for rows.Next() {
+		var day statsdomain.ToolActivityDay
+		if err := rows.Scan(&day.Date, &day.Sessions, &day.Seconds); err != nil {
+			return nil, fmt.Errorf("scan tool activity: %w", err)
+		}
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tool activity: %w", err)
+	}
+	return days, nil
+}
+
+// rebuildBatchSize bounds how many users one rebuild transaction locks, so
+// a full rebuild only holds up writes of the batch in progress.
+const rebuildBatchSize = 100
+
+func (r *PostgresStatsRepo) RebuildActivityRollups(ctx context.Context, userID *uuid.UUID) (int, error) {
+	rows, err := r.db.Query(ctx, `SELECT id FROM users WHERE $1::uuid IS NULL OR id = $1 ORDER BY id`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("query users: %w", err)
+	}
+	userIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return 0, fmt.Errorf("collect users: %w", err)
+	}
+
+	for start := 0; start < len(userIDs); start += rebuildBatchSize {
+		batch := userIDs[start:min(start+rebuildBatchSize, len(userIDs))]
+		if err := r.rebuildBatch(ctx, batch); err != nil {
+			return start, err
+		}
+	}
+	return len(userIDs), nil
+}
+
+func (r *PostgresStatsRepo) rebuildBatch(ctx context.Context, userIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := RebuildActivity(ctx, tx, userIDs); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RebuildActivity recomputes the rollups, tool totals and streaks of the
+// given users inside tx. Civil dates come from each user's timezone as tx sees it, so
+// the user repository calls this in the same transaction that changes it.
+func RebuildActivity(ctx context.Context, tx pgx.Tx, userIDs []uuid.UUID) error {
+	if err := lockUsers(ctx, tx, userIDs); err != nil {
+		return err
+	}
+
+	for _, table := range []string{"user_activity_rollups", "user_tool_totals", "user_tool_labels"} {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE user_id = ANY($1::uuid[])`, userIDs); err != nil {
+			return fmt.Errorf("clear %s: %w", table, err)
+		}
+	}
+
+	if err := addSessionsToRollups(ctx, tx, sessionsByUserSQL, userIDs); err != nil {
+		return err
+	}
+	if err := addSessionsToToolTotals(ctx, tx, sessionsByUserSQL, userIDs); err != nil {
+		return err
+	}
+
+	if err := replaceStreaks(ctx, tx, userIDs, toolGroupNames(), toolStreakDaysSQL); err != nil {
+		return err
+	}
+	if err := replaceStreaks(ctx, tx, userIDs, []string{statsdomain.StreakJournal}, journalStreakDaysSQL); err != nil {
+		return err
+	}
+	return BumpDataVersion(ctx, tx, userIDs)
+}
+
+func (r *PostgresStatsRepo) GetToolTotals(ctx context.Context, userID uuid.UUID, weekStart time.Time) ([]statsdomain.ToolTypeTotals, error) {
+	query := `
+		WITH activity AS (
+			SELECT tool_group AS tool_type,
+			       SUM(sessions)::int AS sessions,
+			       SUM(seconds)::bigint AS seconds,
+			       COALESCE(SUM(sessions) FILTER (WHERE date >= $2), 0)::int AS sessions_this_week
+			FROM   user_activity_rollups
+			WHERE  user_id = $1 AND tool_group = ANY($3::text[])
+			GROUP  BY tool_group
+		)
+		SELECT a.tool_type, a.sessions, a.seconds, a.sessions_this_week,
+		       t.rating_sum, t.rating_count, t.metric_sum, t.metric_count,
+		       t.last_started_at
+		FROM   activity a
+		JOIN   user_tool_totals t ON t.user_id = $1 AND t.tool_type = a.tool_type
+		ORDER  BY a.tool_type
+	`
+
+	rows, err := r.db.Query(ctx, query, userID, weekStart, statsdomain.ToolTypes())
+	if err != nil {
+		return nil, fmt.Errorf("query tool totals: %w", err)
+	}
+	defer rows.Close()
+
+	totals := make([]statsdomain.ToolTypeTotals, 0)
+	for rows.Next() {
+		var t statsdomain.ToolTypeTotals
+		if err := rows.Scan(
+			&t.ToolType, &t.Sessions, &t.Seconds, &t.SessionsThisWeek,
+			&t.Rating.Sum, &t.Rating.Count, &t.Metric.Sum, &t.Metric.Count,
+			&t.LastStartedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan tool totals: %w", err)
+		}
+		totals = append(totals, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tool totals: %w", err)
+	}
+	return totals, nil
+}
+
+func (r *PostgresStatsRepo) GetToolLabelCounts(ctx context.Context, userID uuid.UUID) ([]statsdomain.ToolLabelCount, error) {
+	query := `
+		SELECT tool_type, label, sessions
+		FROM   user_tool_labels
+		WHERE  user_id = $1
+		ORDER  BY tool_type, label
+	`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query tool labels: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]statsdomain.ToolLabelCount, 0)
+	for rows.Next() {
+		var c statsdomain.ToolLabelCount
+		if err := rows.Scan(&c.ToolType, &c.Label, &c.Count); err != nil {
+			return nil, fmt.Errorf("scan tool label: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate tool labels: %w", err)
+	}
+	return counts, nil
+}
@@ -10,9 +10,45 @@ import (
 )
 
 type StatsRepository interface {
+	// UpsertDailyStat also updates the journal streak.
 	UpsertDailyStat(ctx context.Context, userID uuid.UUID, patch statsdomain.DailyStatPatch) (*statsdomain.DailyStat, error)
 	GetDailyStatsByRange(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]*statsdomain.DailyStat, error)
 	GetDailyStatByDate(ctx context.Context, userID uuid.UUID, date time.Time) (*statsdomain.DailyStat, error)
-	GetJournalEntryDates(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
-	GetToolSessions(ctx context.Context, userID uuid.UUID) ([]*statsdomain.ToolSession, error)
+
+	// CreateToolSessions also adds the sessions to the activity rollups,
+	// dated in the user's stored timezone, and updates the tool streaks.
+	CreateToolSessions(ctx context.Context, sessions []*statsdomain.ToolSession) ([]*statsdomain.ToolSession, error)
+
+	// The aggregate queries below are computed in Postgres over a bounded
+	// set of rows, so their cost does not grow with the user's history.
+
+	GetDailyStatAggregate(ctx context.Context, userID uuid.UUID, from, to time.Time) (*statsdomain.DailyStatAggregate, error)
+	GetRecentToolSessions(ctx context.Context, userID uuid.UUID, limit int) ([]*statsdomain.ToolSession, error)
+
+	// GetDataVersion is a cheap check for whether anything behind the
+	// overview changed, used for conditional requests.
+	GetDataVersion(ctx context.Context, userID uuid.UUID) (statsdomain.DataVersion, error)
+
+	// The rollups below are maintained by the writes above, so reading
+	// them costs the same however long the history is.
+
+	// GetStreaks returns the stored state of each tool group's streak and
+	// the journal streak. Streaks without any days are left out.
+	GetStreaks(ctx context.Context, userID uuid.UUID) (map[string]statsdomain.StreakState, error)
+
+	// GetToolActivity returns one row per civil date in the range that has
+	// sessions.
+	GetToolActivity(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]statsdomain.ToolActivityDay, error)
+
+	// GetToolTotals returns the totals of every tool type used, counting
+	// the sessions on or after the civil date weekStart separately.
+	GetToolTotals(ctx context.Context, userID uuid.UUID, weekStart time.Time) ([]statsdomain.ToolTypeTotals, error)
+	GetToolLabelCounts(ctx context.Context, userID uuid.UUID) ([]statsdomain.ToolLabelCount, error)
+
+	// RebuildActivityRollups recomputes the rollups and streaks of one user,
+	// or of every user when userID is nil, and returns how many users it
+	// covered. Every user is rebuilt in small batches with a transaction
+	// each, so the users' writes are only held up while their batch runs.
+	// Timezone changes rebuild on their own, see RebuildActivity.
+	RebuildActivityRollups(ctx context.Context, userID *uuid.UUID) (int, error)
 }
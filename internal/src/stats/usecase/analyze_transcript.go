@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"strings"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+func (uc *StatsUseCase) AnalyzeTranscript(transcript string) (statsdomain.TranscriptAnalysis, error) {
+	if strings.TrimSpace(transcript) == "" {
+		return statsdomain.TranscriptAnalysis{}, statsdomain.ErrTranscriptRequired
+	}
+	if len(transcript) > maxTranscriptLength {
+		return statsdomain.TranscriptAnalysis{}, statsdomain.ErrInvalidTranscript
+	}
+	return uc.analyzer.Analyze(transcript), nil
+}
+
+// applyTranscriptAnalysis derives the stutter fields from the patch's
+// transcript. Counts the client did send are kept as they are; a partial
+// set is still rejected by validation.
+func (uc *StatsUseCase) applyTranscriptAnalysis(patch *statsdomain.DailyStatPatch) error {
+	if !patch.StutterTranscript.Present || patch.StutterTranscript.Value == nil {
+		return statsdomain.ErrTranscriptRequired
+	}
+	if patch.StutterScore.Present || patch.StutterCount.Present || patch.RepetitionCount.Present ||
+		patch.FillerCount.Present || patch.TotalWords.Present {
+		return nil
+	}
+
+	analysis, err := uc.AnalyzeTranscript(*patch.StutterTranscript.Value)
+	if err != nil {
+		return err
+	}
+
+	patch.StutterScore = statsdomain.Optional[float64]{Present: true, Value: &analysis.StutterScore}
+	patch.StutterCount = statsdomain.Optional[int]{Present: true, Value: &analysis.StutterCount}
+	patch.RepetitionCount = statsdomain.Optional[int]{Present: true, Value: &analysis.RepetitionCount}
+	patch.FillerCount = statsdomain.Optional[int]{Present: true, Value: &analysis.FillerCount}
+	patch.TotalWords = statsdomain.Optional[int]{Present: true, Value: &analysis.TotalWords}
+	return nil
+}
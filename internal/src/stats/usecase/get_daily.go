@@ -15,7 +15,11 @@ func (uc *StatsUseCase) GetDailyStat(ctx context.Context, userID uuid.UUID, date
 	if date.IsZero() {
 		return nil, statsdomain.ErrInvalidDate
 	}
-	if isFutureDate(date) {
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isFutureDate(date, loc) {
 		return nil, statsdomain.ErrFutureDate
 	}
 
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+// insightLookbackDays is longer than the overview's default because
+// correlations need many paired days to mean anything.
+const insightLookbackDays = 90
+
+var insightFactors = []string{
+	statsdomain.FactorSleepHours,
+	statsdomain.FactorStressLevel,
+	statsdomain.FactorMindfulHours,
+	statsdomain.FactorMood,
+	statsdomain.FactorToolMinutes,
+}
+
+// GetInsights correlates each wellness factor with the stutter score on
+// the same day and on the following day.
+func (uc *StatsUseCase) GetInsights(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*statsdomain.Insights, error) {
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate, err := resolveRange(todayIn(loc), from, to, insightLookbackDays)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyStats, err := uc.statsRepo.GetDailyStatsByRange(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("get daily stats range: %w", err)
+	}
+
+	activity, err := uc.statsRepo.GetToolActivity(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, fmt.Errorf("get tool activity: %w", err)
+	}
+
+	days := make(map[string]*statsdomain.DailyStat, len(dailyStats))
+	for _, stat := range dailyStats {
+		days[dateKey(stat.Date)] = stat
+	}
+	toolMinutes := make(map[string]float64)
+	for _, day := range activity {
+		toolMinutes[dateKey(day.Date)] = float64(day.Seconds) / 60
+	}
+
+	correlations := make([]statsdomain.Correlation, 0, len(insightFactors)*2)
+	for _, lag := range []int{0, 1} {
+		for _, factor := range insightFactors {
+			xs, ys := pairFactorWithScore(factor, lag, fromDate, toDate, days, toolMinutes)
+			correlations = append(correlations, statsdomain.NewCorrelation(factor, lag, xs, ys))
+		}
+	}
+
+	return &statsdomain.Insights{
+		From:         fromDate,
+		To:           toDate,
+		MinSamples:   statsdomain.MinCorrelationSamples,
+		Correlations: correlations,
+	}, nil
+}
+
+// pairFactorWithScore collects the factor on each day in the range next to
+// the stutter score lag days later, skipping days where either is missing.
+func pairFactorWithScore(
+	factor string,
+	lag int,
+	from, to time.Time,
+	days map[string]*statsdomain.DailyStat,
+	toolMinutes map[string]float64,
+) ([]float64, []float64) {
+	xs := make([]float64, 0)
+	ys := make([]float64, 0)
+
+	for day := from; !day.AddDate(0, 0, lag).After(to); day = day.AddDate(0, 0, 1) {
+		scored, ok := days[dateKey(day.AddDate(0, 0, lag))]
+		if !ok || scored.StutterScore == nil {
+			continue
+		}
+		value, ok := factorValue(factor, days[dateKey(day)], toolMinutes[dateKey(day)])
+		if !ok {
+			continue
+		}
+		xs = append(xs, value)
+		ys = append(ys, *scored.StutterScore)
+	}
+	return xs, ys
+}
+
+// factorValue reads a factor from the day's stat. Tool minutes come from
+// sessions instead, so a day without practice counts as zero minutes.
+func factorValue(factor string, stat *statsdomain.DailyStat, toolMinutes float64) (float64, bool) {
+	if factor == statsdomain.FactorToolMinutes {
+		return toolMinutes, true
+	}
+	if stat == nil {
+		return 0, false
+	}
+
+	switch factor {
+	case statsdomain.FactorSleepHours:
+		if stat.SleepHours != nil {
+			return *stat.SleepHours, true
+		}
+	case statsdomain.FactorStressLevel:
+		if stat.StressLevel != nil {
+			return float64(*stat.StressLevel), true
+		}
+	case statsdomain.FactorMindfulHours:
+		if stat.MindfulHours != nil {
+			return *stat.MindfulHours, true
+		}
+	case statsdomain.FactorMood:
+		if stat.Mood != nil {
+			score, ok := statsdomain.MoodScores[*stat.Mood]
+			return score, ok
+		}
+	}
+	return 0, false
+}
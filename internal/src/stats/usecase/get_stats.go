@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"math"
 	"sort"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -17,24 +15,23 @@ import (
 
 const (
 	dateLayout = "2006-01-02"
-)
 
-func (uc *StatsUseCase) GetStats(ctx context.Context, userID uuid.UUID, from, to *time.Time) (*statsdomain.StatsOverview, error) {
-	today := todayUTC()
-	fromDate := today.AddDate(0, 0, -30)
-	toDate := today
+	recentSessionLimit = 10
+)
 
-	if from != nil {
-		fromDate = startOfDayUTC(*from)
-	}
-	if to != nil {
-		toDate = startOfDayUTC(*to)
+func (uc *StatsUseCase) GetStats(ctx context.Context, userID uuid.UUID, from, to *time.Time, compare statsdomain.CompareMode) (*statsdomain.StatsOverview, error) {
+	if !compare.Valid() {
+		return nil, statsdomain.ErrInvalidCompareMode
 	}
-	if fromDate.After(toDate) {
-		return nil, statsdomain.ErrInvalidDateRange
+
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
-	if toDate.After(today) {
-		return nil, statsdomain.ErrFutureDate
+	today := todayIn(loc)
+	fromDate, toDate, err := resolveRange(today, from, to, 30)
+	if err != nil {
+		return nil, err
 	}
 
 	dailyStats, err := uc.statsRepo.GetDailyStatsByRange(ctx, userID, fromDate, toDate)
@@ -50,514 +47,421 @@ func (uc *StatsUseCase) GetStats(ctx context.Context, userID uuid.UUID, from, to
 		todayStat = nil
 	}
 
-	journalDates, err := uc.statsRepo.GetJournalEntryDates(ctx, userID)
+	aggregate, err := uc.statsRepo.GetDailyStatAggregate(ctx, userID, fromDate, toDate)
 	if err != nil {
-		return nil, fmt.Errorf("get journal dates: %w", err)
+		return nil, fmt.Errorf("get daily stat aggregate: %w", err)
 	}
 
-	toolSessions, err := uc.statsRepo.GetToolSessions(ctx, userID)
+	streaks, err := uc.statsRepo.GetStreaks(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("get tool sessions: %w", err)
+		return nil, fmt.Errorf("get streaks: %w", err)
 	}
 
-	return &statsdomain.StatsOverview{
-		DailyStats:      dailyStats,
-		Today:           todayStat,
-		JournalStreak:   calculateJournalStreak(journalDates, today),
-		WellnessSummary: calculateWellnessSummary(dailyStats),
-		StutterSummary:  calculateStutterSummary(dailyStats),
-		ToolStats:       calculateToolStats(toolSessions, today),
-		WeeklyActivity:  calculateWeeklyActivity(toolSessions, today),
-		WeeklyTrend:     calculateWeeklyTrend(toolSessions, today),
-		RecentSessions:  recentSessions(toolSessions, 10),
-	}, nil
-}
-
-func calculateJournalStreak(dates []time.Time, today time.Time) int {
-	dateSet := make(map[string]struct{}, len(dates))
-	for _, date := range dates {
-		dateSet[dateKey(date)] = struct{}{}
+	toolStats, err := uc.getToolStats(ctx, userID, today, streaks)
+	if err != nil {
+		return nil, err
 	}
 
-	streak := 0
-	for day := today; ; day = day.AddDate(0, 0, -1) {
-		if _, ok := dateSet[dateKey(day)]; !ok {
-			return streak
-		}
-		streak++
+	// The weekly trend covers six weeks from 35 days ago, which also holds
+	// the last seven days shown in the weekly activity.
+	trendStart := today.AddDate(0, 0, -35)
+	activity, err := uc.statsRepo.GetToolActivity(ctx, userID, trendStart, trendStart.AddDate(0, 0, 6*7-1))
+	if err != nil {
+		return nil, fmt.Errorf("get tool activity: %w", err)
 	}
-}
 
-func calculateWellnessSummary(stats []*statsdomain.DailyStat) statsdomain.WellnessSummary {
-	sleep := avgCollector{}
-	stress := avgCollector{}
-	mindful := avgCollector{}
-	moods := map[string]int{"Great": 0, "Happy": 0, "Neutral": 0, "Sad": 0, "Dizzy": 0}
-	journalEntries := 0
-	daysTracked := 0
+	recent, err := uc.statsRepo.GetRecentToolSessions(ctx, userID, recentSessionLimit)
+	if err != nil {
+		return nil, fmt.Errorf("get recent tool sessions: %w", err)
+	}
 
-	for _, stat := range stats {
-		if stat.SleepHours != nil {
-			sleep.add(*stat.SleepHours)
-		}
-		if stat.StressLevel != nil {
-			stress.add(float64(*stat.StressLevel))
-		}
-		if stat.MindfulHours != nil {
-			mindful.add(*stat.MindfulHours)
-		}
-		if stat.JournalEntry != nil {
-			journalEntries++
-		}
-		if stat.Mood != nil {
-			moods[*stat.Mood]++
-		}
-		if stat.HasTrackedData() {
-			daysTracked++
+	overview := &statsdomain.StatsOverview{
+		DailyStats:      dailyStats,
+		Today:           todayStat,
+		JournalStreak:   streaks[statsdomain.StreakJournal].At(today).Current,
+		WellnessSummary: calculateWellnessSummary(aggregate),
+		StutterSummary:  calculateStutterSummary(aggregate, dailyStats),
+		ToolStats:       toolStats,
+		WeeklyActivity:  calculateWeeklyActivity(activity, today),
+		WeeklyTrend:     calculateWeeklyTrend(activity, trendStart),
+		RecentSessions:  recent,
+	}
+
+	if compare != statsdomain.CompareNone {
+		comparison, err := uc.comparePeriods(ctx, userID, compare, fromDate, toDate, overview)
+		if err != nil {
+			return nil, err
 		}
+		overview.Comparison = comparison
 	}
 
-	return statsdomain.WellnessSummary{
-		AvgSleepHours:       sleep.avgPtr(),
-		AvgStressLevel:      stress.avgPtr(),
-		AvgMindfulHours:     mindful.avgPtr(),
-		TotalJournalEntries: journalEntries,
-		MoodDistribution:    moods,
-		DaysTracked:         daysTracked,
-	}
+	return overview, nil
 }
 
-func calculateStutterSummary(stats []*statsdomain.DailyStat) statsdomain.StutterSummary {
-	avgScore := avgCollector{}
-	trend := make([]statsdomain.ScoreTrendPoint, 0)
-	var bestScore *float64
-	var worstScore *float64
-	var latestDate time.Time
-	var latestScore *float64
-
-	for _, stat := range stats {
-		if stat.StutterScore == nil {
-			continue
-		}
-
-		score := *stat.StutterScore
-		avgScore.add(score)
-		trend = append(trend, statsdomain.ScoreTrendPoint{Date: stat.Date, Score: round1(score)})
-		if bestScore == nil || score < *bestScore {
-			bestScore = floatPtr(round1(score))
-		}
-		if worstScore == nil || score > *worstScore {
-			worstScore = floatPtr(round1(score))
-		}
-		if latestScore == nil || stat.Date.After(latestDate) {
-			latestDate = stat.Date
-			latestScore = floatPtr(round1(score))
-		}
+func (uc *StatsUseCase) getToolStats(
+	ctx context.Context,
+	userID uuid.UUID,
+	today time.Time,
+	states map[string]statsdomain.StreakState,
+) (statsdomain.ToolStats, error) {
+	totals, err := uc.statsRepo.GetToolTotals(ctx, userID, today.AddDate(0, 0, -6))
+	if err != nil {
+		return statsdomain.ToolStats{}, fmt.Errorf("get tool totals: %w", err)
 	}
 
-	sort.Slice(trend, func(i, j int) bool {
-		return trend[i].Date.Before(trend[j].Date)
-	})
+	labels, err := uc.statsRepo.GetToolLabelCounts(ctx, userID)
+	if err != nil {
+		return statsdomain.ToolStats{}, fmt.Errorf("get tool labels: %w", err)
+	}
 
-	return statsdomain.StutterSummary{
-		AvgScore:      avgScore.avgPtr(),
-		BestScore:     bestScore,
-		WorstScore:    worstScore,
-		TotalAnalyses: avgScore.count,
-		ScoreTrend:    trend,
-		LatestScore:   latestScore,
+	streaks := make(map[string]statsdomain.Streak, len(statsdomain.ToolGroups))
+	for group := range statsdomain.ToolGroups {
+		streaks[group] = states[group].At(today)
 	}
+
+	return calculateToolStats(totals, labels, streaks), nil
 }
 
-func calculateToolStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.ToolStats {
-	dafSessions := filterToolSessions(sessions, "DAF")
-	fafSessions := filterToolSessions(sessions, "FAF")
-	breathingSessions := filterToolSessions(sessions, "BOX_BREATHING", "DIAPHRAGMATIC", "PRE_SPEECH")
-	drillSessions := filterToolSessions(sessions, "GENTLE_ONSET", "PROLONGED_SPEECH")
-	biofeedbackSessions := filterToolSessions(sessions, "STUTTER_TAP_COUNTER", "TIMED_READING_WPM")
-	simulationSessions := filterToolSessions(sessions, "VIRTUAL_COFFEE_ORDER", "PHONE_CALL_SIMULATOR")
+func (uc *StatsUseCase) comparePeriods(
+	ctx context.Context,
+	userID uuid.UUID,
+	compare statsdomain.CompareMode,
+	from, to time.Time,
+	overview *statsdomain.StatsOverview,
+) (*statsdomain.PeriodComparison, error) {
+	prevFrom, prevTo := compare.Window(from, to)
+	prevAggregate, err := uc.statsRepo.GetDailyStatAggregate(ctx, userID, prevFrom, prevTo)
+	if err != nil {
+		return nil, fmt.Errorf("get comparison aggregate: %w", err)
+	}
 
-	return statsdomain.ToolStats{
-		Combined:    calculateCombinedToolStats(sessions, today),
-		DAF:         calculateDAFStats(dafSessions, today),
-		FAF:         calculateFAFStats(fafSessions, today),
-		Breathing:   calculateBreathingStats(breathingSessions, today),
-		Drills:      calculateDrillStats(drillSessions, today),
-		Biofeedback: calculateBiofeedbackStats(biofeedbackSessions, today),
-		Simulation:  calculateSimulationStats(simulationSessions, today),
+	activity, err := uc.statsRepo.GetToolActivity(ctx, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("get tool activity: %w", err)
+	}
+	prevActivity, err := uc.statsRepo.GetToolActivity(ctx, userID, prevFrom, prevTo)
+	if err != nil {
+		return nil, fmt.Errorf("get comparison tool activity: %w", err)
 	}
+
+	current := calculatePeriodMetrics(overview.WellnessSummary, overview.StutterSummary, activity)
+	previous := calculatePeriodMetrics(calculateWellnessSummary(prevAggregate), calculateStutterSummary(prevAggregate, nil), prevActivity)
+	return &statsdomain.PeriodComparison{
+		Mode:    compare,
+		From:    prevFrom,
+		To:      prevTo,
+		Metrics: statsdomain.ComparePeriods(current, previous),
+	}, nil
 }
 
-func calculateCombinedToolStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.CombinedToolStats {
-	dates := sessionDates(sessions)
-	var lastSessionAt *time.Time
-	if len(sessions) > 0 {
-		latest := sessions[0].StartedAt
-		lastSessionAt = &latest
-	}
+// resolveRange defaults to the lookbackDays before today and rejects
+// inverted or future ranges.
+func resolveRange(today time.Time, from, to *time.Time, lookbackDays int) (time.Time, time.Time, error) {
+	fromDate := today.AddDate(0, 0, -lookbackDays)
+	toDate := today
 
-	return statsdomain.CombinedToolStats{
-		TotalSessions: len(sessions),
-		TotalMinutes:  totalMinutes(sessions),
-		CurrentStreak: currentStreak(dates, today),
-		BestStreak:    bestStreak(dates),
-		ActiveDays:    len(dates),
-		LastSessionAt: lastSessionAt,
+	if from != nil {
+		fromDate = startOfDayUTC(*from)
 	}
+	if to != nil {
+		toDate = startOfDayUTC(*to)
+	}
+	if fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, statsdomain.ErrInvalidDateRange
+	}
+	if toDate.After(today) {
+		return time.Time{}, time.Time{}, statsdomain.ErrFutureDate
+	}
+	return fromDate, toDate, nil
 }
 
-func calculateDAFStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.DAFToolStats {
-	delay := avgCollector{}
-	for _, session := range sessions {
-		if value, ok := metadataNumber(session.Metadata, "delay_ms"); ok {
-			delay.add(value)
-		}
+func calculateWellnessSummary(agg *statsdomain.DailyStatAggregate) statsdomain.WellnessSummary {
+	moods := map[string]int{"Great": 0, "Happy": 0, "Neutral": 0, "Sad": 0, "Dizzy": 0}
+	for mood, count := range agg.MoodCounts {
+		moods[mood] += count
 	}
 
-	return statsdomain.DAFToolStats{
-		TotalSessions:    len(sessions),
-		TotalMinutes:     totalMinutes(sessions),
-		AvgRating:        averageRating(sessions),
-		AvgDelayMS:       delay.avgPtr(),
-		SessionsThisWeek: sessionsThisWeek(sessions),
-		BestStreak:       bestStreak(sessionDates(sessions)),
+	return statsdomain.WellnessSummary{
+		AvgSleepHours:       averageOf(agg.SleepHours),
+		AvgStressLevel:      averageOf(agg.StressLevel),
+		AvgMindfulHours:     averageOf(agg.MindfulHours),
+		TotalJournalEntries: agg.JournalEntries,
+		MoodDistribution:    moods,
+		DaysTracked:         agg.DaysTracked,
 	}
 }
 
-func calculateFAFStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.FAFToolStats {
-	semitones := avgCollector{}
-	directions := map[string]int{}
-	for _, session := range sessions {
-		if value, ok := metadataNumber(session.Metadata, "pitch_semitones"); ok {
-			semitones.add(value)
-		}
-		if direction, ok := metadataString(session.Metadata, "pitch_direction"); ok {
-			directions[direction]++
+// calculateStutterSummary takes the aggregates from Postgres and the score
+// trend and speech summary from the range's rows, which the overview
+// returns anyway.
+func calculateStutterSummary(agg *statsdomain.DailyStatAggregate, stats []*statsdomain.DailyStat) statsdomain.StutterSummary {
+	trend := make([]statsdomain.ScoreTrendPoint, 0)
+	for _, stat := range stats {
+		if stat.StutterScore != nil {
+			trend = append(trend, statsdomain.ScoreTrendPoint{Date: stat.Date, Score: round1(*stat.StutterScore)})
 		}
 	}
+	sort.Slice(trend, func(i, j int) bool {
+		return trend[i].Date.Before(trend[j].Date)
+	})
 
-	return statsdomain.FAFToolStats{
-		TotalSessions:      len(sessions),
-		TotalMinutes:       totalMinutes(sessions),
-		AvgRating:          averageRating(sessions),
-		PreferredDirection: modeStringPtr(directions),
-		AvgSemitones:       semitones.avgPtr(),
-		SessionsThisWeek:   sessionsThisWeek(sessions),
-		BestStreak:         bestStreak(sessionDates(sessions)),
+	return statsdomain.StutterSummary{
+		AvgScore:      averageOf(agg.StutterScore),
+		BestScore:     round1Ptr(agg.BestScore),
+		WorstScore:    round1Ptr(agg.WorstScore),
+		TotalAnalyses: agg.StutterScore.Count,
+		ScoreTrend:    trend,
+		LatestScore:   round1Ptr(agg.LatestScore),
+		Speech:        calculateSpeechSummary(stats),
 	}
 }
 
-func calculateBreathingStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.BreathingToolStats {
-	situationBreakdown := map[string]int{}
-	stats := statsdomain.BreathingToolStats{SituationBreakdown: situationBreakdown}
-
-	for _, session := range sessions {
-		switch session.ToolType {
-		case "BOX_BREATHING":
-			stats.BoxBreathingSessions++
-		case "DIAPHRAGMATIC":
-			stats.DiaphragmaticSessions++
-		case "PRE_SPEECH":
-			stats.PreSpeechSessions++
-			if situation, ok := metadataString(session.Metadata, "situation"); ok {
-				situationBreakdown[situation]++
-			}
-		}
-	}
+func calculateSpeechSummary(stats []*statsdomain.DailyStat) statsdomain.SpeechSummary {
+	ratio := avgCollector{}
+	articulation := avgCollector{}
+	pausesPerMinute := avgCollector{}
+	meanPause := avgCollector{}
+	var summary statsdomain.SpeechSummary
 
-	stats.TotalSessions = len(sessions)
-	stats.TotalMinutes = totalMinutes(sessions)
-	stats.AvgRating = averageRating(sessions)
-	stats.CurrentStreak = currentStreak(sessionDates(sessions), today)
-	return stats
-}
+	for _, stat := range stats {
+		m := stat.SpeechMetrics
+		if m == nil {
+			continue
+		}
 
-func calculateDrillStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.DrillToolStats {
-	gentleScore := avgCollector{}
-	prolongedWPM := avgCollector{}
-	stats := statsdomain.DrillToolStats{}
-
-	for _, session := range sessions {
-		switch session.ToolType {
-		case "GENTLE_ONSET":
-			stats.GentleOnsetSessions++
-			if value, ok := metadataNumber(session.Metadata, "average_score"); ok {
-				gentleScore.add(value)
-			}
-		case "PROLONGED_SPEECH":
-			stats.ProlongedSpeechSessions++
-			if value, ok := metadataNumber(session.Metadata, "estimated_wpm"); ok {
-				prolongedWPM.add(value)
-			}
+		summary.AnalyzedDays++
+		ratio.add(m.SpeechRatio())
+		pausesPerMinute.add(m.PausesPerMinute())
+		if m.PauseCount > 0 {
+			meanPause.add(m.MeanPauseSeconds)
 		}
+		if rate := m.ArticulationRate(stat.TotalWords); rate != nil {
+			articulation.add(*rate)
+		}
+		if summary.LongestPauseSeconds == nil || m.LongestPauseSeconds > *summary.LongestPauseSeconds {
+			summary.LongestPauseSeconds = floatPtr(m.LongestPauseSeconds)
+		}
+		if summary.LongestSpeechSeconds == nil || m.LongestSpeechSeconds > *summary.LongestSpeechSeconds {
+			summary.LongestSpeechSeconds = floatPtr(m.LongestSpeechSeconds)
+		}
+		summary.ShortPauses += m.ShortPauses
+		summary.MediumPauses += m.MediumPauses
+		summary.LongPauses += m.LongPauses
+	}
+
+	// Ratios are 0-1, so they keep more precision than avgPtr's one decimal.
+	if ratio.count > 0 {
+		summary.AvgSpeechRatio = floatPtr(math.Round(ratio.sum/float64(ratio.count)*1000) / 1000)
+	}
+	summary.AvgArticulationRate = articulation.avgPtr()
+	summary.AvgPausesPerMinute = pausesPerMinute.avgPtr()
+	summary.AvgMeanPauseSeconds = meanPause.avgPtr()
+	return summary
+}
+
+// calculatePeriodMetrics reduces a range's summaries and tool activity to
+// the comparable metrics.
+func calculatePeriodMetrics(
+	wellness statsdomain.WellnessSummary,
+	stutter statsdomain.StutterSummary,
+	activity []statsdomain.ToolActivityDay,
+) statsdomain.PeriodMetrics {
+	mood := avgCollector{}
+	for value, count := range wellness.MoodDistribution {
+		mood.sum += statsdomain.MoodScores[value] * float64(count)
+		mood.count += count
+	}
+
+	sessions, seconds := 0, 0
+	for _, day := range activity {
+		sessions += day.Sessions
+		seconds += day.Seconds
+	}
+
+	return statsdomain.PeriodMetrics{
+		AvgSleepHours:   wellness.AvgSleepHours,
+		AvgStressLevel:  wellness.AvgStressLevel,
+		AvgMindfulHours: wellness.AvgMindfulHours,
+		AvgMood:         mood.avgPtr(),
+		DaysTracked:     wellness.DaysTracked,
+		JournalEntries:  wellness.TotalJournalEntries,
+		AvgStutterScore: stutter.AvgScore,
+		StutterAnalyses: stutter.TotalAnalyses,
+		ToolSessions:    sessions,
+		ToolMinutes:     minutesOf(seconds),
+		ActiveToolDays:  len(activity),
+	}
+}
+
+func calculateToolStats(
+	totals []statsdomain.ToolTypeTotals,
+	labels []statsdomain.ToolLabelCount,
+	streaks map[string]statsdomain.Streak,
+) statsdomain.ToolStats {
+	byType := make(map[string]statsdomain.ToolTypeTotals, len(totals))
+	for _, t := range totals {
+		byType[t.ToolType] = t
+	}
+
+	labelCounts := make(map[string]map[string]int)
+	for _, l := range labels {
+		if labelCounts[l.ToolType] == nil {
+			labelCounts[l.ToolType] = make(map[string]int)
+		}
+		labelCounts[l.ToolType][l.Label] += l.Count
 	}
 
-	stats.TotalSessions = len(sessions)
-	stats.TotalMinutes = totalMinutes(sessions)
-	stats.AvgRating = averageRating(sessions)
-	stats.AvgGentleScore = gentleScore.avgPtr()
-	stats.AvgProlongedWPM = prolongedWPM.avgPtr()
-	stats.CurrentStreak = currentStreak(sessionDates(sessions), today)
-	return stats
-}
-
-func calculateBiofeedbackStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.BiofeedbackToolStats {
-	stuttersPerMin := avgCollector{}
-	readingWPM := avgCollector{}
-	stats := statsdomain.BiofeedbackToolStats{}
-
-	for _, session := range sessions {
-		switch session.ToolType {
-		case "STUTTER_TAP_COUNTER":
-			stats.StutterTapSessions++
-			if taps, ok := metadataNumber(session.Metadata, "total_taps"); ok && session.DurationSeconds > 0 {
-				stuttersPerMin.add(taps / (float64(session.DurationSeconds) / 60))
-			}
-		case "TIMED_READING_WPM":
-			stats.TimedReadingSessions++
-			if value, ok := metadataNumber(session.Metadata, "actual_wpm"); ok {
-				readingWPM.add(value)
-			}
+	combined := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupCombined]...)
+	var lastSessionAt *time.Time
+	for _, t := range totals {
+		if lastSessionAt == nil || t.LastStartedAt.After(*lastSessionAt) {
+			latest := t.LastStartedAt
+			lastSessionAt = &latest
 		}
 	}
 
-	stats.TotalSessions = len(sessions)
-	stats.TotalMinutes = totalMinutes(sessions)
-	stats.AvgRating = averageRating(sessions)
-	stats.AvgStuttersPerMin = stuttersPerMin.avgPtr()
-	stats.AvgReadingWPM = readingWPM.avgPtr()
-	stats.CurrentStreak = currentStreak(sessionDates(sessions), today)
-	return stats
-}
+	daf := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupDAF]...)
+	faf := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupFAF]...)
+	breathing := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupBreathing]...)
+	drills := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupDrills]...)
+	biofeedback := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupBiofeedback]...)
+	simulation := sumToolTotals(byType, statsdomain.ToolGroups[statsdomain.ToolGroupSimulation]...)
 
-func calculateSimulationStats(sessions []*statsdomain.ToolSession, today time.Time) statsdomain.SimulationToolStats {
-	completionScore := avgCollector{}
-	stats := statsdomain.SimulationToolStats{}
+	situations := labelCounts[statsdomain.ToolPreSpeech]
+	if situations == nil {
+		situations = map[string]int{}
+	}
 
-	for _, session := range sessions {
-		switch session.ToolType {
-		case "VIRTUAL_COFFEE_ORDER":
-			stats.CoffeeSessions++
-		case "PHONE_CALL_SIMULATOR":
-			stats.CallSessions++
-		}
-		if completed, ok := metadataBool(session.Metadata, "completed"); ok {
-			if completed {
-				completionScore.add(100)
-			} else {
-				completionScore.add(0)
-			}
+	return statsdomain.ToolStats{
+		Combined: statsdomain.CombinedToolStats{
+			TotalSessions: combined.sessions,
+			TotalMinutes:  minutesOf(combined.seconds),
+			CurrentStreak: streaks[statsdomain.ToolGroupCombined].Current,
+			BestStreak:    streaks[statsdomain.ToolGroupCombined].Best,
+			ActiveDays:    streaks[statsdomain.ToolGroupCombined].ActiveDays,
+			LastSessionAt: lastSessionAt,
+		},
+		DAF: statsdomain.DAFToolStats{
+			TotalSessions:    daf.sessions,
+			TotalMinutes:     minutesOf(daf.seconds),
+			AvgRating:        daf.rating.avgPtr(),
+			AvgDelayMS:       daf.metric.avgPtr(),
+			SessionsThisWeek: daf.thisWeek,
+			BestStreak:       streaks[statsdomain.ToolGroupDAF].Best,
+		},
+		FAF: statsdomain.FAFToolStats{
+			TotalSessions:      faf.sessions,
+			TotalMinutes:       minutesOf(faf.seconds),
+			AvgRating:          faf.rating.avgPtr(),
+			PreferredDirection: modeStringPtr(labelCounts[statsdomain.ToolFAF]),
+			AvgSemitones:       faf.metric.avgPtr(),
+			SessionsThisWeek:   faf.thisWeek,
+			BestStreak:         streaks[statsdomain.ToolGroupFAF].Best,
+		},
+		Breathing: statsdomain.BreathingToolStats{
+			TotalSessions:         breathing.sessions,
+			TotalMinutes:          minutesOf(breathing.seconds),
+			AvgRating:             breathing.rating.avgPtr(),
+			BoxBreathingSessions:  byType[statsdomain.ToolBoxBreathing].Sessions,
+			DiaphragmaticSessions: byType[statsdomain.ToolDiaphragmatic].Sessions,
+			PreSpeechSessions:     byType[statsdomain.ToolPreSpeech].Sessions,
+			SituationBreakdown:    situations,
+			CurrentStreak:         streaks[statsdomain.ToolGroupBreathing].Current,
+		},
+		Drills: statsdomain.DrillToolStats{
+			TotalSessions:           drills.sessions,
+			TotalMinutes:            minutesOf(drills.seconds),
+			AvgRating:               drills.rating.avgPtr(),
+			GentleOnsetSessions:     byType[statsdomain.ToolGentleOnset].Sessions,
+			ProlongedSpeechSessions: byType[statsdomain.ToolProlongedSpeech].Sessions,
+			AvgGentleScore:          averageOf(byType[statsdomain.ToolGentleOnset].Metric),
+			AvgProlongedWPM:         averageOf(byType[statsdomain.ToolProlongedSpeech].Metric),
+			CurrentStreak:           streaks[statsdomain.ToolGroupDrills].Current,
+		},
+		Biofeedback: statsdomain.BiofeedbackToolStats{
+			TotalSessions:        biofeedback.sessions,
+			TotalMinutes:         minutesOf(biofeedback.seconds),
+			AvgRating:            biofeedback.rating.avgPtr(),
+			StutterTapSessions:   byType[statsdomain.ToolStutterTapCounter].Sessions,
+			TimedReadingSessions: byType[statsdomain.ToolTimedReadingWPM].Sessions,
+			AvgStuttersPerMin:    averageOf(byType[statsdomain.ToolStutterTapCounter].Metric),
+			AvgReadingWPM:        averageOf(byType[statsdomain.ToolTimedReadingWPM].Metric),
+			CurrentStreak:        streaks[statsdomain.ToolGroupBiofeedback].Current,
+		},
+		Simulation: statsdomain.SimulationToolStats{
+			TotalSessions:      simulation.sessions,
+			TotalMinutes:       minutesOf(simulation.seconds),
+			AvgRating:          simulation.rating.avgPtr(),
+			CoffeeSessions:     byType[statsdomain.ToolVirtualCoffeeOrder].Sessions,
+			CallSessions:       byType[statsdomain.ToolPhoneCallSimulator].Sessions,
+			AvgCompletionScore: simulation.metric.avgPtr(),
+			CurrentStreak:      streaks[statsdomain.ToolGroupSimulation].Current,
+		},
+	}
+}
+
+type toolTotals struct {
+	sessions int
+	seconds  int
+	thisWeek int
+	rating   avgCollector
+	metric   avgCollector
+}
+
+func sumToolTotals(byType map[string]statsdomain.ToolTypeTotals, toolTypes ...string) toolTotals {
+	var sum toolTotals
+	for _, toolType := range toolTypes {
+		t, ok := byType[toolType]
+		if !ok {
+			continue
 		}
+		sum.sessions += t.Sessions
+		sum.seconds += t.Seconds
+		sum.thisWeek += t.SessionsThisWeek
+		sum.rating.addTotal(t.Rating)
+		sum.metric.addTotal(t.Metric)
 	}
-
-	stats.TotalSessions = len(sessions)
-	stats.TotalMinutes = totalMinutes(sessions)
-	stats.AvgRating = averageRating(sessions)
-	stats.AvgCompletionScore = completionScore.avgPtr()
-	stats.CurrentStreak = currentStreak(sessionDates(sessions), today)
-	return stats
+	return sum
 }
 
-func calculateWeeklyActivity(sessions []*statsdomain.ToolSession, today time.Time) []statsdomain.WeeklyActivityDay {
-	counts := make(map[string]int, 7)
-	for _, session := range sessions {
-		key := dateKey(session.StartedAt)
-		counts[key]++
+func calculateWeeklyActivity(activity []statsdomain.ToolActivityDay, today time.Time) []statsdomain.WeeklyActivityDay {
+	counts := make(map[string]int, len(activity))
+	for _, day := range activity {
+		counts[dateKey(day.Date)] = day.Sessions
 	}
 
-	activity := make([]statsdomain.WeeklyActivityDay, 0, 7)
+	days := make([]statsdomain.WeeklyActivityDay, 0, 7)
 	start := today.AddDate(0, 0, -6)
 	for i := 0; i < 7; i++ {
 		date := start.AddDate(0, 0, i)
-		activity = append(activity, statsdomain.WeeklyActivityDay{
+		days = append(days, statsdomain.WeeklyActivityDay{
 			Date:     date,
 			Day:      weekdayLabel(date),
 			Sessions: counts[dateKey(date)],
 		})
 	}
-	return activity
+	return days
 }
 
-func calculateWeeklyTrend(sessions []*statsdomain.ToolSession, today time.Time) []statsdomain.WeeklyTrendWeek {
+func calculateWeeklyTrend(activity []statsdomain.ToolActivityDay, start time.Time) []statsdomain.WeeklyTrendWeek {
 	trend := make([]statsdomain.WeeklyTrendWeek, 0, 6)
-	start := today.AddDate(0, 0, -35)
-
 	for week := 0; week < 6; week++ {
 		weekStart := start.AddDate(0, 0, week*7)
 		weekEnd := weekStart.AddDate(0, 0, 7)
-		minutes := 0.0
-		for _, session := range sessions {
-			sessionDate := startOfDayUTC(session.StartedAt)
-			if !sessionDate.Before(weekStart) && sessionDate.Before(weekEnd) {
-				minutes += float64(session.DurationSeconds) / 60
+		seconds := 0
+		for _, day := range activity {
+			if !day.Date.Before(weekStart) && day.Date.Before(weekEnd) {
+				seconds += day.Seconds
 			}
 		}
 		trend = append(trend, statsdomain.WeeklyTrendWeek{
 			WeekLabel:    fmt.Sprintf("W%d", week+1),
-			TotalMinutes: round1(minutes),
+			TotalMinutes: minutesOf(seconds),
 		})
 	}
 	return trend
 }
 
-func recentSessions(sessions []*statsdomain.ToolSession, limit int) []*statsdomain.ToolSession {
-	if len(sessions) <= limit {
-		return sessions
-	}
-	return sessions[:limit]
-}
-
-func filterToolSessions(sessions []*statsdomain.ToolSession, toolTypes ...string) []*statsdomain.ToolSession {
-	allowed := make(map[string]struct{}, len(toolTypes))
-	for _, toolType := range toolTypes {
-		allowed[toolType] = struct{}{}
-	}
-
-	filtered := make([]*statsdomain.ToolSession, 0)
-	for _, session := range sessions {
-		if _, ok := allowed[session.ToolType]; ok {
-			filtered = append(filtered, session)
-		}
-	}
-	return filtered
-}
-
-func totalMinutes(sessions []*statsdomain.ToolSession) float64 {
-	seconds := 0
-	for _, session := range sessions {
-		seconds += session.DurationSeconds
-	}
-	return round1(float64(seconds) / 60)
-}
-
-func averageRating(sessions []*statsdomain.ToolSession) *float64 {
-	avg := avgCollector{}
-	for _, session := range sessions {
-		if session.SelfRating != nil {
-			avg.add(float64(*session.SelfRating))
-		}
-	}
-	return avg.avgPtr()
-}
-
-func sessionsThisWeek(sessions []*statsdomain.ToolSession) int {
-	cutoff := time.Now().UTC().AddDate(0, 0, -7)
-	count := 0
-	for _, session := range sessions {
-		if !session.StartedAt.Before(cutoff) {
-			count++
-		}
-	}
-	return count
-}
-
-func sessionDates(sessions []*statsdomain.ToolSession) map[string]time.Time {
-	dates := make(map[string]time.Time)
-	for _, session := range sessions {
-		date := startOfDayUTC(session.StartedAt)
-		dates[dateKey(date)] = date
-	}
-	return dates
-}
-
-func currentStreak(dates map[string]time.Time, today time.Time) int {
-	streak := 0
-	for date := today; ; date = date.AddDate(0, 0, -1) {
-		if _, ok := dates[dateKey(date)]; !ok {
-			return streak
-		}
-		streak++
-	}
-}
-
-func bestStreak(dates map[string]time.Time) int {
-	if len(dates) == 0 {
-		return 0
-	}
-
-	ordered := make([]time.Time, 0, len(dates))
-	for _, date := range dates {
-		ordered = append(ordered, date)
-	}
-	sort.Slice(ordered, func(i, j int) bool {
-		return ordered[i].Before(ordered[j])
-	})
-
-	best := 1
-	current := 1
-	for i := 1; i < len(ordered); i++ {
-		if ordered[i].Equal(ordered[i-1].AddDate(0, 0, 1)) {
-			current++
-		} else {
-			current = 1
-		}
-		if current > best {
-			best = current
-		}
-	}
-	return best
-}
-
-func metadataNumber(metadata map[string]any, key string) (float64, bool) {
-	value, ok := metadata[key]
-	if !ok || value == nil {
-		return 0, false
-	}
-
-	switch v := value.(type) {
-	case float64:
-		return v, true
-	case float32:
-		return float64(v), true
-	case int:
-		return float64(v), true
-	case int64:
-		return float64(v), true
-	case int32:
-		return float64(v), true
-	case string:
-		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
-		if err == nil {
-			return parsed, true
-		}
-	}
-	return 0, false
-}
-
-func metadataString(metadata map[string]any, key string) (string, bool) {
-	value, ok := metadata[key]
-	if !ok || value == nil {
-		return "", false
-	}
-	text, ok := value.(string)
-	if !ok {
-		return "", false
-	}
-	text = strings.TrimSpace(text)
-	return text, text != ""
-}
-
-func metadataBool(metadata map[string]any, key string) (bool, bool) {
-	value, ok := metadata[key]
-	if !ok || value == nil {
-		return false, false
-	}
-
-	switch v := value.(type) {
-	case bool:
-		return v, true
-	case string:
-		lower := strings.ToLower(strings.TrimSpace(v))
-		if lower == "true" {
-			return true, true
-		}
-		if lower == "false" {
-			return false, true
-		}
-	}
-	return false, false
-}
-
 func modeStringPtr(counts map[string]int) *string {
 	var mode string
 	bestCount := 0
@@ -583,6 +487,11 @@ func (a *avgCollector) add(value float64) {
 	a.count++
 }
 
+func (a *avgCollector) addTotal(total statsdomain.Total) {
+	a.sum += total.Sum
+	a.count += total.Count
+}
+
 func (a avgCollector) avgPtr() *float64 {
 	if a.count == 0 {
 		return nil
@@ -590,6 +499,14 @@ func (a avgCollector) avgPtr() *float64 {
 	return floatPtr(round1(a.sum / float64(a.count)))
 }
 
+func averageOf(total statsdomain.Total) *float64 {
+	return avgCollector{sum: total.Sum, count: total.Count}.avgPtr()
+}
+
+func minutesOf(seconds int) float64 {
+	return round1(float64(seconds) / 60)
+}
+
 func floatPtr(value float64) *float64 {
 	return &value
 }
@@ -598,6 +515,13 @@ func round1(value float64) float64 {
 	return math.Round(value*10) / 10
 }
 
+func round1Ptr(value *float64) *float64 {
+	if value == nil {
+		return nil
+	}
+	return floatPtr(round1(*value))
+}
+
 func dateKey(date time.Time) string {
 	return startOfDayUTC(date).Format(dateLayout)
 }
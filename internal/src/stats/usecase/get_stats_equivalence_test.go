@@ -0,0 +1,773 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	statsrepo "saythis-backend/internal/src/stats/repository"
+	userrepo "saythis-backend/internal/src/user/repository"
+)
+
+// The overview's aggregates moved from Go into Postgres. This test seeds a
+// user and checks GetStats against the Go calculations it replaced, which
+// are kept below as references. It needs a migrated database, so it only
+// runs when TEST_DATABASE_URL is set.
+//
+// Fixture values are whole numbers or halves so both sides sum them
+// exactly; for arbitrary decimals Postgres's NUMERIC sums can differ from
+// float addition in the last bit and flip a rounding.
+func TestGetStats_MatchesGoCalculations(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	db, err := pgxpool.New(ctx, databaseURL)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer db.Close()
+
+	const timezone = "America/Los_Angeles"
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	userID := uuid.New()
+	if _, err := db.Exec(ctx,
+		`INSERT INTO users (id, email, full_name, timezone) VALUES ($1, $2, 'Stats Equivalence', $3)`,
+		userID, fmt.Sprintf("stats-%s@example.test", userID), timezone,
+	); err != nil {
+		t.Fatalf("insert user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, userID)
+	})
+
+	repo := statsrepo.NewPostgresStatsRepo(db)
+	uc := NewStatsUseCase(repo, userrepo.NewPostgresUserRepo(db), statsdomain.NewTranscriptAnalyzer(nil))
+	today := todayIn(loc)
+	rng := rand.New(rand.NewSource(7))
+
+	journalDates := seedDailyStats(ctx, t, repo, userID, today, rng)
+	sessions := seedToolSessions(ctx, t, repo, userID, today, loc, rng)
+
+	from := today.AddDate(0, 0, -30)
+	got, err := uc.GetStats(ctx, userID, nil, nil, statsdomain.ComparePrevious)
+	if err != nil {
+		t.Fatalf("GetStats: %v", err)
+	}
+	dailyStats, err := repo.GetDailyStatsByRange(ctx, userID, from, today)
+	if err != nil {
+		t.Fatalf("GetDailyStatsByRange: %v", err)
+	}
+
+	if want := referenceJournalStreak(journalDates, today); got.JournalStreak != want {
+		t.Errorf("JournalStreak = %d, want %d", got.JournalStreak, want)
+	}
+	wantWellness := referenceWellnessSummary(dailyStats)
+	if !reflect.DeepEqual(got.WellnessSummary, wantWellness) {
+		t.Errorf("WellnessSummary =\n%+v\nwant\n%+v", got.WellnessSummary, wantWellness)
+	}
+	wantStutter := referenceStutterSummary(dailyStats)
+	if !reflect.DeepEqual(got.StutterSummary, wantStutter) {
+		t.Errorf("StutterSummary =\n%+v\nwant\n%+v", got.StutterSummary, wantStutter)
+	}
+
+	wantTools := referenceToolStats(sessions, today, loc)
+	gotLast, wantLast := got.ToolStats.Combined.LastSessionAt, wantTools.Combined.LastSessionAt
+	if gotLast == nil || wantLast == nil || !gotLast.Equal(*wantLast) {
+		t.Errorf("LastSessionAt = %v, want %v", gotLast, wantLast)
+	}
+	got.ToolStats.Combined.LastSessionAt, wantTools.Combined.LastSessionAt = nil, nil
+	if !reflect.DeepEqual(got.ToolStats, wantTools) {
+		t.Errorf("ToolStats =\n%+v\nwant\n%+v", got.ToolStats, wantTools)
+	}
+
+	if want := referenceWeeklyActivity(sessions, today, loc); !reflect.DeepEqual(got.WeeklyActivity, want) {
+		t.Errorf("WeeklyActivity = %+v, want %+v", got.WeeklyActivity, want)
+	}
+	if want := referenceWeeklyTrend(sessions, today, loc); !reflect.DeepEqual(got.WeeklyTrend, want) {
+		t.Errorf("WeeklyTrend = %+v, want %+v", got.WeeklyTrend, want)
+	}
+
+	if len(got.RecentSessions) != recentSessionLimit {
+		t.Fatalf("RecentSessions has %d sessions, want %d", len(got.RecentSessions), recentSessionLimit)
+	}
+	for i, session := range got.RecentSessions {
+		if !session.StartedAt.Equal(sessions[i].StartedAt) {
+			t.Errorf("RecentSessions[%d] started at %v, want %v", i, session.StartedAt, sessions[i].StartedAt)
+		}
+	}
+
+	prevFrom, prevTo := statsdomain.ComparePrevious.Window(from, today)
+	prevStats, err := repo.GetDailyStatsByRange(ctx, userID, prevFrom, prevTo)
+	if err != nil {
+		t.Fatalf("GetDailyStatsByRange: %v", err)
+	}
+	wantComparison := statsdomain.ComparePeriods(
+		referencePeriodMetrics(wantWellness, wantStutter, sessions, from, today, loc),
+		referencePeriodMetrics(referenceWellnessSummary(prevStats), referenceStutterSummary(prevStats), sessions, prevFrom, prevTo, loc),
+	)
+	if got.Comparison == nil || !reflect.DeepEqual(got.Comparison.Metrics, wantComparison) {
+		t.Errorf("Comparison = %+v, want metrics %+v", got.Comparison, wantComparison)
+	}
+}
+
+// seedDailyStats logs about two months of daily stats with gaps, plus a
+// journal run ending today, and returns the journaled dates.
+func seedDailyStats(ctx context.Context, t *testing.T, repo *statsrepo.PostgresStatsRepo, userID uuid.UUID, today time.Time, rng *rand.Rand) []time.Time {
+	t.Helper()
+	moods := []string{"Great", "Happy", "Neutral", "Sad", "Dizzy"}
+	journalDates := make([]time.Time, 0)
+
+	for offset := 0; offset < 70; offset++ {
+		if rng.Intn(5) == 0 {
+			continue
+		}
+		date := today.AddDate(0, 0, -offset)
+		patch := statsdomain.DailyStatPatch{Date: date}
+		if rng.Intn(4) > 0 {
+			patch.Mood = present(moods[rng.Intn(len(moods))])
+		}
+		if rng.Intn(3) > 0 {
+			patch.SleepHours = present(float64(rng.Intn(21)+4) / 2)
+		}
+		if rng.Intn(3) > 0 {
+			patch.StressLevel = present(rng.Intn(5) + 1)
+		}
+		if rng.Intn(2) > 0 {
+			patch.MindfulHours = present(float64(rng.Intn(9)) / 2)
+		}
+		if rng.Intn(3) > 0 {
+			patch.StutterScore = present(float64(rng.Intn(60)) / 2)
+			patch.StutterCount = present(rng.Intn(20))
+			patch.RepetitionCount = present(rng.Intn(10))
+			patch.FillerCount = present(rng.Intn(10))
+			patch.TotalWords = present(rng.Intn(200) + 50)
+		}
+		if offset < 6 || rng.Intn(3) == 0 {
+			patch.JournalEntry = present("entry " + strconv.Itoa(offset))
+			journalDates = append(journalDates, date)
+		}
+		if _, err := repo.UpsertDailyStat(ctx, userID, patch); err != nil {
+			t.Fatalf("seed daily stat: %v", err)
+		}
+	}
+	return journalDates
+}
+
+// seedToolSessions logs sessions of every tool across two months, at all
+// hours so some fall on a different civil date than in UTC, with metadata
+// in the shapes older clients sent. It returns them newest first.
+func seedToolSessions(ctx context.Context, t *testing.T, repo *statsrepo.PostgresStatsRepo, userID uuid.UUID, today time.Time, loc *time.Location, rng *rand.Rand) []*statsdomain.ToolSession {
+	t.Helper()
+	toolTypes := statsdomain.ToolTypes()
+	sessions := make([]*statsdomain.ToolSession, 0)
+
+	for offset := 0; offset < 60; offset++ {
+		if offset > 3 && rng.Intn(4) == 0 {
+			continue
+		}
+		day := today.AddDate(0, 0, -offset)
+		for n := rng.Intn(3) + 1; n > 0; n-- {
+			toolType := toolTypes[rng.Intn(len(toolTypes))]
+			minutes := rng.Intn(20) + 1
+			startedAt := time.Date(day.Year(), day.Month(), day.Day(), rng.Intn(24), rng.Intn(60), 0, 0, loc)
+			if startedAt.After(time.Now()) {
+				startedAt = time.Now().Add(-time.Duration(n) * time.Minute)
+			}
+			session := &statsdomain.ToolSession{
+				ID:              uuid.New(),
+				UserID:          userID,
+				ToolType:        toolType,
+				StartedAt:       startedAt,
+				DurationSeconds: minutes * 60,
+				Metadata:        seedMetadata(toolType, minutes, rng),
+			}
+			if rng.Intn(3) > 0 {
+				session.SelfRating = present(rng.Intn(5) + 1).Value
+			}
+			sessions = append(sessions, session)
+		}
+	}
+
+	created, err := repo.CreateToolSessions(ctx, sessions)
+	if err != nil {
+		t.Fatalf("seed tool sessions: %v", err)
+	}
+	sort.SliceStable(created, func(i, j int) bool {
+		return created[i].StartedAt.After(created[j].StartedAt)
+	})
+	return created
+}
+
+func seedMetadata(toolType string, minutes int, rng *rand.Rand) map[string]any {
+	// Numbers sometimes arrive as padded strings and booleans as text.
+	number := func(value int) any {
+		if rng.Intn(4) == 0 {
+			return " " + strconv.Itoa(value) + " "
+		}
+		return float64(value)
+	}
+	switch toolType {
+	case statsdomain.ToolDAF:
+		return map[string]any{statsdomain.MetaDelayMS: number(rng.Intn(300) + 50)}
+	case statsdomain.ToolFAF:
+		directions := []any{"up", "down", " up ", "", nil}
+		return map[string]any{
+			statsdomain.MetaPitchSemitones: number(rng.Intn(25) - 12),
+			statsdomain.MetaPitchDirection: directions[rng.Intn(len(directions))],
+		}
+	case statsdomain.ToolPreSpeech:
+		situations := []any{"phone call", "meeting", "ordering", 42}
+		return map[string]any{statsdomain.MetaSituation: situations[rng.Intn(len(situations))]}
+	case statsdomain.ToolGentleOnset:
+		return map[string]any{statsdomain.MetaAverageScore: number(rng.Intn(101))}
+	case statsdomain.ToolProlongedSpeech:
+		return map[string]any{statsdomain.MetaEstimatedWPM: number(rng.Intn(200) + 40)}
+	case statsdomain.ToolStutterTapCounter:
+		// A whole number of taps per minute keeps the rate exact.
+		return map[string]any{statsdomain.MetaTotalTaps: number(minutes * rng.Intn(15))}
+	case statsdomain.ToolTimedReadingWPM:
+		return map[string]any{statsdomain.MetaActualWPM: number(rng.Intn(200) + 60)}
+	case statsdomain.ToolVirtualCoffeeOrder, statsdomain.ToolPhoneCallSimulator:
+		completed := []any{true, false, "TRUE", " false", "maybe"}
+		return map[string]any{statsdomain.MetaCompleted: completed[rng.Intn(len(completed))]}
+	default:
+		return map[string]any{}
+	}
+}
+
+func present[T any](value T) statsdomain.Optional[T] {
+	return statsdomain.Optional[T]{Present: true, Value: &value}
+}
+
+func referenceJournalStreak(dates []time.Time, today time.Time) int {
+	dateSet := make(map[string]struct{}, len(dates))
+	for _, date := range dates {
+		dateSet[dateKey(date)] = struct{}{}
+	}
+
+	streak := 0
+	for day := today; ; day = day.AddDate(0, 0, -1) {
+		if _, ok := dateSet[dateKey(day)]; !ok {
+			return streak
+		}
+		streak++
+	}
+}
+
+func referenceWellnessSummary(stats []*statsdomain.DailyStat) statsdomain.WellnessSummary {
+	sleep := avgCollector{}
+	stress := avgCollector{}
+	mindful := avgCollector{}
+	moods := map[string]int{"Great": 0, "Happy": 0, "Neutral": 0, "Sad": 0, "Dizzy": 0}
+	journalEntries := 0
+	daysTracked := 0
+
+	for _, stat := range stats {
+		if stat.SleepHours != nil {
+			sleep.add(*stat.SleepHours)
+		}
+		if stat.StressLevel != nil {
+			stress.add(float64(*stat.StressLevel))
+		}
+		if stat.MindfulHours != nil {
+			mindful.add(*stat.MindfulHours)
+		}
+		if stat.JournalEntry != nil {
+			journalEntries++
+		}
+		if stat.Mood != nil {
+			moods[*stat.Mood]++
+		}
+		if stat.HasTrackedData() {
+			daysTracked++
+		}
+	}
+
+	return statsdomain.WellnessSummary{
+		AvgSleepHours:       sleep.avgPtr(),
+		AvgStressLevel:      stress.avgPtr(),
+		AvgMindfulHours:     mindful.avgPtr(),
+		TotalJournalEntries: journalEntries,
+		MoodDistribution:    moods,
+		DaysTracked:         daysTracked,
+	}
+}
+
+func referenceStutterSummary(stats []*statsdomain.DailyStat) statsdomain.StutterSummary {
+	avgScore := avgCollector{}
+	trend := make([]statsdomain.ScoreTrendPoint, 0)
+	var bestScore *float64
+	var worstScore *float64
+	var latestDate time.Time
+	var latestScore *float64
+
+	for _, stat := range stats {
+		if stat.StutterScore == nil {
+			continue
+		}
+
+		score := *stat.StutterScore
+		avgScore.add(score)
+		trend = append(trend, statsdomain.ScoreTrendPoint{Date: stat.Date, Score: round1(score)})
+		if bestScore == nil || score < *bestScore {
+			bestScore = floatPtr(round1(score))
+		}
+		if worstScore == nil || score > *worstScore {
+			worstScore = floatPtr(round1(score))
+		}
+		if latestScore == nil || stat.Date.After(latestDate) {
+			latestDate = stat.Date
+			latestScore = floatPtr(round1(score))
+		}
+	}
+
+	sort.Slice(trend, func(i, j int) bool {
+		return trend[i].Date.Before(trend[j].Date)
+	})
+
+	return statsdomain.StutterSummary{
+		AvgScore:      avgScore.avgPtr(),
+		BestScore:     bestScore,
+		WorstScore:    worstScore,
+		TotalAnalyses: avgScore.count,
+		ScoreTrend:    trend,
+		LatestScore:   latestScore,
+		Speech:        calculateSpeechSummary(stats),
+	}
+}
+
+func referencePeriodMetrics(
+	wellness statsdomain.WellnessSummary,
+	stutter statsdomain.StutterSummary,
+	sessions []*statsdomain.ToolSession,
+	from, to time.Time,
+	loc *time.Location,
+) statsdomain.PeriodMetrics {
+	mood := avgCollector{}
+	for value, count := range wellness.MoodDistribution {
+		mood.sum += statsdomain.MoodScores[value] * float64(count)
+		mood.count += count
+	}
+
+	inRange := make([]*statsdomain.ToolSession, 0)
+	for _, session := range sessions {
+		date := localDate(session.StartedAt, loc)
+		if !date.Before(from) && !date.After(to) {
+			inRange = append(inRange, session)
+		}
+	}
+
+	return statsdomain.PeriodMetrics{
+		AvgSleepHours:   wellness.AvgSleepHours,
+		AvgStressLevel:  wellness.AvgStressLevel,
+		AvgMindfulHours: wellness.AvgMindfulHours,
+		AvgMood:         mood.avgPtr(),
+		DaysTracked:     wellness.DaysTracked,
+		JournalEntries:  wellness.TotalJournalEntries,
+		AvgStutterScore: stutter.AvgScore,
+		StutterAnalyses: stutter.TotalAnalyses,
+		ToolSessions:    len(inRange),
+		ToolMinutes:     referenceTotalMinutes(inRange),
+		ActiveToolDays:  len(referenceSessionDates(inRange, loc)),
+	}
+}
+
+func referenceToolStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.ToolStats {
+	dafSessions := referenceFilterToolSessions(sessions, statsdomain.ToolDAF)
+	fafSessions := referenceFilterToolSessions(sessions, statsdomain.ToolFAF)
+	breathingSessions := referenceFilterToolSessions(sessions, statsdomain.ToolBoxBreathing, statsdomain.ToolDiaphragmatic, statsdomain.ToolPreSpeech)
+	drillSessions := referenceFilterToolSessions(sessions, statsdomain.ToolGentleOnset, statsdomain.ToolProlongedSpeech)
+	biofeedbackSessions := referenceFilterToolSessions(sessions, statsdomain.ToolStutterTapCounter, statsdomain.ToolTimedReadingWPM)
+	simulationSessions := referenceFilterToolSessions(sessions, statsdomain.ToolVirtualCoffeeOrder, statsdomain.ToolPhoneCallSimulator)
+
+	return statsdomain.ToolStats{
+		Combined:    referenceCombinedToolStats(sessions, today, loc),
+		DAF:         referenceDAFStats(dafSessions, today, loc),
+		FAF:         referenceFAFStats(fafSessions, today, loc),
+		Breathing:   referenceBreathingStats(breathingSessions, today, loc),
+		Drills:      referenceDrillStats(drillSessions, today, loc),
+		Biofeedback: referenceBiofeedbackStats(biofeedbackSessions, today, loc),
+		Simulation:  referenceSimulationStats(simulationSessions, today, loc),
+	}
+}
+
+func referenceCombinedToolStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.CombinedToolStats {
+	dates := referenceSessionDates(sessions, loc)
+	var lastSessionAt *time.Time
+	if len(sessions) > 0 {
+		latest := sessions[0].StartedAt
+		lastSessionAt = &latest
+	}
+
+	return statsdomain.CombinedToolStats{
+		TotalSessions: len(sessions),
+		TotalMinutes:  referenceTotalMinutes(sessions),
+		CurrentStreak: referenceCurrentStreak(dates, today),
+		BestStreak:    referenceBestStreak(dates),
+		ActiveDays:    len(dates),
+		LastSessionAt: lastSessionAt,
+	}
+}
+
+func referenceDAFStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.DAFToolStats {
+	delay := avgCollector{}
+	for _, session := range sessions {
+		if value, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaDelayMS); ok {
+			delay.add(value)
+		}
+	}
+
+	return statsdomain.DAFToolStats{
+		TotalSessions:    len(sessions),
+		TotalMinutes:     referenceTotalMinutes(sessions),
+		AvgRating:        referenceAverageRating(sessions),
+		AvgDelayMS:       delay.avgPtr(),
+		SessionsThisWeek: referenceSessionsThisWeek(sessions, today, loc),
+		BestStreak:       referenceBestStreak(referenceSessionDates(sessions, loc)),
+	}
+}
+
+func referenceFAFStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.FAFToolStats {
+	semitones := avgCollector{}
+	directions := map[string]int{}
+	for _, session := range sessions {
+		if value, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaPitchSemitones); ok {
+			semitones.add(value)
+		}
+		if direction, ok := referenceMetadataString(session.Metadata, statsdomain.MetaPitchDirection); ok {
+			directions[direction]++
+		}
+	}
+
+	return statsdomain.FAFToolStats{
+		TotalSessions:      len(sessions),
+		TotalMinutes:       referenceTotalMinutes(sessions),
+		AvgRating:          referenceAverageRating(sessions),
+		PreferredDirection: modeStringPtr(directions),
+		AvgSemitones:       semitones.avgPtr(),
+		SessionsThisWeek:   referenceSessionsThisWeek(sessions, today, loc),
+		BestStreak:         referenceBestStreak(referenceSessionDates(sessions, loc)),
+	}
+}
+
+func referenceBreathingStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.BreathingToolStats {
+	situationBreakdown := map[string]int{}
+	stats := statsdomain.BreathingToolStats{SituationBreakdown: situationBreakdown}
+
+	for _, session := range sessions {
+		switch session.ToolType {
+		case statsdomain.ToolBoxBreathing:
+			stats.BoxBreathingSessions++
+		case statsdomain.ToolDiaphragmatic:
+			stats.DiaphragmaticSessions++
+		case statsdomain.ToolPreSpeech:
+			stats.PreSpeechSessions++
+			if situation, ok := referenceMetadataString(session.Metadata, statsdomain.MetaSituation); ok {
+				situationBreakdown[situation]++
+			}
+		}
+	}
+
+	stats.TotalSessions = len(sessions)
+	stats.TotalMinutes = referenceTotalMinutes(sessions)
+	stats.AvgRating = referenceAverageRating(sessions)
+	stats.CurrentStreak = referenceCurrentStreak(referenceSessionDates(sessions, loc), today)
+	return stats
+}
+
+func referenceDrillStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.DrillToolStats {
+	gentleScore := avgCollector{}
+	prolongedWPM := avgCollector{}
+	stats := statsdomain.DrillToolStats{}
+
+	for _, session := range sessions {
+		switch session.ToolType {
+		case statsdomain.ToolGentleOnset:
+			stats.GentleOnsetSessions++
+			if value, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaAverageScore); ok {
+				gentleScore.add(value)
+			}
+		case statsdomain.ToolProlongedSpeech:
+			stats.ProlongedSpeechSessions++
+			if value, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaEstimatedWPM); ok {
+				prolongedWPM.add(value)
+			}
+		}
+	}
+
+	stats.TotalSessions = len(sessions)
+	stats.TotalMinutes = referenceTotalMinutes(sessions)
+	stats.AvgRating = referenceAverageRating(sessions)
+	stats.AvgGentleScore = gentleScore.avgPtr()
+	stats.AvgProlongedWPM = prolongedWPM.avgPtr()
+	stats.CurrentStreak = referenceCurrentStreak(referenceSessionDates(sessions, loc), today)
+	return stats
+}
+
+func referenceBiofeedbackStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.BiofeedbackToolStats {
+	stuttersPerMin := avgCollector{}
+	readingWPM := avgCollector{}
+	stats := statsdomain.BiofeedbackToolStats{}
+
+	for _, session := range sessions {
+		switch session.ToolType {
+		case statsdomain.ToolStutterTapCounter:
+			stats.StutterTapSessions++
+			if taps, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaTotalTaps); ok && session.DurationSeconds > 0 {
+				stuttersPerMin.add(taps / (float64(session.DurationSeconds) / 60))
+			}
+		case statsdomain.ToolTimedReadingWPM:
+			stats.TimedReadingSessions++
+			if value, ok := referenceMetadataNumber(session.Metadata, statsdomain.MetaActualWPM); ok {
+				readingWPM.add(value)
+			}
+		}
+	}
+
+	stats.TotalSessions = len(sessions)
+	stats.TotalMinutes = referenceTotalMinutes(sessions)
+	stats.AvgRating = referenceAverageRating(sessions)
+	stats.AvgStuttersPerMin = stuttersPerMin.avgPtr()
+	stats.AvgReadingWPM = readingWPM.avgPtr()
+	stats.CurrentStreak = referenceCurrentStreak(referenceSessionDates(sessions, loc), today)
+	return stats
+}
+
+func referenceSimulationStats(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) statsdomain.SimulationToolStats {
+	completionScore := avgCollector{}
+	stats := statsdomain.SimulationToolStats{}
+
+	for _, session := range sessions {
+		switch session.ToolType {
+		case statsdomain.ToolVirtualCoffeeOrder:
+			stats.CoffeeSessions++
+		case statsdomain.ToolPhoneCallSimulator:
+			stats.CallSessions++
+		}
+		if completed, ok := referenceMetadataBool(session.Metadata, statsdomain.MetaCompleted); ok {
+			if completed {
+				completionScore.add(100)
+			} else {
+				completionScore.add(0)
+			}
+		}
+	}
+
+	stats.TotalSessions = len(sessions)
+	stats.TotalMinutes = referenceTotalMinutes(sessions)
+	stats.AvgRating = referenceAverageRating(sessions)
+	stats.AvgCompletionScore = completionScore.avgPtr()
+	stats.CurrentStreak = referenceCurrentStreak(referenceSessionDates(sessions, loc), today)
+	return stats
+}
+
+func referenceWeeklyActivity(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) []statsdomain.WeeklyActivityDay {
+	counts := make(map[string]int, 7)
+	for _, session := range sessions {
+		key := dateKey(localDate(session.StartedAt, loc))
+		counts[key]++
+	}
+
+	activity := make([]statsdomain.WeeklyActivityDay, 0, 7)
+	start := today.AddDate(0, 0, -6)
+	for i := 0; i < 7; i++ {
+		date := start.AddDate(0, 0, i)
+		activity = append(activity, statsdomain.WeeklyActivityDay{
+			Date:     date,
+			Day:      weekdayLabel(date),
+			Sessions: counts[dateKey(date)],
+		})
+	}
+	return activity
+}
+
+func referenceWeeklyTrend(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) []statsdomain.WeeklyTrendWeek {
+	trend := make([]statsdomain.WeeklyTrendWeek, 0, 6)
+	start := today.AddDate(0, 0, -35)
+
+	for week := 0; week < 6; week++ {
+		weekStart := start.AddDate(0, 0, week*7)
+		weekEnd := weekStart.AddDate(0, 0, 7)
+		minutes := 0.0
+		for _, session := range sessions {
+			sessionDate := localDate(session.StartedAt, loc)
+			if !sessionDate.Before(weekStart) && sessionDate.Before(weekEnd) {
+				minutes += float64(session.DurationSeconds) / 60
+			}
+		}
+		trend = append(trend, statsdomain.WeeklyTrendWeek{
+			WeekLabel:    fmt.Sprintf("W%d", week+1),
+			TotalMinutes: round1(minutes),
+		})
+	}
+	return trend
+}
+
+func referenceFilterToolSessions(sessions []*statsdomain.ToolSession, toolTypes ...string) []*statsdomain.ToolSession {
+	allowed := make(map[string]struct{}, len(toolTypes))
+	for _, toolType := range toolTypes {
+		allowed[toolType] = struct{}{}
+	}
+
+	filtered := make([]*statsdomain.ToolSession, 0)
+	for _, session := range sessions {
+		if _, ok := allowed[session.ToolType]; ok {
+			filtered = append(filtered, session)
+		}
+	}
+	return filtered
+}
+
+func referenceTotalMinutes(sessions []*statsdomain.ToolSession) float64 {
+	seconds := 0
+	for _, session := range sessions {
+		seconds += session.DurationSeconds
+	}
+	return round1(float64(seconds) / 60)
+}
+
+func referenceAverageRating(sessions []*statsdomain.ToolSession) *float64 {
+	avg := avgCollector{}
+	for _, session := range sessions {
+		if session.SelfRating != nil {
+			avg.add(float64(*session.SelfRating))
+		}
+	}
+	return avg.avgPtr()
+}
+
+func referenceSessionsThisWeek(sessions []*statsdomain.ToolSession, today time.Time, loc *time.Location) int {
+	cutoff := today.AddDate(0, 0, -6)
+	count := 0
+	for _, session := range sessions {
+		if !localDate(session.StartedAt, loc).Before(cutoff) {
+			count++
+		}
+	}
+	return count
+}
+
+func referenceSessionDates(sessions []*statsdomain.ToolSession, loc *time.Location) map[string]time.Time {
+	dates := make(map[string]time.Time)
+	for _, session := range sessions {
+		date := localDate(session.StartedAt, loc)
+		dates[dateKey(date)] = date
+	}
+	return dates
+}
+
+func referenceCurrentStreak(dates map[string]time.Time, today time.Time) int {
+	streak := 0
+	for date := today; ; date = date.AddDate(0, 0, -1) {
+		if _, ok := dates[dateKey(date)]; !ok {
+			return streak
+		}
+		streak++
+	}
+}
+
+func referenceBestStreak(dates map[string]time.Time) int {
+	if len(dates) == 0 {
+		return 0
+	}
+
+	ordered := make([]time.Time, 0, len(dates))
+	for _, date := range dates {
+		ordered = append(ordered, date)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Before(ordered[j])
+	})
+
+	best := 1
+	current := 1
+	for i := 1; i < len(ordered); i++ {
+		if ordered[i].Equal(ordered[i-1].AddDate(0, 0, 1)) {
+			current++
+		} else {
+			current = 1
+		}
+		if current > best {
+			best = current
+		}
+	}
+	return best
+}
+
+func referenceMetadataNumber(metadata map[string]any, key string) (float64, bool) {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return 0, false
+	}
+
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err == nil {
+			return parsed, true
+		}
+	}
+	return 0, false
+}
+
+func referenceMetadataString(metadata map[string]any, key string) (string, bool) {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return "", false
+	}
+	text, ok := value.(string)
+	if !ok {
+		return "", false
+	}
+	text = strings.TrimSpace(text)
+	return text, text != ""
+}
+
+func referenceMetadataBool(metadata map[string]any, key string) (bool, bool) {
+	value, ok := metadata[key]
+	if !ok || value == nil {
+		return false, false
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case string:
+		lower := strings.ToLower(strings.TrimSpace(v))
+		if lower == "true" {
+			return true, true
+		}
+		if lower == "false" {
+			return false, true
+		}
+	}
+	return false, false
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+const (
+	maxToolSessionsPerRequest = 100
+	maxToolSessionSeconds     = 4 * 60 * 60
+	maxToolMetadataBytes      = 4096
+	startedAtClockSkew        = 5 * time.Minute
+)
+
+func (uc *StatsUseCase) LogToolSessions(ctx context.Context, userID uuid.UUID, sessions []statsdomain.ToolSession) ([]*statsdomain.ToolSession, error) {
+	if len(sessions) == 0 {
+		return nil, statsdomain.ErrNoToolSessions
+	}
+	if len(sessions) > maxToolSessionsPerRequest {
+		return nil, statsdomain.ErrTooManyToolSessions
+	}
+
+	now := time.Now().UTC()
+	toCreate := make([]*statsdomain.ToolSession, 0, len(sessions))
+	for i, session := range sessions {
+		if err := validateToolSession(session, now); err != nil {
+			return nil, err
+		}
+		if err := validateToolMetadata(i, session); err != nil {
+			return nil, err
+		}
+
+		metadata := session.Metadata
+		if metadata == nil {
+			metadata = map[string]any{}
+		}
+
+		toCreate = append(toCreate, &statsdomain.ToolSession{
+			ID:              uuid.New(),
+			UserID:          userID,
+			ToolType:        session.ToolType,
+			StartedAt:       session.StartedAt.UTC(),
+			DurationSeconds: session.DurationSeconds,
+			SelfRating:      session.SelfRating,
+			Metadata:        metadata,
+		})
+	}
+
+	created, err := uc.statsRepo.CreateToolSessions(ctx, toCreate)
+	if err != nil {
+		return nil, fmt.Errorf("log tool sessions: %w", err)
+	}
+	return created, nil
+}
+
+func validateToolSession(session statsdomain.ToolSession, now time.Time) error {
+	if !statsdomain.IsValidToolType(session.ToolType) {
+		return statsdomain.ErrInvalidToolType
+	}
+	if session.StartedAt.IsZero() || session.StartedAt.After(now.Add(startedAtClockSkew)) {
+		return statsdomain.ErrInvalidStartedAt
+	}
+	if session.DurationSeconds < 1 || session.DurationSeconds > maxToolSessionSeconds {
+		return statsdomain.ErrInvalidDuration
+	}
+	if session.SelfRating != nil && (*session.SelfRating < 1 || *session.SelfRating > 5) {
+		return statsdomain.ErrInvalidSelfRating
+	}
+	if session.Metadata != nil {
+		encoded, err := json.Marshal(session.Metadata)
+		if err != nil || len(encoded) > maxToolMetadataBytes {
+			return statsdomain.ErrInvalidMetadata
+		}
+	}
+	return nil
+}
+
+func validateToolMetadata(index int, session statsdomain.ToolSession) error {
+	schema, ok := statsdomain.ToolSchemaFor(session.ToolType)
+	if !ok {
+		return statsdomain.ErrInvalidToolType
+	}
+
+	fieldErrors := schema.Validate(session.Metadata)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+	return &statsdomain.MetadataValidationError{
+		Index:    index,
+		ToolType: session.ToolType,
+		Fields:   fieldErrors,
+	}
+}
+
+func (uc *StatsUseCase) ToolSchemas() []statsdomain.ToolSchema {
+	return statsdomain.ToolSchemas()
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+// StatsVersion returns a key that changes whenever GetStats would answer
+// differently for the same arguments, without computing the overview.
+// Today's date is part of it because the default range and the streaks
+// move with it.
+func (uc *StatsUseCase) StatsVersion(ctx context.Context, userID uuid.UUID, from, to *time.Time, compare statsdomain.CompareMode) (string, error) {
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	version, err := uc.statsRepo.GetDataVersion(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get stats data version: %w", err)
+	}
+
+	return fmt.Sprintf("stats|%s|%s|%s|%s|%s|%d|%s|%d|%s|%d",
+		loc.String(), dateKey(todayIn(loc)),
+		optionalDateKey(from), optionalDateKey(to), compare,
+		version.DailyStats, timestampKey(version.DailyStatsUpdatedAt),
+		version.ToolSessions, timestampKey(version.ToolSessionsAddedAt),
+		version.Writes,
+	), nil
+}
+
+func optionalDateKey(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return dateKey(*date)
+}
+
+func timestampKey(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(value.UnixMicro(), 10)
+}
@@ -25,7 +25,16 @@ var allowedMoods = map[string]struct{}{
 }
 
 func (uc *StatsUseCase) UpdateDailyStat(ctx context.Context, userID uuid.UUID, patch statsdomain.DailyStatPatch) (*statsdomain.DailyStat, error) {
-	if err := validateDailyStatPatch(patch); err != nil {
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if patch.AnalyzeTranscript {
+		if err := uc.applyTranscriptAnalysis(&patch); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateDailyStatPatch(patch, loc); err != nil {
 		return nil, err
 	}
 
@@ -36,11 +45,11 @@ func (uc *StatsUseCase) UpdateDailyStat(ctx context.Context, userID uuid.UUID, p
 	return stat, nil
 }
 
-func validateDailyStatPatch(patch statsdomain.DailyStatPatch) error {
+func validateDailyStatPatch(patch statsdomain.DailyStatPatch, loc *time.Location) error {
 	if patch.Date.IsZero() {
 		return statsdomain.ErrDateRequired
 	}
-	if isFutureDate(patch.Date) {
+	if isFutureDate(patch.Date, loc) {
 		return statsdomain.ErrFutureDate
 	}
 
@@ -119,12 +128,17 @@ func isHalfStep(value float64) bool {
 	return math.Abs(value*2-math.Round(value*2)) < 0.0000001
 }
 
-func isFutureDate(date time.Time) bool {
-	return startOfDayUTC(date).After(todayUTC())
+func isFutureDate(date time.Time, loc *time.Location) bool {
+	return startOfDayUTC(date).After(todayIn(loc))
+}
+
+func todayIn(loc *time.Location) time.Time {
+	return localDate(time.Now(), loc)
 }
 
-func todayUTC() time.Time {
-	return startOfDayUTC(time.Now().UTC())
+func localDate(value time.Time, loc *time.Location) time.Time {
+	year, month, day := value.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
 
 func startOfDayUTC(value time.Time) time.Time {
@@ -1,11 +1,35 @@
 package usecase
 
-import statsrepo "saythis-backend/internal/src/stats/repository"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+	statsrepo "saythis-backend/internal/src/stats/repository"
+	userrepo "saythis-backend/internal/src/user/repository"
+)
 
 type StatsUseCase struct {
 	statsRepo statsrepo.StatsRepository
+	userRepo  userrepo.UserRepository
+	analyzer  *statsdomain.TranscriptAnalyzer
+}
+
+func NewStatsUseCase(
+	statsRepo statsrepo.StatsRepository,
+	userRepo userrepo.UserRepository,
+	analyzer *statsdomain.TranscriptAnalyzer,
+) *StatsUseCase {
+	return &StatsUseCase{statsRepo: statsRepo, userRepo: userRepo, analyzer: analyzer}
 }
 
-func NewStatsUseCase(statsRepo statsrepo.StatsRepository) *StatsUseCase {
-	return &StatsUseCase{statsRepo: statsRepo}
+func (uc *StatsUseCase) userLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user timezone: %w", err)
+	}
+	return user.Location(), nil
 }
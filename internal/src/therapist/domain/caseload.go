@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCaseloadPageSize = 50
+	MaxCaseloadPageSize     = 200
+
+	// CaseloadScoreWindowDays matches the default GetStats range, so the
+	// dashboard's stutter averages agree with the client's own overview.
+	CaseloadScoreWindowDays = 30
+
+	// MoodTrendThreshold is how far, on a 1 (Dizzy) to 5 (Great) scale, the
+	// last 7 days' mean mood must move from the 7 days before to count as
+	// a change rather than noise.
+	MoodTrendThreshold = 0.5
+)
+
+type MoodTrend string
+
+const (
+	MoodImproving MoodTrend = "improving"
+	MoodStable    MoodTrend = "stable"
+	MoodDeclining MoodTrend = "declining"
+)
+
+func (m MoodTrend) IsValid() bool {
+	switch m {
+	case MoodImproving, MoodStable, MoodDeclining:
+		return true
+	}
+	return false
+}
+
+type CaseloadSort string
+
+const (
+	SortByName              CaseloadSort = "name"
+	SortByLastActivity      CaseloadSort = "last_activity"
+	SortByToolStreak        CaseloadSort = "tool_streak"
+	SortByLatestScore       CaseloadSort = "latest_stutter_score"
+	SortByAvgScore          CaseloadSort = "avg_stutter_score"
+	SortByExercisesThisWeek CaseloadSort = "exercises_this_week"
+)
+
+func (s CaseloadSort) IsValid() bool {
+	switch s {
+	case SortByName, SortByLastActivity, SortByToolStreak,
+		SortByLatestScore, SortByAvgScore, SortByExercisesThisWeek:
+		return true
+	}
+	return false
+}
+
+type CaseloadFilter struct {
+	Query     string
+	MoodTrend *MoodTrend
+	// InactiveDays keeps only clients with no shared activity in that many
+	// days, including those with none at all.
+	InactiveDays *int
+	Sort         CaseloadSort
+	Descending   bool
+	Limit        int
+	Offset       int
+}
+
+// CaseloadClient is one row of the therapist dashboard. Metrics backed by
+// a scope the client has not granted are left nil rather than zero, so the
+// UI can tell "not shared" apart from "no activity".
+type CaseloadClient struct {
+	LinkID   uuid.UUID
+	ClientID uuid.UUID
+	FullName string
+	Email    string
+	Scopes   []Scope
+	LinkedAt time.Time
+
+	LastActivityAt     *time.Time
+	CurrentToolStreak  *int
+	LatestStutterScore *float64
+	AvgStutterScore    *float64
+	ExercisesThisWeek  *int
+	MoodTrend          *MoodTrend
+}
+
+type CaseloadPage struct {
+	Clients []*CaseloadClient
+	Total   int
+	Limit   int
+	Offset  int
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidScope          = errors.New("invalid consent scope")
+	ErrNoScopes              = errors.New("at least one consent scope is required")
+	ErrEmptyLinkTarget       = errors.New("provide either a link code or a therapist email")
+	ErrInvalidLinkCode       = errors.New("invalid or expired link code")
+	ErrTherapistNotFound     = errors.New("no therapist found with that email")
+	ErrCannotLinkSelf        = errors.New("you cannot link yourself as your own therapist")
+	ErrAlreadyLinked         = errors.New("this therapist is already linked")
+	ErrLinkNotFound          = errors.New("therapist link not found")
+	ErrClientNotLinked       = errors.New("client not found")
+	ErrScopeNotGranted       = errors.New("client has not shared this data")
+	ErrTooManyLinkCodes      = errors.New("too many active link codes, try again later")
+	ErrInvalidCaseloadFilter = errors.New("invalid caseload filter")
+)
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope is a category of client data a therapist may read. Clients grant
+// scopes explicitly and can narrow or revoke them at any time.
+type Scope string
+
+const (
+	ScopeTherapyProgress Scope = "therapy_progress"
+	ScopeDailyStats      Scope = "daily_stats"
+	ScopeJournal         Scope = "journal"
+	ScopeTranscripts     Scope = "transcripts"
+)
+
+var scopes = []Scope{ScopeTherapyProgress, ScopeDailyStats, ScopeJournal, ScopeTranscripts}
+
+func Scopes() []Scope {
+	out := make([]Scope, len(scopes))
+	copy(out, scopes)
+	return out
+}
+
+func (s Scope) IsValid() bool {
+	for _, scope := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeScopes validates, de-duplicates and sorts requested scopes.
+func NormalizeScopes(requested []Scope) ([]Scope, error) {
+	if len(requested) == 0 {
+		return nil, ErrNoScopes
+	}
+
+	seen := make(map[Scope]struct{}, len(requested))
+	out := make([]Scope, 0, len(requested))
+	for _, scope := range requested {
+		if !scope.IsValid() {
+			return nil, ErrInvalidScope
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		out = append(out, scope)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out, nil
+}
+
+type LinkStatus string
+
+const (
+	LinkActive  LinkStatus = "active"
+	LinkRevoked LinkStatus = "revoked"
+)
+
+type Link struct {
+	ID          uuid.UUID
+	TherapistID uuid.UUID
+	ClientID    uuid.UUID
+	Scopes      []Scope
+	Status      LinkStatus
+	LinkedAt    time.Time
+	RevokedAt   *time.Time
+	UpdatedAt   time.Time
+
+	TherapistName  string
+	TherapistEmail string
+	ClientName     string
+	ClientEmail    string
+}
+
+func (l *Link) HasScope(scope Scope) bool {
+	for _, s := range l.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	LinkCodeTTL          = 7 * 24 * time.Hour
+	MaxActiveLinkCodes   = 20
+	linkCodeAlphabet     = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	LinkCodeLength       = 8
+	linkCodeGroupDivider = 4
+)
+
+type LinkCode struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
+// GenerateLinkCode draws a code shaped "ABCD-EFGH" from random. Bytes at or
+// above the largest multiple of the alphabet size are redrawn, so every
+// character is equally likely.
+func GenerateLinkCode(random io.Reader) (string, error) {
+	limit := byte(256 - 256%len(linkCodeAlphabet))
+	buf := make([]byte, LinkCodeLength)
+	out := make([]byte, 0, LinkCodeLength+1)
+	for len(out) < LinkCodeLength+1 {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return "", fmt.Errorf("read random bytes: %w", err)
+		}
+		for _, b := range buf {
+			if b >= limit || len(out) == LinkCodeLength+1 {
+				continue
+			}
+			if len(out) == linkCodeGroupDivider {
+				out = append(out, '-')
+			}
+			out = append(out, linkCodeAlphabet[int(b)%len(linkCodeAlphabet)])
+		}
+	}
+	return string(out), nil
+}
@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+func TestNormalizeScopes_DedupesAndSorts(t *testing.T) {
+	got, err := therapistdomain.NormalizeScopes([]therapistdomain.Scope{
+		therapistdomain.ScopeTranscripts,
+		therapistdomain.ScopeDailyStats,
+		therapistdomain.ScopeTranscripts,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []therapistdomain.Scope{therapistdomain.ScopeDailyStats, therapistdomain.ScopeTranscripts}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestNormalizeScopes_Rejects(t *testing.T) {
+	if _, err := therapistdomain.NormalizeScopes(nil); !errors.Is(err, therapistdomain.ErrNoScopes) {
+		t.Errorf("want ErrNoScopes, got %v", err)
+	}
+	if _, err := therapistdomain.NormalizeScopes([]therapistdomain.Scope{"everything"}); !errors.Is(err, therapistdomain.ErrInvalidScope) {
+		t.Errorf("want ErrInvalidScope, got %v", err)
+	}
+}
+
+func TestGenerateLinkCode(t *testing.T) {
+	// 248 and up would over-represent the first characters, so they are
+	// skipped and drawn again.
+	random := bytes.NewReader([]byte{0, 255, 1, 2, 248, 3, 4, 5, 6, 250, 7, 0, 0, 0, 0, 0})
+	code, err := therapistdomain.GenerateLinkCode(random)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != "ABCD-EFGH" {
+		t.Errorf("want ABCD-EFGH, got %s", code)
+	}
+}
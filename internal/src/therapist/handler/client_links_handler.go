@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	"saythis-backend/internal/src/therapist/usecase"
+)
+
+const maxBodySize = 1 << 20
+
+type LinkTherapistHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewLinkTherapistHandler(uc *usecase.TherapistUseCase) *LinkTherapistHandler {
+	return &LinkTherapistHandler{usecase: uc}
+}
+
+type linkTherapistRequest struct {
+	Code   string                  `json:"code"`
+	Email  string                  `json:"email"`
+	Scopes []therapistdomain.Scope `json:"scopes"`
+}
+
+func (h *LinkTherapistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req linkTherapistRequest
+	if err := helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	link, err := h.usecase.LinkTherapist(r.Context(), claims.UserID, req.Code, req.Email, req.Scopes, helper.ClientIP(r))
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusCreated, therapistLinkResponse{Link: toTherapistLinkPayload(link)})
+}
+
+type ListTherapistsHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewListTherapistsHandler(uc *usecase.TherapistUseCase) *ListTherapistsHandler {
+	return &ListTherapistsHandler{usecase: uc}
+}
+
+func (h *ListTherapistsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	links, err := h.usecase.ListTherapists(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	resp := therapistLinksResponse{Links: make([]therapistLinkPayload, 0, len(links))}
+	for _, link := range links {
+		resp.Links = append(resp.Links, toTherapistLinkPayload(link))
+	}
+	helper.JSON(w, http.StatusOK, resp)
+}
+
+type UpdateConsentHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewUpdateConsentHandler(uc *usecase.TherapistUseCase) *UpdateConsentHandler {
+	return &UpdateConsentHandler{usecase: uc}
+}
+
+type updateConsentRequest struct {
+	Scopes []therapistdomain.Scope `json:"scopes"`
+}
+
+func (h *UpdateConsentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	linkID, err := uuid.Parse(r.PathValue("linkID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid link id")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+	defer r.Body.Close()
+
+	var req updateConsentRequest
+	if err = helper.DecodeJSON(r, &req); err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	link, err := h.usecase.UpdateConsent(r.Context(), claims.UserID, linkID, req.Scopes, helper.ClientIP(r))
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, therapistLinkResponse{Link: toTherapistLinkPayload(link)})
+}
+
+type RevokeTherapistHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewRevokeTherapistHandler(uc *usecase.TherapistUseCase) *RevokeTherapistHandler {
+	return &RevokeTherapistHandler{usecase: uc}
+}
+
+func (h *RevokeTherapistHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	linkID, err := uuid.Parse(r.PathValue("linkID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid link id")
+		return
+	}
+
+	if err = h.usecase.RevokeLink(r.Context(), claims.UserID, linkID, helper.ClientIP(r)); err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/therapist/usecase"
+)
+
+type CreateLinkCodeHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewCreateLinkCodeHandler(uc *usecase.TherapistUseCase) *CreateLinkCodeHandler {
+	return &CreateLinkCodeHandler{usecase: uc}
+}
+
+func (h *CreateLinkCodeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	code, err := h.usecase.CreateLinkCode(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusCreated, linkCodeResponse{Code: code.Code, ExpiresAt: code.ExpiresAt})
+}
@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	"saythis-backend/internal/src/therapist/usecase"
+)
+
+type ListClientsHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewListClientsHandler(uc *usecase.TherapistUseCase) *ListClientsHandler {
+	return &ListClientsHandler{usecase: uc}
+}
+
+type caseloadClientPayload struct {
+	LinkID             uuid.UUID                  `json:"link_id"`
+	ClientID           uuid.UUID                  `json:"client_id"`
+	FullName           string                     `json:"full_name"`
+	Email              string                     `json:"email"`
+	Scopes             []therapistdomain.Scope    `json:"scopes"`
+	LinkedAt           time.Time                  `json:"linked_at"`
+	LastActivityAt     *time.Time                 `json:"last_activity_at"`
+	CurrentToolStreak  *int                       `json:"current_tool_streak"`
+	LatestStutterScore *float64                   `json:"latest_stutter_score"`
+	AvgStutterScore    *float64                   `json:"avg_stutter_score"`
+	ExercisesThisWeek  *int                       `json:"exercises_this_week"`
+	MoodTrend          *therapistdomain.MoodTrend `json:"mood_trend"`
+}
+
+type listClientsResponse struct {
+	Clients []caseloadClientPayload `json:"clients"`
+	Total   int                     `json:"total"`
+	Limit   int                     `json:"limit"`
+	Offset  int                     `json:"offset"`
+}
+
+func (h *ListClientsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	filter, err := parseCaseloadFilter(r)
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	page, err := h.usecase.ListClients(r.Context(), claims.UserID, filter)
+	if err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	clients := make([]caseloadClientPayload, 0, len(page.Clients))
+	for _, c := range page.Clients {
+		clients = append(clients, caseloadClientPayload{
+			LinkID:             c.LinkID,
+			ClientID:           c.ClientID,
+			FullName:           c.FullName,
+			Email:              c.Email,
+			Scopes:             c.Scopes,
+			LinkedAt:           c.LinkedAt,
+			LastActivityAt:     c.LastActivityAt,
+			CurrentToolStreak:  c.CurrentToolStreak,
+			LatestStutterScore: c.LatestStutterScore,
+			AvgStutterScore:    c.AvgStutterScore,
+			ExercisesThisWeek:  c.ExercisesThisWeek,
+			MoodTrend:          c.MoodTrend,
+		})
+	}
+
+	helper.JSON(w, http.StatusOK, listClientsResponse{
+		Clients: clients,
+		Total:   page.Total,
+		Limit:   page.Limit,
+		Offset:  page.Offset,
+	})
+}
+
+// parseCaseloadFilter reads ?q=&mood_trend=&inactive_days=&sort=&order=
+// &limit=&offset=. Without an explicit order, names sort ascending and
+// every metric sorts descending.
+func parseCaseloadFilter(r *http.Request) (therapistdomain.CaseloadFilter, error) {
+	query := r.URL.Query()
+	filter := therapistdomain.CaseloadFilter{
+		Query: query.Get("q"),
+		Sort:  therapistdomain.CaseloadSort(query.Get("sort")),
+	}
+
+	if value := query.Get("mood_trend"); value != "" {
+		trend := therapistdomain.MoodTrend(value)
+		filter.MoodTrend = &trend
+	}
+
+	switch query.Get("order") {
+	case "":
+		filter.Descending = filter.Sort != "" && filter.Sort != therapistdomain.SortByName
+	case "asc":
+		filter.Descending = false
+	case "desc":
+		filter.Descending = true
+	default:
+		return filter, therapistdomain.ErrInvalidCaseloadFilter
+	}
+
+	if value := query.Get("inactive_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return filter, therapistdomain.ErrInvalidCaseloadFilter
+		}
+		filter.InactiveDays = &days
+	}
+
+	var err error
+	if filter.Limit, err = parseIntQuery(query.Get("limit")); err != nil {
+		return filter, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	if filter.Offset, err = parseIntQuery(query.Get("offset")); err != nil {
+		return filter, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	return filter, nil
+}
+
+func parseIntQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
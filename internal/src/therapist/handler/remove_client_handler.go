@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/therapist/usecase"
+)
+
+type RemoveClientHandler struct {
+	usecase *usecase.TherapistUseCase
+}
+
+func NewRemoveClientHandler(uc *usecase.TherapistUseCase) *RemoveClientHandler {
+	return &RemoveClientHandler{usecase: uc}
+}
+
+func (h *RemoveClientHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	if err = h.usecase.RemoveClient(r.Context(), claims.UserID, clientID, helper.ClientIP(r)); err != nil {
+		status, msg := mapTherapistError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+type therapistLinkPayload struct {
+	ID             uuid.UUID               `json:"id"`
+	TherapistID    uuid.UUID               `json:"therapist_id"`
+	TherapistName  string                  `json:"therapist_name"`
+	TherapistEmail string                  `json:"therapist_email"`
+	Scopes         []therapistdomain.Scope `json:"scopes"`
+	LinkedAt       time.Time               `json:"linked_at"`
+	UpdatedAt      time.Time               `json:"updated_at"`
+}
+
+type therapistLinkResponse struct {
+	Link therapistLinkPayload `json:"link"`
+}
+
+type therapistLinksResponse struct {
+	Links []therapistLinkPayload `json:"links"`
+}
+
+type linkCodeResponse struct {
+	Code      string    `json:"code"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func toTherapistLinkPayload(link *therapistdomain.Link) therapistLinkPayload {
+	return therapistLinkPayload{
+		ID:             link.ID,
+		TherapistID:    link.TherapistID,
+		TherapistName:  link.TherapistName,
+		TherapistEmail: link.TherapistEmail,
+		Scopes:         link.Scopes,
+		LinkedAt:       link.LinkedAt,
+		UpdatedAt:      link.UpdatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+func mapTherapistError(err error) (int, string) {
+	switch {
+
+	case errors.Is(err, therapistdomain.ErrInvalidScope),
+		errors.Is(err, therapistdomain.ErrNoScopes),
+		errors.Is(err, therapistdomain.ErrEmptyLinkTarget),
+		errors.Is(err, therapistdomain.ErrInvalidLinkCode),
+		errors.Is(err, therapistdomain.ErrCannotLinkSelf),
+		errors.Is(err, therapistdomain.ErrInvalidCaseloadFilter):
+		return http.StatusBadRequest, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrTherapistNotFound),
+		errors.Is(err, therapistdomain.ErrLinkNotFound),
+		errors.Is(err, therapistdomain.ErrClientNotLinked):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrScopeNotGranted):
+		return http.StatusForbidden, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrAlreadyLinked):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrTooManyLinkCodes):
+		return http.StatusTooManyRequests, err.Error()
+
+	default:
+		return http.StatusInternalServerError, "internal server error"
+	}
+}
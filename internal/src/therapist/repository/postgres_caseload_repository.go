@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+// caseloadCTE computes every dashboard metric for all of a therapist's
+// active clients in one pass. Each client's "today" is taken in their own
+// timezone, and each metric only reads tables the client has shared:
+// daily_stats covers tool sessions and daily stats, therapy_progress
+// covers exercises.
+//
+// Parameters: $1 therapist, $2 score window in days, $3 mood threshold.
+const caseloadCTE = `
+	WITH clients AS (
+		SELECT l.id AS link_id, l.client_id, l.scopes, l.linked_at,
+		       u.full_name, u.email, u.timezone AS tz,
+		       (NOW() AT TIME ZONE u.timezone)::date AS today,
+		       'daily_stats'      = ANY (l.scopes) AS share_stats,
+		       'therapy_progress' = ANY (l.scopes) AS share_progress
+		FROM   therapist_clients l
+		JOIN   users u ON u.id = l.client_id
+		WHERE  l.therapist_id = $1
+		  AND  l.status  = 'active'
+		  AND  u.status <> 'deleted'
+	),
+	tool_days AS (
+		SELECT DISTINCT s.user_id, (s.started_at AT TIME ZONE c.tz)::date AS day
+		FROM   tool_sessions s
+		JOIN   clients c ON c.client_id = s.user_id
+		WHERE  c.share_stats
+	),
+	tool_streaks AS (
+		-- Consecutive days share the same (day - row_number); the island
+		-- ending today is the current streak.
+		SELECT i.user_id, COUNT(*)::int AS streak
+		FROM  (SELECT user_id, day,
+		              day - (ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY day))::int AS island
+		       FROM   tool_days) i
+		JOIN   clients c ON c.client_id = i.user_id
+		GROUP  BY i.user_id, i.island, c.today
+		HAVING MAX(i.day) = c.today
+	),
+	tool_activity AS (
+		SELECT s.user_id, MAX(s.started_at) AS last_at
+		FROM   tool_sessions s
+		JOIN   clients c ON c.client_id = s.user_id
+		WHERE  c.share_stats
+		GROUP  BY s.user_id
+	),
+	daily AS (
+		SELECT d.user_id,
+		       MAX(d.updated_at) AS last_at,
+		       (ARRAY_AGG(ROUND(d.stutter_score, 1) ORDER BY d.date DESC)
+		            FILTER (WHERE d.stutter_score IS NOT NULL AND d.date BETWEEN c.today - $2::int AND c.today))[1] AS latest_score,
+		       ROUND(AVG(d.stutter_score) FILTER (WHERE d.date BETWEEN c.today - $2::int AND c.today), 1) AS avg_score,
+		       AVG(m.value) FILTER (WHERE d.date >  c.today - 7  AND d.date <= c.today)     AS recent_mood,
+		       AVG(m.value) FILTER (WHERE d.date >  c.today - 14 AND d.date <= c.today - 7) AS previous_mood
+		FROM   user_daily_stats d
+		JOIN   clients c ON c.client_id = d.user_id
+		CROSS  JOIN LATERAL (
+		           SELECT CASE d.mood
+		                      WHEN 'Dizzy'   THEN 1
+		                      WHEN 'Sad'     THEN 2
+		                      WHEN 'Neutral' THEN 3
+		                      WHEN 'Happy'   THEN 4
+		                      WHEN 'Great'   THEN 5
+		                  END AS value
+		       ) m
+		WHERE  c.share_stats
+		GROUP  BY d.user_id, c.today
+	),
+	exercises AS (
+		SELECT e.user_id,
+		       MAX(e.completed_at) AS last_at,
+		       COUNT(DISTINCT e.exercise_id) FILTER (WHERE (e.completed_at AT TIME ZONE c.tz)::date > c.today - 7)::int AS this_week
+		FROM   exercise_attempts e
+		JOIN   clients c ON c.client_id = e.user_id
+		WHERE  c.share_progress
+		GROUP  BY e.user_id
+	),
+	caseload AS (
+		SELECT c.link_id, c.client_id, c.full_name, c.email, c.scopes, c.linked_at,
+		       GREATEST(ta.last_at, d.last_at, ex.last_at) AS last_activity_at,
+		       CASE WHEN c.share_stats    THEN COALESCE(ts.streak, 0)   END AS tool_streak,
+		       d.latest_score::float8 AS latest_score,
+		       d.avg_score::float8    AS avg_score,
+		       CASE WHEN c.share_progress THEN COALESCE(ex.this_week, 0) END AS exercises_this_week,
+		       CASE
+		           WHEN d.recent_mood IS NULL OR d.previous_mood IS NULL THEN NULL
+		           WHEN d.recent_mood - d.previous_mood >=  $3::float8 THEN 'improving'
+		           WHEN d.recent_mood - d.previous_mood <= -$3::float8 THEN 'declining'
+		           ELSE 'stable'
+		       END AS mood_trend
+		FROM   clients c
+		LEFT   JOIN tool_streaks  ts ON ts.user_id = c.client_id
+		LEFT   JOIN tool_activity ta ON ta.user_id = c.client_id
+		LEFT   JOIN daily         d  ON d.user_id  = c.client_id
+		LEFT   JOIN exercises     ex ON ex.user_id = c.client_id
+	)
+`
+
+// Parameters: $4 name/email pattern, $5 mood trend, $6 inactive days.
+const caseloadWhere = `
+	WHERE ($4 = '' OR email ILIKE $4 ESCAPE '\' OR full_name ILIKE $4 ESCAPE '\')
+	  AND ($5::text IS NULL OR mood_trend = $5)
+	  AND ($6::int  IS NULL OR last_activity_at IS NULL OR last_activity_at < NOW() - make_interval(days => $6::int))
+`
+
+var caseloadSortColumns = map[therapistdomain.CaseloadSort]string{
+	therapistdomain.SortByName:              "full_name",
+	therapistdomain.SortByLastActivity:      "last_activity_at",
+	therapistdomain.SortByToolStreak:        "tool_streak",
+	therapistdomain.SortByLatestScore:       "latest_score",
+	therapistdomain.SortByAvgScore:          "avg_score",
+	therapistdomain.SortByExercisesThisWeek: "exercises_this_week",
+}
+
+func (r *PostgresTherapistRepo) ListCaseload(ctx context.Context, therapistID uuid.UUID, filter therapistdomain.CaseloadFilter) ([]*therapistdomain.CaseloadClient, int, error) {
+	column, ok := caseloadSortColumns[filter.Sort]
+	if !ok {
+		return nil, 0, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+
+	query := caseloadCTE + `
+		SELECT link_id, client_id, full_name, email, scopes, linked_at,
+		       last_activity_at, tool_streak, latest_score, avg_score,
+		       exercises_this_week, mood_trend, COUNT(*) OVER ()
+		FROM   caseload
+	` + caseloadWhere + `
+		ORDER  BY ` + column + ` ` + direction + ` NULLS LAST, full_name, client_id
+		LIMIT  $7 OFFSET $8
+	`
+
+	pattern := ""
+	if filter.Query != "" {
+		pattern = "%" + escapeLike(filter.Query) + "%"
+	}
+	var moodTrend *string
+	if filter.MoodTrend != nil {
+		value := string(*filter.MoodTrend)
+		moodTrend = &value
+	}
+	args := []any{
+		therapistID, therapistdomain.CaseloadScoreWindowDays, therapistdomain.MoodTrendThreshold,
+		pattern, moodTrend, filter.InactiveDays,
+	}
+
+	rows, err := r.db.Query(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("query caseload: %w", err)
+	}
+	defer rows.Close()
+
+	clients := make([]*therapistdomain.CaseloadClient, 0)
+	total := 0
+	for rows.Next() {
+		var (
+			client    therapistdomain.CaseloadClient
+			scopes    []string
+			moodTrend *string
+		)
+		err := rows.Scan(
+			&client.LinkID, &client.ClientID, &client.FullName, &client.Email, &scopes, &client.LinkedAt,
+			&client.LastActivityAt, &client.CurrentToolStreak, &client.LatestStutterScore, &client.AvgStutterScore,
+			&client.ExercisesThisWeek, &moodTrend, &total,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan caseload row: %w", err)
+		}
+		for _, scope := range scopes {
+			client.Scopes = append(client.Scopes, therapistdomain.Scope(scope))
+		}
+		if moodTrend != nil {
+			trend := therapistdomain.MoodTrend(*moodTrend)
+			client.MoodTrend = &trend
+		}
+		clients = append(clients, &client)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("iterate caseload rows: %w", err)
+	}
+
+	// COUNT(*) OVER () is only available on returned rows, so a page past
+	// the end needs its own count.
+	if len(clients) == 0 && filter.Offset > 0 {
+		countQuery := caseloadCTE + `SELECT COUNT(*) FROM caseload ` + caseloadWhere
+		if err := r.db.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+			return nil, 0, fmt.Errorf("count caseload: %w", err)
+		}
+	}
+
+	return clients, total, nil
+}
+
+// escapeLike makes user input match literally inside an ILIKE pattern.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
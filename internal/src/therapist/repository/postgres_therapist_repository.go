@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+var _ TherapistRepository = (*PostgresTherapistRepo)(nil)
+
+const pgUniqueViolation = "23505"
+
+type PostgresTherapistRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresTherapistRepo(db *pgxpool.Pool) *PostgresTherapistRepo {
+	return &PostgresTherapistRepo{db: db}
+}
+
+func (r *PostgresTherapistRepo) CreateLinkCode(ctx context.Context, therapistID uuid.UUID, codeHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO therapist_link_codes (code_hash, therapist_id, expires_at)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := r.db.Exec(ctx, query, codeHash, therapistID, expiresAt); err != nil {
+		return fmt.Errorf("create link code: %w", err)
+	}
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM therapist_link_codes WHERE expires_at < NOW()`); err != nil {
+		return fmt.Errorf("prune expired link codes: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresTherapistRepo) CountActiveLinkCodes(ctx context.Context, therapistID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM   therapist_link_codes
+		WHERE  therapist_id = $1
+		  AND  expires_at > NOW()
+	`
+	var count int
+	if err := r.db.QueryRow(ctx, query, therapistID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("count link codes: %w", err)
+	}
+	return count, nil
+}
+
+// FindLinkCodeTherapist only resolves codes that are unexpired and still
+// belong to an active therapist account.
+func (r *PostgresTherapistRepo) FindLinkCodeTherapist(ctx context.Context, codeHash string) (uuid.UUID, error) {
+	query := `
+		SELECT c.therapist_id
+		FROM   therapist_link_codes c
+		JOIN   users u ON u.id = c.therapist_id
+		WHERE  c.code_hash  = $1
+		  AND  c.expires_at > NOW()
+		  AND  u.role   = 'therapist'
+		  AND  u.status = 'active'
+	`
+	var therapistID uuid.UUID
+	if err := r.db.QueryRow(ctx, query, codeHash).Scan(&therapistID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, therapistdomain.ErrInvalidLinkCode
+		}
+		return uuid.Nil, fmt.Errorf("find link code: %w", err)
+	}
+	return therapistID, nil
+}
+
+func (r *PostgresTherapistRepo) CreateLink(ctx context.Context, link *therapistdomain.Link) error {
+	query := `
+		INSERT INTO therapist_clients (id, therapist_id, client_id, scopes, status, linked_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query,
+		link.ID, link.TherapistID, link.ClientID, scopesToStrings(link.Scopes),
+		string(link.Status), link.LinkedAt, link.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return therapistdomain.ErrAlreadyLinked
+		}
+		return fmt.Errorf("create therapist link: %w", err)
+	}
+	return nil
+}
+
+const linkSelectColumns = `
+	l.id, l.therapist_id, l.client_id, l.scopes, l.status, l.linked_at, l.revoked_at, l.updated_at,
+	t.full_name, t.email, c.full_name, c.email
+`
+
+const linkJoins = `
+	FROM therapist_clients l
+	JOIN users t ON t.id = l.therapist_id
+	JOIN users c ON c.id = l.client_id
+`
+
+func scanLink(row pgx.Row) (*therapistdomain.Link, error) {
+	var (
+		link   therapistdomain.Link
+		scopes []string
+		status string
+	)
+	err := row.Scan(
+		&link.ID, &link.TherapistID, &link.ClientID, &scopes, &status,
+		&link.LinkedAt, &link.RevokedAt, &link.UpdatedAt,
+		&link.TherapistName, &link.TherapistEmail, &link.ClientName, &link.ClientEmail,
+	)
+	if err != nil {
+		return nil, err
+	}
+	link.Status = therapistdomain.LinkStatus(status)
+	link.Scopes = make([]therapistdomain.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		link.Scopes = append(link.Scopes, therapistdomain.Scope(scope))
+	}
+	return &link, nil
+}
+
+func (r *PostgresTherapistRepo) FindLinkByID(ctx context.Context, linkID uuid.UUID) (*therapistdomain.Link, error) {
+	query := `SELECT ` + linkSelectColumns + linkJoins + ` WHERE l.id = $1`
+	link, err := scanLink(r.db.QueryRow(ctx, query, linkID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, therapistdomain.ErrLinkNotFound
+		}
+		return nil, fmt.Errorf("find therapist link: %w", err)
+	}
+	return link, nil
+}
+
+// FindActiveLink hides links to deleted client accounts, so a therapist
+// loses access as soon as the client deletes their account.
+func (r *PostgresTherapistRepo) FindActiveLink(ctx context.Context, therapistID, clientID uuid.UUID) (*therapistdomain.Link, error) {
+	query := `SELECT ` + linkSelectColumns + linkJoins + `
+		WHERE l.therapist_id = $1
+		  AND l.client_id    = $2
+		  AND l.status       = 'active'
+		  AND c.status      <> 'deleted'
+	`
+	link, err := scanLink(r.db.QueryRow(ctx, query, therapistID, clientID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, therapistdomain.ErrClientNotLinked
+		}
+		return nil, fmt.Errorf("find active therapist link: %w", err)
+	}
+	return link, nil
+}
+
+func (r *PostgresTherapistRepo) ListActiveLinksByClient(ctx context.Context, clientID uuid.UUID) ([]*therapistdomain.Link, error) {
+	query := `SELECT ` + linkSelectColumns + linkJoins + `
+		WHERE l.client_id = $1
+		  AND l.status    = 'active'
+		ORDER BY l.linked_at DESC
+	`
+	rows, err := r.db.Query(ctx, query, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("query therapist links: %w", err)
+	}
+	defer rows.Close()
+
+	links := make([]*therapistdomain.Link, 0)
+	for rows.Next() {
+		link, err := scanLink(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan therapist link: %w", err)
+		}
+		links = append(links, link)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate therapist links: %w", err)
+	}
+	return links, nil
+}
+
+func (r *PostgresTherapistRepo) UpdateLinkScopes(ctx context.Context, linkID uuid.UUID, scopes []therapistdomain.Scope, updatedAt time.Time) error {
+	query := `
+		UPDATE therapist_clients
+		SET    scopes     = $2,
+		       updated_at = $3
+		WHERE  id     = $1
+		  AND  status = 'active'
+	`
+	tag, err := r.db.Exec(ctx, query, linkID, scopesToStrings(scopes), updatedAt)
+	if err != nil {
+		return fmt.Errorf("update link scopes: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return therapistdomain.ErrLinkNotFound
+	}
+	return nil
+}
+
+func (r *PostgresTherapistRepo) RevokeLink(ctx context.Context, linkID uuid.UUID, revokedAt time.Time) error {
+	query := `
+		UPDATE therapist_clients
+		SET    status     = 'revoked',
+		       revoked_at = $2,
+		       updated_at = $2
+		WHERE  id     = $1
+		  AND  status = 'active'
+	`
+	tag, err := r.db.Exec(ctx, query, linkID, revokedAt)
+	if err != nil {
+		return fmt.Errorf("revoke therapist link: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return therapistdomain.ErrLinkNotFound
+	}
+	return nil
+}
+
+func scopesToStrings(scopes []therapistdomain.Scope) []string {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		out = append(out, string(scope))
+	}
+	return out
+}
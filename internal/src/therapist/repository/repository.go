@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+type TherapistRepository interface {
+	CreateLinkCode(ctx context.Context, therapistID uuid.UUID, codeHash string, expiresAt time.Time) error
+
+	CountActiveLinkCodes(ctx context.Context, therapistID uuid.UUID) (int, error)
+
+	FindLinkCodeTherapist(ctx context.Context, codeHash string) (uuid.UUID, error)
+
+	CreateLink(ctx context.Context, link *therapistdomain.Link) error
+
+	FindLinkByID(ctx context.Context, linkID uuid.UUID) (*therapistdomain.Link, error)
+
+	FindActiveLink(ctx context.Context, therapistID, clientID uuid.UUID) (*therapistdomain.Link, error)
+
+	ListActiveLinksByClient(ctx context.Context, clientID uuid.UUID) ([]*therapistdomain.Link, error)
+
+	UpdateLinkScopes(ctx context.Context, linkID uuid.UUID, scopes []therapistdomain.Scope, updatedAt time.Time) error
+
+	RevokeLink(ctx context.Context, linkID uuid.UUID, revokedAt time.Time) error
+
+	ListCaseload(ctx context.Context, therapistID uuid.UUID, filter therapistdomain.CaseloadFilter) ([]*therapistdomain.CaseloadClient, int, error)
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+)
+
+// ListClients returns the therapist's caseload dashboard. Sorting defaults
+// to most recently active first; names sort A–Z unless told otherwise.
+func (uc *TherapistUseCase) ListClients(ctx context.Context, therapistID uuid.UUID, filter therapistdomain.CaseloadFilter) (therapistdomain.CaseloadPage, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Sort == "" {
+		filter.Sort = therapistdomain.SortByLastActivity
+		filter.Descending = true
+	}
+	if !filter.Sort.IsValid() {
+		return therapistdomain.CaseloadPage{}, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	if filter.MoodTrend != nil && !filter.MoodTrend.IsValid() {
+		return therapistdomain.CaseloadPage{}, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	if filter.InactiveDays != nil && *filter.InactiveDays < 0 {
+		return therapistdomain.CaseloadPage{}, therapistdomain.ErrInvalidCaseloadFilter
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = therapistdomain.DefaultCaseloadPageSize
+	}
+	if filter.Limit > therapistdomain.MaxCaseloadPageSize {
+		filter.Limit = therapistdomain.MaxCaseloadPageSize
+	}
+	if filter.Offset < 0 {
+		return therapistdomain.CaseloadPage{}, therapistdomain.ErrInvalidCaseloadFilter
+	}
+
+	clients, total, err := uc.therapistRepo.ListCaseload(ctx, therapistID, filter)
+	if err != nil {
+		if errors.Is(err, therapistdomain.ErrInvalidCaseloadFilter) {
+			return therapistdomain.CaseloadPage{}, err
+		}
+		return therapistdomain.CaseloadPage{}, fmt.Errorf("list caseload: %w", err)
+	}
+
+	return therapistdomain.CaseloadPage{
+		Clients: clients,
+		Total:   total,
+		Limit:   filter.Limit,
+		Offset:  filter.Offset,
+	}, nil
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	"saythis-backend/internal/src/auth"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	userdomain "saythis-backend/internal/src/user/domain"
+)
+
+// CreateLinkCode issues a short code the therapist can hand to a client.
+// Codes may be redeemed by several clients until they expire; only their
+// hash is stored.
+func (uc *TherapistUseCase) CreateLinkCode(ctx context.Context, therapistID uuid.UUID) (therapistdomain.LinkCode, error) {
+	active, err := uc.therapistRepo.CountActiveLinkCodes(ctx, therapistID)
+	if err != nil {
+		return therapistdomain.LinkCode{}, fmt.Errorf("count link codes: %w", err)
+	}
+	if active >= therapistdomain.MaxActiveLinkCodes {
+		return therapistdomain.LinkCode{}, therapistdomain.ErrTooManyLinkCodes
+	}
+
+	code, err := therapistdomain.GenerateLinkCode(rand.Reader)
+	if err != nil {
+		return therapistdomain.LinkCode{}, fmt.Errorf("generate link code: %w", err)
+	}
+	expiresAt := time.Now().UTC().Add(therapistdomain.LinkCodeTTL)
+
+	if err = uc.therapistRepo.CreateLinkCode(ctx, therapistID, hashLinkCode(code), expiresAt); err != nil {
+		return therapistdomain.LinkCode{}, fmt.Errorf("create link code: %w", err)
+	}
+	return therapistdomain.LinkCode{Code: code, ExpiresAt: expiresAt}, nil
+}
+
+// LinkTherapist connects the client to a therapist identified either by a
+// link code or by the therapist's account email, granting the given scopes.
+func (uc *TherapistUseCase) LinkTherapist(ctx context.Context, clientID uuid.UUID, code, email string, scopes []therapistdomain.Scope, ip string) (*therapistdomain.Link, error) {
+	scopes, err := therapistdomain.NormalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	therapistID, err := uc.resolveTherapist(ctx, code, email)
+	if err != nil {
+		return nil, err
+	}
+	if therapistID == clientID {
+		return nil, therapistdomain.ErrCannotLinkSelf
+	}
+
+	now := time.Now().UTC()
+	link := &therapistdomain.Link{
+		ID:          uuid.New(),
+		TherapistID: therapistID,
+		ClientID:    clientID,
+		Scopes:      scopes,
+		Status:      therapistdomain.LinkActive,
+		LinkedAt:    now,
+		UpdatedAt:   now,
+	}
+	if err = uc.therapistRepo.CreateLink(ctx, link); err != nil {
+		if errors.Is(err, therapistdomain.ErrAlreadyLinked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("create link: %w", err)
+	}
+
+	uc.audit(ctx, clientID, auditdomain.ActionTherapistLinked, link.ID, map[string]any{
+		"therapist_id": therapistID,
+		"scopes":       scopes,
+	}, ip)
+
+	return uc.findLink(ctx, link.ID)
+}
+
+func (uc *TherapistUseCase) resolveTherapist(ctx context.Context, code, email string) (uuid.UUID, error) {
+	code = strings.TrimSpace(code)
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	switch {
+	case code != "":
+		therapistID, err := uc.therapistRepo.FindLinkCodeTherapist(ctx, hashLinkCode(code))
+		if err != nil {
+			if errors.Is(err, therapistdomain.ErrInvalidLinkCode) {
+				return uuid.Nil, err
+			}
+			return uuid.Nil, fmt.Errorf("find link code: %w", err)
+		}
+		return therapistID, nil
+
+	case email != "":
+		user, err := uc.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, userdomain.ErrUserNotFound) {
+				return uuid.Nil, therapistdomain.ErrTherapistNotFound
+			}
+			return uuid.Nil, fmt.Errorf("find therapist: %w", err)
+		}
+		if user.Role() != userdomain.RoleTherapist || user.Status() != userdomain.StatusActive {
+			return uuid.Nil, therapistdomain.ErrTherapistNotFound
+		}
+		return user.ID(), nil
+
+	default:
+		return uuid.Nil, therapistdomain.ErrEmptyLinkTarget
+	}
+}
+
+func (uc *TherapistUseCase) ListTherapists(ctx context.Context, clientID uuid.UUID) ([]*therapistdomain.Link, error) {
+	links, err := uc.therapistRepo.ListActiveLinksByClient(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("list therapist links: %w", err)
+	}
+	return links, nil
+}
+
+// UpdateConsent replaces the scopes a client has granted on one of their
+// links. Narrowing takes effect on the therapist's next request.
+func (uc *TherapistUseCase) UpdateConsent(ctx context.Context, clientID, linkID uuid.UUID, scopes []therapistdomain.Scope, ip string) (*therapistdomain.Link, error) {
+	scopes, err := therapistdomain.NormalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := uc.findClientLink(ctx, clientID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = uc.therapistRepo.UpdateLinkScopes(ctx, link.ID, scopes, time.Now().UTC()); err != nil {
+		if errors.Is(err, therapistdomain.ErrLinkNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("update link scopes: %w", err)
+	}
+
+	uc.audit(ctx, clientID, auditdomain.ActionTherapistScopesChanged, link.ID, map[string]any{
+		"therapist_id": link.TherapistID,
+		"from":         link.Scopes,
+		"to":           scopes,
+	}, ip)
+
+	return uc.findLink(ctx, link.ID)
+}
+
+// RevokeLink is the client ending a therapist's access.
+func (uc *TherapistUseCase) RevokeLink(ctx context.Context, clientID, linkID uuid.UUID, ip string) error {
+	link, err := uc.findClientLink(ctx, clientID, linkID)
+	if err != nil {
+		return err
+	}
+	return uc.revoke(ctx, clientID, link, ip)
+}
+
+// RemoveClient is the therapist ending a link from their side.
+func (uc *TherapistUseCase) RemoveClient(ctx context.Context, therapistID, clientID uuid.UUID, ip string) error {
+	link, err := uc.therapistRepo.FindActiveLink(ctx, therapistID, clientID)
+	if err != nil {
+		if errors.Is(err, therapistdomain.ErrClientNotLinked) {
+			return err
+		}
+		return fmt.Errorf("find link: %w", err)
+	}
+	return uc.revoke(ctx, therapistID, link, ip)
+}
+
+// RequireClientScope returns the active link between therapist and client
+// only if the client has granted the scope. Unlinked clients are reported
+// as not found so therapists cannot probe for account IDs.
+func (uc *TherapistUseCase) RequireClientScope(ctx context.Context, therapistID, clientID uuid.UUID, scope therapistdomain.Scope) (*therapistdomain.Link, error) {
+	link, err := uc.therapistRepo.FindActiveLink(ctx, therapistID, clientID)
+	if err != nil {
+		if errors.Is(err, therapistdomain.ErrClientNotLinked) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("find link: %w", err)
+	}
+	if !link.HasScope(scope) {
+		return nil, therapistdomain.ErrScopeNotGranted
+	}
+	return link, nil
+}
+
+func (uc *TherapistUseCase) revoke(ctx context.Context, actorID uuid.UUID, link *therapistdomain.Link, ip string) error {
+	if err := uc.therapistRepo.RevokeLink(ctx, link.ID, time.Now().UTC()); err != nil {
+		if errors.Is(err, therapistdomain.ErrLinkNotFound) {
+			return err
+		}
+		return fmt.Errorf("revoke link: %w", err)
+	}
+
+	uc.audit(ctx, actorID, auditdomain.ActionTherapistUnlinked, link.ID, map[string]any{
+		"therapist_id": link.TherapistID,
+		"client_id":    link.ClientID,
+	}, ip)
+	return nil
+}
+
+func (uc *TherapistUseCase) findClientLink(ctx context.Context, clientID, linkID uuid.UUID) (*therapistdomain.Link, error) {
+	link, err := uc.findLink(ctx, linkID)
+	if err != nil {
+		return nil, err
+	}
+	if link.ClientID != clientID || link.Status != therapistdomain.LinkActive {
+		return nil, therapistdomain.ErrLinkNotFound
+	}
+	return link, nil
+}
+
+func (uc *TherapistUseCase) findLink(ctx context.Context, linkID uuid.UUID) (*therapistdomain.Link, error) {
+	link, err := uc.therapistRepo.FindLinkByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, therapistdomain.ErrLinkNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("find link: %w", err)
+	}
+	return link, nil
+}
+
+// hashLinkCode ignores case, spaces and dashes so "abcd efgh" matches the
+// issued "ABCD-EFGH".
+func hashLinkCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+	return auth.HashToken(normalized)
+}
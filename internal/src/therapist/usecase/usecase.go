@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	auditdomain "saythis-backend/internal/src/audit/domain"
+	auditrepo "saythis-backend/internal/src/audit/repository"
+	therapistrepo "saythis-backend/internal/src/therapist/repository"
+	userrepo "saythis-backend/internal/src/user/repository"
+)
+
+type TherapistUseCase struct {
+	therapistRepo therapistrepo.TherapistRepository
+	userRepo      userrepo.UserRepository
+	auditRepo     auditrepo.AuditRepository
+}
+
+func NewTherapistUseCase(
+	therapistRepo therapistrepo.TherapistRepository,
+	userRepo userrepo.UserRepository,
+	auditRepo auditrepo.AuditRepository,
+) *TherapistUseCase {
+	return &TherapistUseCase{
+		therapistRepo: therapistRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+func (uc *TherapistUseCase) audit(ctx context.Context, actorID uuid.UUID, action string, linkID uuid.UUID, metadata map[string]any, ip string) {
+	entry := auditdomain.NewEntry(&actorID, action, auditdomain.TargetLink, &linkID, metadata, ip)
+	if err := uc.auditRepo.Record(ctx, entry); err != nil {
+		slog.Error("therapist: failed to write audit entry",
+			"action", action,
+			"actor_id", actorID,
+			"link_id", linkID,
+			"error", err,
+		)
+	}
+}
@@ -0,0 +1,32 @@
+// Package catalog embeds the therapy curriculum shipped with the server.
+// Bump the version field whenever chapters or exercises change so clients
+// know to refresh their cached copy.
+package catalog
+
+import (
+	_ "embed"
+	"fmt"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+//go:embed curriculum.json
+var curriculum []byte
+
+func Load() (*therapydomain.Catalog, error) {
+	catalog, err := therapydomain.ParseCatalog(curriculum)
+	if err != nil {
+		return nil, fmt.Errorf("load embedded curriculum: %w", err)
+	}
+	return catalog, nil
+}
+
+// MustLoad panics if the embedded curriculum is invalid. The file is
+// compiled into the binary, so this can only fail on a bad release.
+func MustLoad() *therapydomain.Catalog {
+	catalog, err := Load()
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
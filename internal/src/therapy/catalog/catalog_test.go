@@ -0,0 +1,17 @@
+package catalog_test
+
+import (
+	"testing"
+
+	"saythis-backend/internal/src/therapy/catalog"
+)
+
+func TestLoad_EmbeddedCurriculumIsValid(t *testing.T) {
+	c, err := catalog.Load()
+	if err != nil {
+		t.Fatalf("embedded curriculum failed validation: %v", err)
+	}
+	if c.Version == "" || len(c.Chapters) == 0 {
+		t.Fatalf("want a versioned catalog with chapters, got %+v", c)
+	}
+}
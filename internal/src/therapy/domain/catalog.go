@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	statsdomain "saythis-backend/internal/src/stats/domain"
+)
+
+// Catalog is the versioned therapy curriculum. It is loaded once at start
+// up and treated as read-only afterwards.
+type Catalog struct {
+	Version  string    `json:"version"`
+	Chapters []Chapter `json:"chapters"`
+
+	exercises map[string]*Exercise
+	chapters  map[string]*Chapter
+}
+
+type Chapter struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Order       int        `json:"order"`
+	Exercises   []Exercise `json:"exercises"`
+}
+
+type Exercise struct {
+	ID               string   `json:"id"`
+	ChapterID        string   `json:"-"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Order            int      `json:"order"`
+	EstimatedMinutes int      `json:"estimated_minutes"`
+	Prerequisites    []string `json:"prerequisites"`
+	ToolType         string   `json:"tool_type,omitempty"`
+}
+
+// ParseCatalog decodes and validates a catalog document. Chapters and
+// exercises are sorted by their order field; IDs must be unique across the
+// whole catalog and prerequisites must refer to exercises that exist
+// without forming a cycle.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var catalog Catalog
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("decode catalog: %w", err)
+	}
+	if strings.TrimSpace(catalog.Version) == "" {
+		return nil, fmt.Errorf("catalog: version is required")
+	}
+	if len(catalog.Chapters) == 0 {
+		return nil, fmt.Errorf("catalog: no chapters")
+	}
+
+	sort.SliceStable(catalog.Chapters, func(i, j int) bool {
+		return catalog.Chapters[i].Order < catalog.Chapters[j].Order
+	})
+
+	catalog.chapters = make(map[string]*Chapter)
+	catalog.exercises = make(map[string]*Exercise)
+	for i := range catalog.Chapters {
+		chapter := &catalog.Chapters[i]
+		if chapter.ID == "" || chapter.Title == "" {
+			return nil, fmt.Errorf("catalog: chapter %d needs an id and title", i)
+		}
+		if _, dup := catalog.chapters[chapter.ID]; dup {
+			return nil, fmt.Errorf("catalog: duplicate chapter %q", chapter.ID)
+		}
+		if len(chapter.Exercises) == 0 {
+			return nil, fmt.Errorf("catalog: chapter %q has no exercises", chapter.ID)
+		}
+		catalog.chapters[chapter.ID] = chapter
+
+		sort.SliceStable(chapter.Exercises, func(i, j int) bool {
+			return chapter.Exercises[i].Order < chapter.Exercises[j].Order
+		})
+		for j := range chapter.Exercises {
+			exercise := &chapter.Exercises[j]
+			exercise.ChapterID = chapter.ID
+			if exercise.ID == "" || exercise.Title == "" {
+				return nil, fmt.Errorf("catalog: exercise %d in %q needs an id and title", j, chapter.ID)
+			}
+			if _, dup := catalog.exercises[exercise.ID]; dup {
+				return nil, fmt.Errorf("catalog: duplicate exercise %q", exercise.ID)
+			}
+			if exercise.EstimatedMinutes <= 0 {
+				return nil, fmt.Errorf("catalog: exercise %q needs estimated_minutes", exercise.ID)
+			}
+			if exercise.ToolType != "" && !statsdomain.IsValidToolType(exercise.ToolType) {
+				return nil, fmt.Errorf("catalog: exercise %q has unknown tool type %q", exercise.ID, exercise.ToolType)
+			}
+			if exercise.Prerequisites == nil {
+				exercise.Prerequisites = []string{}
+			}
+			catalog.exercises[exercise.ID] = exercise
+		}
+	}
+
+	if err := catalog.checkPrerequisites(); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+func (c *Catalog) checkPrerequisites() error {
+	for _, exercise := range c.exercises {
+		for _, prerequisite := range exercise.Prerequisites {
+			if _, ok := c.exercises[prerequisite]; !ok {
+				return fmt.Errorf("catalog: exercise %q requires unknown exercise %q", exercise.ID, prerequisite)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(c.exercises))
+	var visit func(id string) error
+	visit = func(id string) error {
+		switch state[id] {
+		case visiting:
+			return fmt.Errorf("catalog: prerequisite cycle through %q", id)
+		case done:
+			return nil
+		}
+		state[id] = visiting
+		for _, prerequisite := range c.exercises[id].Prerequisites {
+			if err := visit(prerequisite); err != nil {
+				return err
+			}
+		}
+		state[id] = done
+		return nil
+	}
+	for id := range c.exercises {
+		if err := visit(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) Chapter(id string) (*Chapter, bool) {
+	chapter, ok := c.chapters[id]
+	return chapter, ok
+}
+
+func (c *Catalog) Exercise(id string) (*Exercise, bool) {
+	exercise, ok := c.exercises[id]
+	return exercise, ok
+}
+
+// ValidateExercise checks that both IDs exist and that the exercise
+// belongs to the chapter.
+func (c *Catalog) ValidateExercise(chapterID, exerciseID string) (*Exercise, error) {
+	if _, ok := c.chapters[chapterID]; !ok {
+		return nil, ErrUnknownChapter
+	}
+	exercise, ok := c.exercises[exerciseID]
+	if !ok {
+		return nil, ErrUnknownExercise
+	}
+	if exercise.ChapterID != chapterID {
+		return nil, ErrExerciseChapterMismatch
+	}
+	return exercise, nil
+}
@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+const testCatalog = `{
+  "version": "test",
+  "chapters": [
+    {"id": "b", "title": "B", "order": 2, "exercises": [
+      {"id": "b1", "title": "B1", "order": 1, "estimated_minutes": 5, "prerequisites": ["a2"]}
+    ]},
+    {"id": "a", "title": "A", "order": 1, "exercises": [
+      {"id": "a2", "title": "A2", "order": 2, "estimated_minutes": 5, "prerequisites": ["a1"], "tool_type": "DAF"},
+      {"id": "a1", "title": "A1", "order": 1, "estimated_minutes": 5}
+    ]}
+  ]
+}`
+
+func TestParseCatalog_SortsAndIndexes(t *testing.T) {
+	c, err := therapydomain.ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.Chapters[0].ID != "a" || c.Chapters[0].Exercises[0].ID != "a1" {
+		t.Errorf("chapters and exercises should be ordered, got %+v", c.Chapters)
+	}
+	if exercise, ok := c.Exercise("b1"); !ok || exercise.ChapterID != "b" {
+		t.Errorf("want b1 indexed under chapter b, got %+v", exercise)
+	}
+}
+
+func TestCatalog_ValidateExercise(t *testing.T) {
+	c, err := therapydomain.ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		chapter, exercise string
+		want              error
+	}{
+		{"a", "a1", nil},
+		{"z", "a1", therapydomain.ErrUnknownChapter},
+		{"a", "zz", therapydomain.ErrUnknownExercise},
+		{"a", "b1", therapydomain.ErrExerciseChapterMismatch},
+	}
+	for _, tc := range cases {
+		if _, err := c.ValidateExercise(tc.chapter, tc.exercise); !errors.Is(err, tc.want) {
+			t.Errorf("%s/%s: want %v, got %v", tc.chapter, tc.exercise, tc.want, err)
+		}
+	}
+}
+
+func TestParseCatalog_RejectsBadPrerequisites(t *testing.T) {
+	unknown := strings.Replace(testCatalog, `["a1"]`, `["nope"]`, 1)
+	if _, err := therapydomain.ParseCatalog([]byte(unknown)); err == nil {
+		t.Error("want an error for an unknown prerequisite")
+	}
+
+	cycle := strings.Replace(testCatalog, `"estimated_minutes": 5}`, `"estimated_minutes": 5, "prerequisites": ["a2"]}`, 1)
+	if _, err := therapydomain.ParseCatalog([]byte(cycle)); err == nil {
+		t.Error("want an error for a prerequisite cycle")
+	}
+}
+
+func TestCatalog_Progress(t *testing.T) {
+	c, err := therapydomain.ParseCatalog([]byte(testCatalog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	progress := c.Progress(map[string]bool{"a1": true, "retired": true})
+	if progress.Completed != 1 || progress.Total != 3 || progress.Percent != 33.3 {
+		t.Errorf("want 1/3 (33.3%%), got %d/%d (%v%%)", progress.Completed, progress.Total, progress.Percent)
+	}
+	if progress.NextExercise == nil || progress.NextExercise.ID != "a2" {
+		t.Errorf("want a2 recommended next, got %+v", progress.NextExercise)
+	}
+	if !progress.Chapters[0].Unlocked || progress.Chapters[1].Unlocked {
+		t.Errorf("want chapter a unlocked and b locked, got %+v", progress.Chapters)
+	}
+	if c.IsUnlocked("b1", map[string]bool{"a1": true}) {
+		t.Error("b1 should stay locked until a2 is complete")
+	}
+
+	done := c.Progress(map[string]bool{"a1": true, "a2": true, "b1": true})
+	if done.Percent != 100 || done.NextExercise != nil {
+		t.Errorf("want a finished course with nothing next, got %+v", done)
+	}
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+type ChapterProgress struct {
+	ChapterID string
+	Completed int
+	Total     int
+	Percent   float64
+	Unlocked  bool
+}
+
+// CourseProgress is a user's position in the curriculum. Completed
+// exercises that are no longer in the catalog are ignored.
+type CourseProgress struct {
+	Chapters     []ChapterProgress
+	Completed    int
+	Total        int
+	Percent      float64
+	NextExercise *Exercise
+}
+
+// ProgressOverview is what GetProgress returns: the latest attempt at each
+// exercise plus where that leaves the user in the course.
+type ProgressOverview struct {
+	Exercises []*ExerciseSummary
+	Course    CourseProgress
+}
+
+// IsUnlocked reports whether every prerequisite of the exercise has been
+// completed. Unknown exercises are never unlocked.
+func (c *Catalog) IsUnlocked(exerciseID string, completed map[string]bool) bool {
+	exercise, ok := c.exercises[exerciseID]
+	if !ok {
+		return false
+	}
+	for _, prerequisite := range exercise.Prerequisites {
+		if !completed[prerequisite] {
+			return false
+		}
+	}
+	return true
+}
+
+// Progress scores each chapter and the whole course. A chapter counts as
+// unlocked once any of its exercises can be started. The recommended next
+// exercise is the first unlocked, uncompleted one in curriculum order.
+func (c *Catalog) Progress(completed map[string]bool) CourseProgress {
+	progress := CourseProgress{Chapters: make([]ChapterProgress, 0, len(c.Chapters))}
+
+	for i := range c.Chapters {
+		chapter := &c.Chapters[i]
+		cp := ChapterProgress{ChapterID: chapter.ID, Total: len(chapter.Exercises)}
+
+		for j := range chapter.Exercises {
+			exercise := &chapter.Exercises[j]
+			unlocked := c.IsUnlocked(exercise.ID, completed)
+			if completed[exercise.ID] {
+				cp.Completed++
+			} else if unlocked && progress.NextExercise == nil {
+				progress.NextExercise = exercise
+			}
+			if completed[exercise.ID] || unlocked {
+				cp.Unlocked = true
+			}
+		}
+
+		cp.Percent = percentOf(cp.Completed, cp.Total)
+		progress.Completed += cp.Completed
+		progress.Total += cp.Total
+		progress.Chapters = append(progress.Chapters, cp)
+	}
+
+	progress.Percent = percentOf(progress.Completed, progress.Total)
+	return progress
+}
+
+func percentOf(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*1000) / 10
+}
+
+// ProgressVersion identifies the state of a user's attempts. The count
+// catches an attempt that commits after a later one.
+type ProgressVersion struct {
+	Attempts        int
+	LastCompletedAt *time.Time
+}
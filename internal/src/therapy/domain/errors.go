@@ -6,4 +6,11 @@ var (
 	ErrInvalidChapterID  = errors.New("chapter_id must not be empty")
 	ErrInvalidExerciseID = errors.New("exercise_id must not be empty")
 	ErrInvalidRating     = errors.New("rating must be between 1 and 5")
+
+	ErrUnknownChapter          = errors.New("unknown chapter_id")
+	ErrUnknownExercise         = errors.New("unknown exercise_id")
+	ErrExerciseChapterMismatch = errors.New("exercise does not belong to this chapter")
+	ErrExerciseLocked          = errors.New("complete this exercise's prerequisites first")
+
+	ErrReviewNotFound = errors.New("review not found")
 )
@@ -0,0 +1,66 @@
+package domain
+
+import "math"
+
+type TrendDirection string
+
+const (
+	TrendImproving TrendDirection = "improving"
+	TrendSteady    TrendDirection = "steady"
+	TrendDeclining TrendDirection = "declining"
+)
+
+// RatingTrend summarises a user's self-ratings across every attempt at
+// one exercise.
+type RatingTrend struct {
+	Attempts     int
+	FirstRating  int
+	LatestRating int
+	BestRating   int
+	AvgRating    float64
+}
+
+// NewRatingTrend expects attempts in the order they were completed.
+func NewRatingTrend(attempts []*ExerciseProgress) RatingTrend {
+	if len(attempts) == 0 {
+		return RatingTrend{}
+	}
+
+	trend := RatingTrend{
+		Attempts:     len(attempts),
+		FirstRating:  attempts[0].Rating(),
+		LatestRating: attempts[len(attempts)-1].Rating(),
+	}
+	sum := 0
+	for _, attempt := range attempts {
+		sum += attempt.Rating()
+		if attempt.Rating() > trend.BestRating {
+			trend.BestRating = attempt.Rating()
+		}
+	}
+	trend.AvgRating = math.Round(float64(sum)/float64(len(attempts))*10) / 10
+	return trend
+}
+
+// Change is how far the latest rating has moved from the first.
+func (t RatingTrend) Change() int {
+	return t.LatestRating - t.FirstRating
+}
+
+func (t RatingTrend) Direction() TrendDirection {
+	switch change := t.Change(); {
+	case change > 0:
+		return TrendImproving
+	case change < 0:
+		return TrendDeclining
+	default:
+		return TrendSteady
+	}
+}
+
+// ExerciseSummary is the latest attempt at an exercise together with the
+// trend across all attempts.
+type ExerciseSummary struct {
+	Latest *ExerciseProgress
+	Trend  RatingTrend
+}
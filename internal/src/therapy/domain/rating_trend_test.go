@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+func attempts(ratings ...int) []*therapydomain.ExerciseProgress {
+	userID := uuid.New()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	out := make([]*therapydomain.ExerciseProgress, 0, len(ratings))
+	for i, rating := range ratings {
+		out = append(out, therapydomain.NewExerciseProgress(userID, "c1", "e1", rating, "", start.AddDate(0, 0, i)))
+	}
+	return out
+}
+
+func TestNewRatingTrend(t *testing.T) {
+	trend := therapydomain.NewRatingTrend(attempts(2, 4, 3))
+
+	if trend.Attempts != 3 || trend.FirstRating != 2 || trend.LatestRating != 3 || trend.BestRating != 4 {
+		t.Errorf("unexpected trend: %+v", trend)
+	}
+	if trend.AvgRating != 3 {
+		t.Errorf("want average 3, got %v", trend.AvgRating)
+	}
+	if trend.Change() != 1 || trend.Direction() != therapydomain.TrendImproving {
+		t.Errorf("want +1 improving, got %+d %s", trend.Change(), trend.Direction())
+	}
+}
+
+func TestRatingTrend_Direction(t *testing.T) {
+	if got := therapydomain.NewRatingTrend(attempts(5, 3)).Direction(); got != therapydomain.TrendDeclining {
+		t.Errorf("want declining, got %s", got)
+	}
+	if got := therapydomain.NewRatingTrend(attempts(4)).Direction(); got != therapydomain.TrendSteady {
+		t.Errorf("want steady for a single attempt, got %s", got)
+	}
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	DefaultEaseFactor = 2.5
+	MinEaseFactor     = 1.3
+
+	// PassingRating is the lowest rating that counts as a successful
+	// review. Anything below restarts the exercise's repetition count.
+	PassingRating = 3
+)
+
+// Review is the SM-2 scheduling state for one exercise. DueOn is a civil
+// date in the user's timezone, stored as UTC midnight.
+type Review struct {
+	ChapterID      string
+	ExerciseID     string
+	Repetitions    int
+	IntervalDays   int
+	EaseFactor     float64
+	LastRating     int
+	LastReviewedAt time.Time
+	DueOn          time.Time
+}
+
+// ReviewQueue is the set of reviews due on or before Date.
+type ReviewQueue struct {
+	Date    time.Time
+	Reviews []*Review
+}
+
+// ScheduleReview applies one SM-2 step to prev (nil for a first attempt)
+// using the 1-5 exercise rating as the response quality. reviewedOn is the
+// local civil date the attempt happened on. Attempts before prev is due are
+// practice: they are recorded but leave the schedule as it is, so repeating
+// an exercise does not push its next review further out.
+func ScheduleReview(
+	prev *Review,
+	chapterID, exerciseID string,
+	rating int,
+	reviewedAt, reviewedOn time.Time,
+) *Review {
+	next := &Review{
+		ChapterID:      chapterID,
+		ExerciseID:     exerciseID,
+		EaseFactor:     DefaultEaseFactor,
+		LastRating:     rating,
+		LastReviewedAt: reviewedAt,
+	}
+	if prev != nil {
+		next.Repetitions = prev.Repetitions
+		next.IntervalDays = prev.IntervalDays
+		next.EaseFactor = prev.EaseFactor
+		if reviewedOn.Before(prev.DueOn) {
+			next.DueOn = prev.DueOn
+			return next
+		}
+	}
+
+	if rating >= PassingRating {
+		switch next.Repetitions {
+		case 0:
+			next.IntervalDays = 1
+		case 1:
+			next.IntervalDays = 6
+		default:
+			next.IntervalDays = int(math.Round(float64(next.IntervalDays) * next.EaseFactor))
+		}
+		next.Repetitions++
+	} else {
+		next.Repetitions = 0
+		next.IntervalDays = 1
+	}
+
+	q := float64(5 - rating)
+	next.EaseFactor = math.Round((next.EaseFactor+0.1-q*(0.08+q*0.02))*100) / 100
+	if next.EaseFactor < MinEaseFactor {
+		next.EaseFactor = MinEaseFactor
+	}
+
+	next.DueOn = reviewedOn.AddDate(0, 0, next.IntervalDays)
+	return next
+}
+
+// OverdueDays is how many days past DueOn the review is on today, or zero
+// if it is not yet overdue.
+func (r *Review) OverdueDays(today time.Time) int {
+	days := int(today.Sub(r.DueOn).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+func TestScheduleReview_SuccessfulRepetitions(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	first := therapydomain.ScheduleReview(nil, "easy-onset", "vowel-onsets", 4, day, day)
+	if first.Repetitions != 1 || first.IntervalDays != 1 || first.EaseFactor != 2.5 {
+		t.Fatalf("unexpected first review: %+v", first)
+	}
+	if want := day.AddDate(0, 0, 1); !first.DueOn.Equal(want) {
+		t.Errorf("want due %v, got %v", want, first.DueOn)
+	}
+
+	second := therapydomain.ScheduleReview(first, "easy-onset", "vowel-onsets", 5, day, first.DueOn)
+	if second.IntervalDays != 6 || second.EaseFactor != 2.6 {
+		t.Fatalf("unexpected second review: %+v", second)
+	}
+
+	third := therapydomain.ScheduleReview(second, "easy-onset", "vowel-onsets", 3, day, second.DueOn)
+	if third.Repetitions != 3 || third.IntervalDays != 16 || third.EaseFactor != 2.46 {
+		t.Fatalf("unexpected third review: %+v", third)
+	}
+}
+
+func TestScheduleReview_FailedRatingResets(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	prev := &therapydomain.Review{Repetitions: 4, IntervalDays: 30, EaseFactor: 1.4}
+
+	next := therapydomain.ScheduleReview(prev, "easy-onset", "vowel-onsets", 1, day, day)
+	if next.Repetitions != 0 || next.IntervalDays != 1 {
+		t.Errorf("want repetitions reset to a one-day interval, got %+v", next)
+	}
+	if next.EaseFactor != therapydomain.MinEaseFactor {
+		t.Errorf("want ease factor clamped to %v, got %v", therapydomain.MinEaseFactor, next.EaseFactor)
+	}
+}
+
+func TestScheduleReview_AttemptsBeforeDueKeepSchedule(t *testing.T) {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	afternoon := day.Add(15 * time.Hour)
+
+	first := therapydomain.ScheduleReview(nil, "easy-onset", "vowel-onsets", 5, day.Add(14*time.Hour), day)
+	repeat := therapydomain.ScheduleReview(first, "easy-onset", "vowel-onsets", 5, afternoon, day)
+	again := therapydomain.ScheduleReview(repeat, "easy-onset", "vowel-onsets", 1, afternoon.Add(time.Hour), day)
+
+	if again.Repetitions != first.Repetitions || again.IntervalDays != first.IntervalDays || again.EaseFactor != first.EaseFactor {
+		t.Errorf("want same-day repeats to keep the schedule %+v, got %+v", first, again)
+	}
+	if !again.DueOn.Equal(first.DueOn) {
+		t.Errorf("want due %v, got %v", first.DueOn, again.DueOn)
+	}
+	if again.LastRating != 1 || !again.LastReviewedAt.Equal(afternoon.Add(time.Hour)) {
+		t.Errorf("want the latest attempt recorded, got rating %d at %v", again.LastRating, again.LastReviewedAt)
+	}
+
+	due := therapydomain.ScheduleReview(again, "easy-onset", "vowel-onsets", 5, first.DueOn, first.DueOn)
+	if due.Repetitions != 2 || due.IntervalDays != 6 {
+		t.Errorf("want the attempt on the due date to advance the schedule, got %+v", due)
+	}
+}
+
+func TestReview_OverdueDays(t *testing.T) {
+	review := &therapydomain.Review{DueOn: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)}
+
+	if got := review.OverdueDays(time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)); got != 3 {
+		t.Errorf("want 3 days overdue, got %d", got)
+	}
+	if got := review.OverdueDays(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)); got != 0 {
+		t.Errorf("want 0 days overdue before the due date, got %d", got)
+	}
+}
@@ -30,6 +30,7 @@ type completeExerciseResponse struct {
 	Rating      int       `json:"rating"`
 	Remarks     string    `json:"remarks"`
 	CompletedAt time.Time `json:"completed_at"`
+	NextReview  string    `json:"next_review_on"`
 }
 
 func (h *CompleteExerciseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -49,7 +50,7 @@ func (h *CompleteExerciseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	progress, err := h.usecase.CompleteExercise(
+	progress, review, err := h.usecase.CompleteExercise(
 		r.Context(),
 		claims.UserID,
 		req.ChapterID,
@@ -69,5 +70,6 @@ func (h *CompleteExerciseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		Rating:      progress.Rating(),
 		Remarks:     progress.Remarks(),
 		CompletedAt: progress.CompletedAt(),
+		NextReview:  review.DueOn.Format(dateLayout),
 	})
 }
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/therapy/usecase"
+)
+
+type GetAttemptsHandler struct {
+	usecase *usecase.TherapyUseCase
+}
+
+func NewGetAttemptsHandler(uc *usecase.TherapyUseCase) *GetAttemptsHandler {
+	return &GetAttemptsHandler{usecase: uc}
+}
+
+type exerciseAttemptItem struct {
+	ID          uuid.UUID `json:"id"`
+	ChapterID   string    `json:"chapter_id"`
+	Rating      int       `json:"rating"`
+	Remarks     string    `json:"remarks"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+type getAttemptsResponse struct {
+	ExerciseID  string                `json:"exercise_id"`
+	Attempts    []exerciseAttemptItem `json:"attempts"`
+	RatingTrend ratingTrendPayload    `json:"rating_trend"`
+}
+
+func (h *GetAttemptsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	exerciseID := r.PathValue("exerciseID")
+	attempts, trend, err := h.usecase.GetAttempts(r.Context(), claims.UserID, exerciseID)
+	if err != nil {
+		status, msg := mapTherapyError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	items := make([]exerciseAttemptItem, 0, len(attempts))
+	for _, a := range attempts {
+		items = append(items, exerciseAttemptItem{
+			ID:          a.ID(),
+			ChapterID:   a.ChapterID(),
+			Rating:      a.Rating(),
+			Remarks:     a.Remarks(),
+			CompletedAt: a.CompletedAt(),
+		})
+	}
+
+	helper.JSON(w, http.StatusOK, getAttemptsResponse{
+		ExerciseID:  exerciseID,
+		Attempts:    items,
+		RatingTrend: toRatingTrendPayload(trend),
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+	"saythis-backend/internal/src/therapy/usecase"
+)
+
+type GetCatalogHandler struct {
+	usecase *usecase.TherapyUseCase
+}
+
+func NewGetCatalogHandler(uc *usecase.TherapyUseCase) *GetCatalogHandler {
+	return &GetCatalogHandler{usecase: uc}
+}
+
+type catalogExercisePayload struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Order            int      `json:"order"`
+	EstimatedMinutes int      `json:"estimated_minutes"`
+	Prerequisites    []string `json:"prerequisites"`
+	ToolType         *string  `json:"tool_type"`
+}
+
+type catalogChapterPayload struct {
+	ID          string                   `json:"id"`
+	Title       string                   `json:"title"`
+	Description string                   `json:"description"`
+	Order       int                      `json:"order"`
+	Exercises   []catalogExercisePayload `json:"exercises"`
+}
+
+type catalogResponse struct {
+	Version  string                  `json:"version"`
+	Chapters []catalogChapterPayload `json:"chapters"`
+}
+
+func (h *GetCatalogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	helper.JSON(w, http.StatusOK, toCatalogResponse(h.usecase.Catalog()))
+}
+
+func toCatalogResponse(catalog *therapydomain.Catalog) catalogResponse {
+	resp := catalogResponse{
+		Version:  catalog.Version,
+		Chapters: make([]catalogChapterPayload, 0, len(catalog.Chapters)),
+	}
+	for _, chapter := range catalog.Chapters {
+		exercises := make([]catalogExercisePayload, 0, len(chapter.Exercises))
+		for _, e := range chapter.Exercises {
+			var toolType *string
+			if e.ToolType != "" {
+				value := e.ToolType
+				toolType = &value
+			}
+			exercises = append(exercises, catalogExercisePayload{
+				ID:               e.ID,
+				Title:            e.Title,
+				Description:      e.Description,
+				Order:            e.Order,
+				EstimatedMinutes: e.EstimatedMinutes,
+				Prerequisites:    e.Prerequisites,
+				ToolType:         toolType,
+			})
+		}
+		resp.Chapters = append(resp.Chapters, catalogChapterPayload{
+			ID:          chapter.ID,
+			Title:       chapter.Title,
+			Description: chapter.Description,
+			Order:       chapter.Order,
+			Exercises:   exercises,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
+	therapistusecase "saythis-backend/internal/src/therapist/usecase"
+	"saythis-backend/internal/src/therapy/usecase"
+)
+
+// GetClientProgressHandler serves a linked client's exercise progress to
+// their therapist, provided the client granted the therapy_progress scope.
+type GetClientProgressHandler struct {
+	usecase   *usecase.TherapyUseCase
+	therapist *therapistusecase.TherapistUseCase
+}
+
+func NewGetClientProgressHandler(uc *usecase.TherapyUseCase, therapist *therapistusecase.TherapistUseCase) *GetClientProgressHandler {
+	return &GetClientProgressHandler{usecase: uc, therapist: therapist}
+}
+
+func (h *GetClientProgressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	clientID, err := uuid.Parse(r.PathValue("clientID"))
+	if err != nil {
+		helper.Error(w, http.StatusBadRequest, "invalid client id")
+		return
+	}
+
+	if _, err = h.therapist.RequireClientScope(r.Context(), claims.UserID, clientID, therapistdomain.ScopeTherapyProgress); err != nil {
+		status, msg := mapTherapyError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	overview, err := h.usecase.GetProgress(r.Context(), clientID)
+	if err != nil {
+		status, msg := mapTherapyError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, toProgressResponse(overview))
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"saythis-backend/internal/helper"
+	"saythis-backend/internal/src/auth"
+	"saythis-backend/internal/src/therapy/usecase"
+)
+
+type GetDueReviewsHandler struct {
+	usecase *usecase.TherapyUseCase
+}
+
+func NewGetDueReviewsHandler(uc *usecase.TherapyUseCase) *GetDueReviewsHandler {
+	return &GetDueReviewsHandler{usecase: uc}
+}
+
+func (h *GetDueReviewsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, ok := auth.ClaimsFromContext(r.Context())
+	if !ok {
+		helper.Error(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	queue, err := h.usecase.GetDueReviews(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapTherapyError(err)
+		helper.Error(w, status, msg)
+		return
+	}
+
+	helper.JSON(w, http.StatusOK, toDueReviewsResponse(queue, h.usecase.Catalog()))
+}
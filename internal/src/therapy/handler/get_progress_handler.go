@@ -2,7 +2,6 @@ package handler
 
 import (
 	"net/http"
-	"time"
 
 	"saythis-backend/internal/helper"
 	"saythis-backend/internal/src/auth"
@@ -17,19 +16,6 @@ func NewGetProgressHandler(uc *usecase.TherapyUseCase) *GetProgressHandler {
 	return &GetProgressHandler{usecase: uc}
 }
 
-type exerciseProgressItem struct {
-	ChapterID   string    `json:"chapter_id"`
-	ExerciseID  string    `json:"exercise_id"`
-	Rating      int       `json:"rating"`
-	Remarks     string    `json:"remarks"`
-	CompletedAt time.Time `json:"completed_at"`
-}
-
-type getProgressResponse struct {
-	CompletedExercises []exerciseProgressItem `json:"completed_exercises"`
-	TotalCompleted     int                    `json:"total_completed"`
-}
-
 func (h *GetProgressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	claims, ok := auth.ClaimsFromContext(r.Context())
 	if !ok {
@@ -37,26 +23,24 @@ func (h *GetProgressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	progressList, err := h.usecase.GetProgress(r.Context(), claims.UserID)
+	version, err := h.usecase.ProgressVersion(r.Context(), claims.UserID)
 	if err != nil {
 		status, msg := mapTherapyError(err)
 		helper.Error(w, status, msg)
 		return
 	}
+	etag := helper.ETag(version)
+	if helper.NotModified(w, r, etag) {
+		return
+	}
 
-	items := make([]exerciseProgressItem, 0, len(progressList))
-	for _, p := range progressList {
-		items = append(items, exerciseProgressItem{
-			ChapterID:   p.ChapterID(),
-			ExerciseID:  p.ExerciseID(),
-			Rating:      p.Rating(),
-			Remarks:     p.Remarks(),
-			CompletedAt: p.CompletedAt(),
-		})
+	overview, err := h.usecase.GetProgress(r.Context(), claims.UserID)
+	if err != nil {
+		status, msg := mapTherapyError(err)
+		helper.Error(w, status, msg)
+		return
 	}
 
-	helper.JSON(w, http.StatusOK, getProgressResponse{
-		CompletedExercises: items,
-		TotalCompleted:     len(items),
-	})
+	helper.SetETag(w, etag)
+	helper.JSON(w, http.StatusOK, toProgressResponse(overview))
 }
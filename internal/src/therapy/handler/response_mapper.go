@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"time"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+const dateLayout = "2006-01-02"
+
+type ratingTrendPayload struct {
+	Attempts      int                          `json:"attempts"`
+	FirstRating   int                          `json:"first_rating"`
+	LatestRating  int                          `json:"latest_rating"`
+	BestRating    int                          `json:"best_rating"`
+	AverageRating float64                      `json:"average_rating"`
+	Change        int                          `json:"change"`
+	Direction     therapydomain.TrendDirection `json:"direction"`
+}
+
+type exerciseProgressItem struct {
+	ChapterID   string             `json:"chapter_id"`
+	ExerciseID  string             `json:"exercise_id"`
+	Rating      int                `json:"rating"`
+	Remarks     string             `json:"remarks"`
+	CompletedAt time.Time          `json:"completed_at"`
+	RatingTrend ratingTrendPayload `json:"rating_trend"`
+}
+
+type chapterProgressPayload struct {
+	ChapterID string  `json:"chapter_id"`
+	Completed int     `json:"completed"`
+	Total     int     `json:"total"`
+	Percent   float64 `json:"percent"`
+	Unlocked  bool    `json:"unlocked"`
+}
+
+type nextExercisePayload struct {
+	ChapterID        string `json:"chapter_id"`
+	ExerciseID       string `json:"exercise_id"`
+	Title            string `json:"title"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+}
+
+type courseProgressPayload struct {
+	Completed    int                      `json:"completed"`
+	Total        int                      `json:"total"`
+	Percent      float64                  `json:"percent"`
+	Chapters     []chapterProgressPayload `json:"chapters"`
+	NextExercise *nextExercisePayload     `json:"next_exercise"`
+}
+
+type getProgressResponse struct {
+	CompletedExercises []exerciseProgressItem `json:"completed_exercises"`
+	TotalCompleted     int                    `json:"total_completed"`
+	Course             courseProgressPayload  `json:"course"`
+}
+
+func toRatingTrendPayload(trend therapydomain.RatingTrend) ratingTrendPayload {
+	return ratingTrendPayload{
+		Attempts:      trend.Attempts,
+		FirstRating:   trend.FirstRating,
+		LatestRating:  trend.LatestRating,
+		BestRating:    trend.BestRating,
+		AverageRating: trend.AvgRating,
+		Change:        trend.Change(),
+		Direction:     trend.Direction(),
+	}
+}
+
+func toCourseProgressPayload(course therapydomain.CourseProgress) courseProgressPayload {
+	chapters := make([]chapterProgressPayload, 0, len(course.Chapters))
+	for _, c := range course.Chapters {
+		chapters = append(chapters, chapterProgressPayload{
+			ChapterID: c.ChapterID,
+			Completed: c.Completed,
+			Total:     c.Total,
+			Percent:   c.Percent,
+			Unlocked:  c.Unlocked,
+		})
+	}
+
+	payload := courseProgressPayload{
+		Completed: course.Completed,
+		Total:     course.Total,
+		Percent:   course.Percent,
+		Chapters:  chapters,
+	}
+	if next := course.NextExercise; next != nil {
+		payload.NextExercise = &nextExercisePayload{
+			ChapterID:        next.ChapterID,
+			ExerciseID:       next.ID,
+			Title:            next.Title,
+			EstimatedMinutes: next.EstimatedMinutes,
+		}
+	}
+	return payload
+}
+
+func toProgressResponse(overview *therapydomain.ProgressOverview) getProgressResponse {
+	items := make([]exerciseProgressItem, 0, len(overview.Exercises))
+	for _, s := range overview.Exercises {
+		items = append(items, exerciseProgressItem{
+			ChapterID:   s.Latest.ChapterID(),
+			ExerciseID:  s.Latest.ExerciseID(),
+			Rating:      s.Latest.Rating(),
+			Remarks:     s.Latest.Remarks(),
+			CompletedAt: s.Latest.CompletedAt(),
+			RatingTrend: toRatingTrendPayload(s.Trend),
+		})
+	}
+	return getProgressResponse{
+		CompletedExercises: items,
+		TotalCompleted:     len(items),
+		Course:             toCourseProgressPayload(overview.Course),
+	}
+}
+
+type dueReviewItem struct {
+	ChapterID    string  `json:"chapter_id"`
+	ExerciseID   string  `json:"exercise_id"`
+	Title        string  `json:"title"`
+	DueOn        string  `json:"due_on"`
+	OverdueDays  int     `json:"overdue_days"`
+	Repetitions  int     `json:"repetitions"`
+	IntervalDays int     `json:"interval_days"`
+	EaseFactor   float64 `json:"ease_factor"`
+	LastRating   int     `json:"last_rating"`
+}
+
+type dueReviewsResponse struct {
+	Date    string          `json:"date"`
+	Reviews []dueReviewItem `json:"reviews"`
+	Total   int             `json:"total"`
+}
+
+func toDueReviewsResponse(queue *therapydomain.ReviewQueue, catalog *therapydomain.Catalog) dueReviewsResponse {
+	items := make([]dueReviewItem, 0, len(queue.Reviews))
+	for _, r := range queue.Reviews {
+		var title string
+		if exercise, ok := catalog.Exercise(r.ExerciseID); ok {
+			title = exercise.Title
+		}
+		items = append(items, dueReviewItem{
+			ChapterID:    r.ChapterID,
+			ExerciseID:   r.ExerciseID,
+			Title:        title,
+			DueOn:        r.DueOn.Format(dateLayout),
+			OverdueDays:  r.OverdueDays(queue.Date),
+			Repetitions:  r.Repetitions,
+			IntervalDays: r.IntervalDays,
+			EaseFactor:   r.EaseFactor,
+			LastRating:   r.LastRating,
+		})
+	}
+	return dueReviewsResponse{
+		Date:    queue.Date.Format(dateLayout),
+		Reviews: items,
+		Total:   len(items),
+	}
+}
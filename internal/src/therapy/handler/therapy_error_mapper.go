@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 
+	therapistdomain "saythis-backend/internal/src/therapist/domain"
 	therapydomain "saythis-backend/internal/src/therapy/domain"
 )
 
@@ -12,9 +13,21 @@ func mapTherapyError(err error) (int, string) {
 
 	case errors.Is(err, therapydomain.ErrInvalidChapterID),
 		errors.Is(err, therapydomain.ErrInvalidExerciseID),
-		errors.Is(err, therapydomain.ErrInvalidRating):
+		errors.Is(err, therapydomain.ErrInvalidRating),
+		errors.Is(err, therapydomain.ErrUnknownChapter),
+		errors.Is(err, therapydomain.ErrUnknownExercise),
+		errors.Is(err, therapydomain.ErrExerciseChapterMismatch):
 		return http.StatusBadRequest, err.Error()
 
+	case errors.Is(err, therapydomain.ErrExerciseLocked):
+		return http.StatusConflict, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrClientNotLinked):
+		return http.StatusNotFound, err.Error()
+
+	case errors.Is(err, therapistdomain.ErrScopeNotGranted):
+		return http.StatusForbidden, err.Error()
+
 	default:
 		return http.StatusInternalServerError, "internal server error"
 	}
@@ -23,66 +23,237 @@ func NewPostgresTherapyRepo(db *pgxpool.Pool) *PostgresTherapyRepo {
 	return &PostgresTherapyRepo{db: db}
 }
 
-func (r *PostgresTherapyRepo) UpsertExerciseProgress(ctx context.Context, p *therapydomain.ExerciseProgress) error {
-	query := `
-		INSERT INTO exercise_progress (id, user_id, chapter_id, exercise_id, completed, rating, remarks, completed_at)
+func (r *PostgresTherapyRepo) CreateExerciseAttempt(ctx context.Context, p *therapydomain.ExerciseProgress, review *therapydomain.Review) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	attemptQuery := `
+		INSERT INTO exercise_attempts (id, user_id, chapter_id, exercise_id, completed, rating, remarks, completed_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (user_id, exercise_id) DO UPDATE
-		    SET chapter_id   = EXCLUDED.chapter_id,
-		        rating       = EXCLUDED.rating,
-		        remarks      = EXCLUDED.remarks,
-		        completed_at = EXCLUDED.completed_at
 	`
-	_, err := r.db.Exec(ctx, query,
+	_, err = tx.Exec(ctx, attemptQuery,
 		p.ID(), p.UserID(), p.ChapterID(), p.ExerciseID(),
 		p.Completed(), p.Rating(), p.Remarks(), p.CompletedAt(),
 	)
 	if err != nil {
-		return fmt.Errorf("upsert exercise progress: %w", err)
+		return fmt.Errorf("insert exercise attempt: %w", err)
+	}
+
+	reviewQuery := `
+		INSERT INTO exercise_reviews (
+			user_id, exercise_id, chapter_id, repetitions, interval_days,
+			ease_factor, last_rating, last_reviewed_at, due_on
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, exercise_id) DO UPDATE
+		SET chapter_id       = EXCLUDED.chapter_id,
+		    repetitions      = EXCLUDED.repetitions,
+		    interval_days    = EXCLUDED.interval_days,
+		    ease_factor      = EXCLUDED.ease_factor,
+		    last_rating      = EXCLUDED.last_rating,
+		    last_reviewed_at = EXCLUDED.last_reviewed_at,
+		    due_on           = EXCLUDED.due_on
+	`
+	_, err = tx.Exec(ctx, reviewQuery,
+		p.UserID(), review.ExerciseID, review.ChapterID, review.Repetitions, review.IntervalDays,
+		review.EaseFactor, review.LastRating, review.LastReviewedAt, review.DueOn,
+	)
+	if err != nil {
+		return fmt.Errorf("upsert exercise review: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
 	}
 	return nil
 }
 
-func (r *PostgresTherapyRepo) GetProgressByUserID(ctx context.Context, userID uuid.UUID) ([]*therapydomain.ExerciseProgress, error) {
+func (r *PostgresTherapyRepo) GetProgressByUserID(ctx context.Context, userID uuid.UUID) ([]*therapydomain.ExerciseSummary, error) {
 	query := `
-		SELECT id, user_id, chapter_id, exercise_id, completed, rating, remarks, completed_at
-		FROM exercise_progress
-		WHERE user_id = $1
-		ORDER BY completed_at ASC
+		SELECT p.id, p.user_id, p.chapter_id, p.exercise_id, p.completed, p.rating, p.remarks, p.completed_at,
+		       t.attempts, t.first_rating, t.best_rating, t.avg_rating
+		FROM   exercise_progress p
+		JOIN  (SELECT exercise_id,
+		              COUNT(*)::int                                  AS attempts,
+		              (ARRAY_AGG(rating ORDER BY completed_at, id))[1] AS first_rating,
+		              MAX(rating)                                    AS best_rating,
+		              ROUND(AVG(rating), 1)::float8                  AS avg_rating
+		       FROM   exercise_attempts
+		       WHERE  user_id = $1
+		       GROUP  BY exercise_id) t ON t.exercise_id = p.exercise_id
+		WHERE  p.user_id = $1
+		ORDER  BY p.completed_at ASC
 	`
 	rows, err := r.db.Query(ctx, query, userID)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return []*therapydomain.ExerciseProgress{}, nil
-		}
 		return nil, fmt.Errorf("query exercise progress: %w", err)
 	}
 	defer rows.Close()
 
-	var results []*therapydomain.ExerciseProgress
+	results := make([]*therapydomain.ExerciseSummary, 0)
 	for rows.Next() {
-		var (
-			id          uuid.UUID
-			dbUserID    uuid.UUID
-			chapterID   string
-			exerciseID  string
-			completed   bool
-			rating      int
-			remarks     string
-			completedAt time.Time
-		)
-		if err := rows.Scan(
-			&id, &dbUserID, &chapterID, &exerciseID,
-			&completed, &rating, &remarks, &completedAt,
-		); err != nil {
+		var trend therapydomain.RatingTrend
+		latest, err := scanExerciseProgress(rows, &trend.Attempts, &trend.FirstRating, &trend.BestRating, &trend.AvgRating)
+		if err != nil {
 			return nil, fmt.Errorf("scan exercise progress row: %w", err)
 		}
-		results = append(results, therapydomain.ReconstitueExerciseProgress(
-			id, dbUserID, chapterID, exerciseID, completed, rating, remarks, completedAt,
-		))
+		trend.LatestRating = latest.Rating()
+		results = append(results, &therapydomain.ExerciseSummary{Latest: latest, Trend: trend})
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate exercise progress rows: %w", err)
 	}
 	return results, nil
 }
+
+func (r *PostgresTherapyRepo) GetProgressVersion(ctx context.Context, userID uuid.UUID) (therapydomain.ProgressVersion, error) {
+	query := `
+		SELECT COUNT(*)::int, MAX(completed_at)
+		FROM   exercise_attempts
+		WHERE  user_id = $1
+	`
+
+	var version therapydomain.ProgressVersion
+	if err := r.db.QueryRow(ctx, query, userID).Scan(&version.Attempts, &version.LastCompletedAt); err != nil {
+		return therapydomain.ProgressVersion{}, fmt.Errorf("get progress version: %w", err)
+	}
+	return version, nil
+}
+
+func (r *PostgresTherapyRepo) GetCompletedExerciseIDs(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT DISTINCT exercise_id
+		FROM   exercise_attempts
+		WHERE  user_id = $1
+	`
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query completed exercises: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan completed exercise: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate completed exercises: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *PostgresTherapyRepo) GetAttempts(ctx context.Context, userID uuid.UUID, exerciseID string) ([]*therapydomain.ExerciseProgress, error) {
+	query := `
+		SELECT id, user_id, chapter_id, exercise_id, completed, rating, remarks, completed_at
+		FROM   exercise_attempts
+		WHERE  user_id = $1 AND exercise_id = $2
+		ORDER  BY completed_at ASC, id ASC
+	`
+	rows, err := r.db.Query(ctx, query, userID, exerciseID)
+	if err != nil {
+		return nil, fmt.Errorf("query exercise attempts: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*therapydomain.ExerciseProgress, 0)
+	for rows.Next() {
+		attempt, err := scanExerciseProgress(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan exercise attempt row: %w", err)
+		}
+		results = append(results, attempt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate exercise attempt rows: %w", err)
+	}
+	return results, nil
+}
+
+func scanExerciseProgress(row pgx.Row, extra ...any) (*therapydomain.ExerciseProgress, error) {
+	var (
+		id          uuid.UUID
+		userID      uuid.UUID
+		chapterID   string
+		exerciseID  string
+		completed   bool
+		rating      int
+		remarks     string
+		completedAt time.Time
+	)
+	dest := append([]any{
+		&id, &userID, &chapterID, &exerciseID,
+		&completed, &rating, &remarks, &completedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return therapydomain.ReconstitueExerciseProgress(
+		id, userID, chapterID, exerciseID, completed, rating, remarks, completedAt,
+	), nil
+}
+
+const reviewColumns = `
+	chapter_id, exercise_id, repetitions, interval_days, ease_factor,
+	last_rating, last_reviewed_at, due_on
+`
+
+func (r *PostgresTherapyRepo) GetReview(ctx context.Context, userID uuid.UUID, exerciseID string) (*therapydomain.Review, error) {
+	query := `SELECT ` + reviewColumns + `
+		FROM   exercise_reviews
+		WHERE  user_id = $1 AND exercise_id = $2
+	`
+	review, err := scanReview(r.db.QueryRow(ctx, query, userID, exerciseID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, therapydomain.ErrReviewNotFound
+		}
+		return nil, fmt.Errorf("get review: %w", err)
+	}
+	return review, nil
+}
+
+func (r *PostgresTherapyRepo) ListDueReviews(ctx context.Context, userID uuid.UUID, onOrBefore time.Time) ([]*therapydomain.Review, error) {
+	query := `SELECT ` + reviewColumns + `
+		FROM   exercise_reviews
+		WHERE  user_id = $1 AND due_on <= $2
+		ORDER  BY due_on, last_rating, exercise_id
+	`
+	rows, err := r.db.Query(ctx, query, userID, onOrBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query due reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews := make([]*therapydomain.Review, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan review: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate due reviews: %w", err)
+	}
+	return reviews, nil
+}
+
+func scanReview(row pgx.Row) (*therapydomain.Review, error) {
+	var review therapydomain.Review
+	err := row.Scan(
+		&review.ChapterID, &review.ExerciseID, &review.Repetitions, &review.IntervalDays,
+		&review.EaseFactor, &review.LastRating, &review.LastReviewedAt, &review.DueOn,
+	)
+	if err != nil {
+		return nil, err
+	}
+	review.DueOn = review.DueOn.UTC()
+	return &review, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -9,7 +10,26 @@ import (
 )
 
 type TherapyRepository interface {
-	UpsertExerciseProgress(ctx context.Context, progress *therapydomain.ExerciseProgress) error
+	// CreateExerciseAttempt stores the attempt and the exercise's updated
+	// review schedule in one transaction.
+	CreateExerciseAttempt(ctx context.Context, attempt *therapydomain.ExerciseProgress, review *therapydomain.Review) error
 
-	GetProgressByUserID(ctx context.Context, userID uuid.UUID) ([]*therapydomain.ExerciseProgress, error)
+	// GetProgressByUserID returns the latest attempt at each exercise the
+	// user has completed, with the rating trend across all attempts.
+	GetProgressByUserID(ctx context.Context, userID uuid.UUID) ([]*therapydomain.ExerciseSummary, error)
+
+	// GetProgressVersion is a cheap check for whether the progress changed,
+	// used for conditional requests.
+	GetProgressVersion(ctx context.Context, userID uuid.UUID) (therapydomain.ProgressVersion, error)
+
+	GetCompletedExerciseIDs(ctx context.Context, userID uuid.UUID) ([]string, error)
+
+	// GetAttempts returns every attempt at one exercise, oldest first.
+	GetAttempts(ctx context.Context, userID uuid.UUID, exerciseID string) ([]*therapydomain.ExerciseProgress, error)
+
+	GetReview(ctx context.Context, userID uuid.UUID, exerciseID string) (*therapydomain.Review, error)
+
+	// ListDueReviews returns reviews due on or before the given date,
+	// most overdue first.
+	ListDueReviews(ctx context.Context, userID uuid.UUID, onOrBefore time.Time) ([]*therapydomain.Review, error)
 }
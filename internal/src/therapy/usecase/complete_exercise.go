@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -17,29 +18,54 @@ func (uc *TherapyUseCase) CompleteExercise(
 	chapterID, exerciseID string,
 	rating int,
 	remarks string,
-) (*therapydomain.ExerciseProgress, error) {
+) (*therapydomain.ExerciseProgress, *therapydomain.Review, error) {
 
 	chapterID = strings.TrimSpace(chapterID)
 	exerciseID = strings.TrimSpace(exerciseID)
 	remarks = strings.TrimSpace(remarks)
 
 	if chapterID == "" {
-		return nil, therapydomain.ErrInvalidChapterID
+		return nil, nil, therapydomain.ErrInvalidChapterID
 	}
 	if exerciseID == "" {
-		return nil, therapydomain.ErrInvalidExerciseID
+		return nil, nil, therapydomain.ErrInvalidExerciseID
 	}
 	if rating < 1 || rating > 5 {
-		return nil, therapydomain.ErrInvalidRating
+		return nil, nil, therapydomain.ErrInvalidRating
+	}
+	if _, err := uc.catalog.ValidateExercise(chapterID, exerciseID); err != nil {
+		return nil, nil, err
 	}
 
+	completedIDs, err := uc.therapyRepo.GetCompletedExerciseIDs(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get completed exercises: %w", err)
+	}
+	if !uc.catalog.IsUnlocked(exerciseID, toSet(completedIDs)) {
+		return nil, nil, therapydomain.ErrExerciseLocked
+	}
+
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	previous, err := uc.therapyRepo.GetReview(ctx, userID, exerciseID)
+	if err != nil && !errors.Is(err, therapydomain.ErrReviewNotFound) {
+		return nil, nil, fmt.Errorf("get review: %w", err)
+	}
+
+	now := time.Now().UTC()
 	progress := therapydomain.NewExerciseProgress(
-		userID, chapterID, exerciseID, rating, remarks, time.Now().UTC(),
+		userID, chapterID, exerciseID, rating, remarks, now,
+	)
+	review := therapydomain.ScheduleReview(
+		previous, chapterID, exerciseID, rating, now, localDate(now, loc),
 	)
 
-	if err := uc.therapyRepo.UpsertExerciseProgress(ctx, progress); err != nil {
-		return nil, fmt.Errorf("complete exercise: %w", err)
+	if err := uc.therapyRepo.CreateExerciseAttempt(ctx, progress, review); err != nil {
+		return nil, nil, fmt.Errorf("complete exercise: %w", err)
 	}
 
-	return progress, nil
+	return progress, review, nil
 }
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
+)
+
+// GetDueReviews returns the exercises due for review today in the user's
+// timezone, including overdue ones. Exercises retired from the catalog are
+// left out since they can no longer be completed.
+func (uc *TherapyUseCase) GetDueReviews(ctx context.Context, userID uuid.UUID) (*therapydomain.ReviewQueue, error) {
+	loc, err := uc.userLocation(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := localDate(time.Now(), loc)
+
+	reviews, err := uc.therapyRepo.ListDueReviews(ctx, userID, today)
+	if err != nil {
+		return nil, fmt.Errorf("list due reviews: %w", err)
+	}
+
+	due := make([]*therapydomain.Review, 0, len(reviews))
+	for _, review := range reviews {
+		if _, ok := uc.catalog.Exercise(review.ExerciseID); ok {
+			due = append(due, review)
+		}
+	}
+	return &therapydomain.ReviewQueue{Date: today, Reviews: due}, nil
+}
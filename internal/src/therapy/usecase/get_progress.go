@@ -3,16 +3,64 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 
 	therapydomain "saythis-backend/internal/src/therapy/domain"
 )
 
-func (uc *TherapyUseCase) GetProgress(ctx context.Context, userID uuid.UUID) ([]*therapydomain.ExerciseProgress, error) {
-	progress, err := uc.therapyRepo.GetProgressByUserID(ctx, userID)
+func (uc *TherapyUseCase) GetProgress(ctx context.Context, userID uuid.UUID) (*therapydomain.ProgressOverview, error) {
+	exercises, err := uc.therapyRepo.GetProgressByUserID(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get progress: %w", err)
 	}
-	return progress, nil
+
+	completed := make(map[string]bool, len(exercises))
+	for _, e := range exercises {
+		completed[e.Latest.ExerciseID()] = true
+	}
+
+	return &therapydomain.ProgressOverview{
+		Exercises: exercises,
+		Course:    uc.catalog.Progress(completed),
+	}, nil
+}
+
+func (uc *TherapyUseCase) GetAttempts(ctx context.Context, userID uuid.UUID, exerciseID string) ([]*therapydomain.ExerciseProgress, therapydomain.RatingTrend, error) {
+	exerciseID = strings.TrimSpace(exerciseID)
+	if exerciseID == "" {
+		return nil, therapydomain.RatingTrend{}, therapydomain.ErrInvalidExerciseID
+	}
+
+	attempts, err := uc.therapyRepo.GetAttempts(ctx, userID, exerciseID)
+	if err != nil {
+		return nil, therapydomain.RatingTrend{}, fmt.Errorf("get attempts: %w", err)
+	}
+	return attempts, therapydomain.NewRatingTrend(attempts), nil
+}
+
+func toSet(ids []string) map[string]bool {
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// ProgressVersion returns a key that changes whenever GetProgress would
+// answer differently, without loading the progress. The catalog version is
+// part of it since the course summary depends on the curriculum.
+func (uc *TherapyUseCase) ProgressVersion(ctx context.Context, userID uuid.UUID) (string, error) {
+	version, err := uc.therapyRepo.GetProgressVersion(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("get progress version: %w", err)
+	}
+
+	lastCompleted := ""
+	if version.LastCompletedAt != nil {
+		lastCompleted = strconv.FormatInt(version.LastCompletedAt.UnixMicro(), 10)
+	}
+	return fmt.Sprintf("progress|%s|%d|%s", uc.catalog.Version, version.Attempts, lastCompleted), nil
 }
@@ -1,13 +1,44 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	therapydomain "saythis-backend/internal/src/therapy/domain"
 	therapyrepo "saythis-backend/internal/src/therapy/repository"
+	userrepo "saythis-backend/internal/src/user/repository"
 )
 
 type TherapyUseCase struct {
 	therapyRepo therapyrepo.TherapyRepository
+	userRepo    userrepo.UserRepository
+	catalog     *therapydomain.Catalog
+}
+
+func NewTherapyUseCase(
+	therapyRepo therapyrepo.TherapyRepository,
+	userRepo userrepo.UserRepository,
+	catalog *therapydomain.Catalog,
+) *TherapyUseCase {
+	return &TherapyUseCase{therapyRepo: therapyRepo, userRepo: userRepo, catalog: catalog}
+}
+
+func (uc *TherapyUseCase) Catalog() *therapydomain.Catalog {
+	return uc.catalog
+}
+
+func (uc *TherapyUseCase) userLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get user timezone: %w", err)
+	}
+	return user.Location(), nil
 }
 
-func NewTherapyUseCase(therapyRepo therapyrepo.TherapyRepository) *TherapyUseCase {
-	return &TherapyUseCase{therapyRepo: therapyRepo}
+func localDate(value time.Time, loc *time.Location) time.Time {
+	year, month, day := value.In(loc).Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
@@ -9,6 +9,8 @@ var (
 	ErrInvalidRole           = errors.New("invalid user role")
 	ErrInvalidStatus         = errors.New("invalid user status")
 	ErrInvalidFullNameLength = errors.New("full name must be between 3 and 100 characters")
+	ErrInvalidTimezone       = errors.New("timezone must be a valid IANA time zone name")
+	ErrNothingToUpdate       = errors.New("no profile fields to update")
 
 	ErrDuplicateEmail = errors.New("email already in use")
 	ErrUserNotFound   = errors.New("user not found")
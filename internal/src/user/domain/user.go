@@ -13,6 +13,9 @@ var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-
 const (
 	minFullNameLength = 3
 	maxFullNameLength = 100
+	maxTimezoneLength = 64
+
+	DefaultTimezone = "UTC"
 )
 
 type User struct {
@@ -20,6 +23,7 @@ type User struct {
 	email           string
 	fullName        string
 	avatarURL       string
+	timezone        string
 	role            UserRole
 	status          UserStatus
 	emailVerifiedAt *time.Time
@@ -52,6 +56,7 @@ func NewUser(email string, fullName string, role UserRole, timeNow time.Time) (*
 		id:        uuid.New(),
 		email:     email,
 		fullName:  fullName,
+		timezone:  DefaultTimezone,
 		role:      role,
 		status:    StatusPending,
 		createdAt: timeNow,
@@ -61,7 +66,7 @@ func NewUser(email string, fullName string, role UserRole, timeNow time.Time) (*
 
 func ReconstitueUser(
 	id uuid.UUID,
-	email, fullName, avatarURL string,
+	email, fullName, avatarURL, timezone string,
 	role UserRole,
 	status UserStatus,
 	emailVerifiedAt *time.Time,
@@ -72,6 +77,7 @@ func ReconstitueUser(
 		email:           email,
 		fullName:        fullName,
 		avatarURL:       avatarURL,
+		timezone:        timezone,
 		role:            role,
 		status:          status,
 		emailVerifiedAt: emailVerifiedAt,
@@ -88,12 +94,21 @@ func (u *User) ID() uuid.UUID               { return u.id }
 func (u *User) Email() string               { return u.email }
 func (u *User) FullName() string            { return u.fullName }
 func (u *User) AvatarURL() string           { return u.avatarURL }
+func (u *User) Timezone() string            { return u.timezone }
 func (u *User) Role() UserRole              { return u.role }
 func (u *User) Status() UserStatus          { return u.status }
 func (u *User) CreatedAt() time.Time        { return u.createdAt }
 func (u *User) UpdatedAt() time.Time        { return u.updatedAt }
 func (u *User) EmailVerifiedAt() *time.Time { return u.emailVerifiedAt }
 
+func (u *User) Location() *time.Location {
+	loc, err := time.LoadLocation(u.timezone)
+	if err != nil || u.timezone == "" {
+		return time.UTC
+	}
+	return loc
+}
+
 // *******
 // Setters
 // *******
@@ -115,3 +130,14 @@ func ValidateFullName(fullName string) error {
 	}
 	return nil
 }
+
+func ValidateTimezone(timezone string) error {
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" || timezone == "Local" || len(timezone) > maxTimezoneLength {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
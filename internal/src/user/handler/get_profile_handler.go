@@ -40,6 +40,7 @@ func (h *GetProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Email:           user.Email(),
 			FullName:        user.FullName(),
 			AvatarURL:       user.AvatarURL(),
+			Timezone:        user.Timezone(),
 			Role:            user.Role(),
 			Status:          user.Status(),
 			EmailVerifiedAt: user.EmailVerifiedAt(),
@@ -70,6 +70,7 @@ func (h *UpdateAvatarHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			Email:           user.Email(),
 			FullName:        user.FullName(),
 			AvatarURL:       user.AvatarURL(),
+			Timezone:        user.Timezone(),
 			Role:            user.Role(),
 			Status:          user.Status(),
 			EmailVerifiedAt: user.EmailVerifiedAt(),
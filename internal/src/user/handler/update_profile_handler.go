@@ -21,7 +21,8 @@ func NewUpdateProfileHandler(uc *usecase.UserUseCase) *UpdateProfileHandler {
 }
 
 type updateProfileRequest struct {
-	FullName string `json:"full_name"`
+	FullName *string `json:"full_name"`
+	Timezone *string `json:"timezone"`
 }
 
 type updateProfileResponse struct {
@@ -33,6 +34,7 @@ type userPayload struct {
 	Email           string                `json:"email"`
 	FullName        string                `json:"full_name"`
 	AvatarURL       string                `json:"avatar_url"`
+	Timezone        string                `json:"timezone"`
 	Role            userdomain.UserRole   `json:"role"`
 	Status          userdomain.UserStatus `json:"status"`
 	EmailVerifiedAt *time.Time            `json:"email_verified_at"`
@@ -57,7 +59,7 @@ func (h *UpdateProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	user, err := h.usecase.UpdateProfile(r.Context(), claims.UserID, req.FullName)
+	user, err := h.usecase.UpdateProfile(r.Context(), claims.UserID, req.FullName, req.Timezone)
 	if err != nil {
 		status, msg := mapUserError(err)
 		helper.Error(w, status, msg)
@@ -70,6 +72,7 @@ func (h *UpdateProfileHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 			Email:           user.Email(),
 			FullName:        user.FullName(),
 			AvatarURL:       user.AvatarURL(),
+			Timezone:        user.Timezone(),
 			Role:            user.Role(),
 			Status:          user.Status(),
 			EmailVerifiedAt: user.EmailVerifiedAt(),
@@ -11,7 +11,9 @@ func mapUserError(err error) (int, string) {
 	switch {
 
 	case errors.Is(err, userdomain.ErrEmptyFullName),
-		errors.Is(err, userdomain.ErrInvalidFullNameLength):
+		errors.Is(err, userdomain.ErrInvalidFullNameLength),
+		errors.Is(err, userdomain.ErrInvalidTimezone),
+		errors.Is(err, userdomain.ErrNothingToUpdate):
 		return http.StatusBadRequest, err.Error()
 
 	case errors.Is(err, userdomain.ErrUserNotFound):
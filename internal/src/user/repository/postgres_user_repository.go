@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	statsrepo "saythis-backend/internal/src/stats/repository"
 	"saythis-backend/internal/src/user/domain"
 )
 
@@ -27,13 +28,13 @@ func NewPostgresUserRepo(db *pgxpool.Pool) *PostgresUserRepo {
 
 func (r *PostgresUserRepo) Create(ctx context.Context, user *domain.User) error {
 	query := `
-		INSERT INTO users (id, email, full_name, role, status, email_verified_at, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO users (id, email, full_name, timezone, role, status, email_verified_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING created_at, updated_at
 	`
 	var createdAt, updatedAt time.Time
 	err := r.db.QueryRow(ctx, query,
-		user.ID(), user.Email(), user.FullName(), user.Role(),
+		user.ID(), user.Email(), user.FullName(), user.Timezone(), user.Role(),
 		user.Status(), user.EmailVerifiedAt(), user.CreatedAt(), user.UpdatedAt(),
 	).Scan(&createdAt, &updatedAt)
 	if err != nil {
@@ -51,7 +52,7 @@ func (r *PostgresUserRepo) Create(ctx context.Context, user *domain.User) error
 
 func (r *PostgresUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	query := `
-		SELECT id, email, full_name, COALESCE(avatar_url, ''), role, status,
+		SELECT id, email, full_name, COALESCE(avatar_url, ''), timezone, role, status,
 		       email_verified_at, created_at, updated_at
 		FROM users
 		WHERE id = $1
@@ -61,6 +62,7 @@ func (r *PostgresUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 		email           string
 		fullName        string
 		avatarURL       string
+		timezone        string
 		role            domain.UserRole
 		status          domain.UserStatus
 		emailVerifiedAt *time.Time
@@ -68,7 +70,7 @@ func (r *PostgresUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 		updatedAt       time.Time
 	)
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&dbID, &email, &fullName, &avatarURL,
+		&dbID, &email, &fullName, &avatarURL, &timezone,
 		&role, &status, &emailVerifiedAt,
 		&createdAt, &updatedAt,
 	)
@@ -78,7 +80,7 @@ func (r *PostgresUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 		}
 		return nil, fmt.Errorf("get user by id: %w", err)
 	}
-	return domain.ReconstitueUser(dbID, email, fullName, avatarURL, role, status, emailVerifiedAt, createdAt, updatedAt), nil
+	return domain.ReconstitueUser(dbID, email, fullName, avatarURL, timezone, role, status, emailVerifiedAt, createdAt, updatedAt), nil
 }
 
 func (r *PostgresUserRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
@@ -98,14 +100,35 @@ func (r *PostgresUserRepo) SoftDelete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func (r *PostgresUserRepo) UpdateFullName(ctx context.Context, id uuid.UUID, fullName string, updatedAt time.Time) (*domain.User, error) {
+// UpdateProfile rebuilds the user's activity rollups and streaks in the
+// same transaction when the timezone changes, since their civil dates are
+// taken in it.
+func (r *PostgresUserRepo) UpdateProfile(ctx context.Context, id uuid.UUID, fullName, timezone *string, updatedAt time.Time) (*domain.User, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var previousTimezone string
+	err = tx.QueryRow(ctx, `
+		SELECT timezone FROM users WHERE id = $1 AND status = 'active' FOR NO KEY UPDATE
+	`, id).Scan(&previousTimezone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("lock user: %w", err)
+	}
+
 	query := `
 		UPDATE users
-		SET    full_name  = $2,
-		       updated_at = $3
+		SET    full_name  = COALESCE($2, full_name),
+		       timezone   = COALESCE($3, timezone),
+		       updated_at = $4
 		WHERE  id = $1
 		  AND  status    = 'active'
-		RETURNING id, email, full_name, COALESCE(avatar_url, ''),
+		RETURNING id, email, full_name, COALESCE(avatar_url, ''), timezone,
 		          role, status, email_verified_at, created_at, updated_at
 	`
 	var (
@@ -113,14 +136,15 @@ func (r *PostgresUserRepo) UpdateFullName(ctx context.Context, id uuid.UUID, ful
 		email           string
 		dbFullName      string
 		avatarURL       string
+		dbTimezone      string
 		role            domain.UserRole
 		status          domain.UserStatus
 		emailVerifiedAt *time.Time
 		createdAt       time.Time
 		dbUpdatedAt     time.Time
 	)
-	err := r.db.QueryRow(ctx, query, id, fullName, updatedAt).Scan(
-		&dbID, &email, &dbFullName, &avatarURL,
+	err = tx.QueryRow(ctx, query, id, fullName, timezone, updatedAt).Scan(
+		&dbID, &email, &dbFullName, &avatarURL, &dbTimezone,
 		&role, &status, &emailVerifiedAt,
 		&createdAt, &dbUpdatedAt,
 	)
@@ -128,9 +152,19 @@ func (r *PostgresUserRepo) UpdateFullName(ctx context.Context, id uuid.UUID, ful
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrUserNotFound
 		}
-		return nil, fmt.Errorf("update full name: %w", err)
+		return nil, fmt.Errorf("update profile: %w", err)
+	}
+
+	if dbTimezone != previousTimezone {
+		if err := statsrepo.RebuildActivity(ctx, tx, []uuid.UUID{id}); err != nil {
+			return nil, fmt.Errorf("rebuild activity for new timezone: %w", err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
 	}
-	return domain.ReconstitueUser(dbID, email, dbFullName, avatarURL, role, status, emailVerifiedAt, createdAt, dbUpdatedAt), nil
+	return domain.ReconstitueUser(dbID, email, dbFullName, avatarURL, dbTimezone, role, status, emailVerifiedAt, createdAt, dbUpdatedAt), nil
 }
 
 func (r *PostgresUserRepo) UpdateAvatarURL(ctx context.Context, id uuid.UUID, avatarURL string, updatedAt time.Time) (*domain.User, error) {
@@ -140,7 +174,7 @@ func (r *PostgresUserRepo) UpdateAvatarURL(ctx context.Context, id uuid.UUID, av
 		       updated_at = $3
 		WHERE  id     = $1
 		  AND  status = 'active'
-		RETURNING id, email, full_name, COALESCE(avatar_url, ''),
+		RETURNING id, email, full_name, COALESCE(avatar_url, ''), timezone,
 		          role, status, email_verified_at, created_at, updated_at
 	`
 	var (
@@ -148,6 +182,7 @@ func (r *PostgresUserRepo) UpdateAvatarURL(ctx context.Context, id uuid.UUID, av
 		email           string
 		fullName        string
 		dbAvatarURL     string
+		timezone        string
 		role            domain.UserRole
 		status          domain.UserStatus
 		emailVerifiedAt *time.Time
@@ -155,7 +190,7 @@ func (r *PostgresUserRepo) UpdateAvatarURL(ctx context.Context, id uuid.UUID, av
 		dbUpdatedAt     time.Time
 	)
 	err := r.db.QueryRow(ctx, query, id, avatarURL, updatedAt).Scan(
-		&dbID, &email, &fullName, &dbAvatarURL,
+		&dbID, &email, &fullName, &dbAvatarURL, &timezone,
 		&role, &status, &emailVerifiedAt,
 		&createdAt, &dbUpdatedAt,
 	)
@@ -165,12 +200,12 @@ func (r *PostgresUserRepo) UpdateAvatarURL(ctx context.Context, id uuid.UUID, av
 		}
 		return nil, fmt.Errorf("update avatar url: %w", err)
 	}
-	return domain.ReconstitueUser(dbID, email, fullName, dbAvatarURL, role, status, emailVerifiedAt, createdAt, dbUpdatedAt), nil
+	return domain.ReconstitueUser(dbID, email, fullName, dbAvatarURL, timezone, role, status, emailVerifiedAt, createdAt, dbUpdatedAt), nil
 }
 
 func (r *PostgresUserRepo) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	query := `
-		SELECT id, email, full_name, COALESCE(avatar_url, ''), role, status,
+		SELECT id, email, full_name, COALESCE(avatar_url, ''), timezone, role, status,
 		       email_verified_at, created_at, updated_at
 		FROM users
 		WHERE email = $1
@@ -180,6 +215,7 @@ func (r *PostgresUserRepo) GetByEmail(ctx context.Context, email string) (*domai
 		dbEmail         string
 		fullName        string
 		avatarURL       string
+		timezone        string
 		role            domain.UserRole
 		status          domain.UserStatus
 		emailVerifiedAt *time.Time
@@ -187,7 +223,7 @@ func (r *PostgresUserRepo) GetByEmail(ctx context.Context, email string) (*domai
 		updatedAt       time.Time
 	)
 	err := r.db.QueryRow(ctx, query, email).Scan(
-		&dbID, &dbEmail, &fullName, &avatarURL,
+		&dbID, &dbEmail, &fullName, &avatarURL, &timezone,
 		&role, &status, &emailVerifiedAt,
 		&createdAt, &updatedAt,
 	)
@@ -197,5 +233,5 @@ func (r *PostgresUserRepo) GetByEmail(ctx context.Context, email string) (*domai
 		}
 		return nil, fmt.Errorf("get user by email: %w", err)
 	}
-	return domain.ReconstitueUser(dbID, dbEmail, fullName, avatarURL, role, status, emailVerifiedAt, createdAt, updatedAt), nil
+	return domain.ReconstitueUser(dbID, dbEmail, fullName, avatarURL, timezone, role, status, emailVerifiedAt, createdAt, updatedAt), nil
 }
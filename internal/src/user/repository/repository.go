@@ -18,7 +18,7 @@ type UserRepository interface {
 
 	SoftDelete(ctx context.Context, id uuid.UUID) error
 
-	UpdateFullName(ctx context.Context, id uuid.UUID, fullName string, updatedAt time.Time) (*domain.User, error)
+	UpdateProfile(ctx context.Context, id uuid.UUID, fullName, timezone *string, updatedAt time.Time) (*domain.User, error)
 
 	UpdateAvatarURL(ctx context.Context, id uuid.UUID, avatarURL string, updatedAt time.Time) (*domain.User, error)
 }
@@ -21,6 +21,13 @@ func (uc *UserUseCase) DeleteAccount(ctx context.Context, userID uuid.UUID) erro
 		)
 	}
 
+	if err := uc.revocations.RevokeUser(ctx, userID); err != nil {
+		slog.Error("delete_account: failed to revoke access tokens",
+			"user_id", userID,
+			"error", err,
+		)
+	}
+
 	slog.Info("user account deleted", "user_id", userID)
 	return nil
 }
@@ -11,15 +11,30 @@ import (
 	"saythis-backend/internal/src/user/domain"
 )
 
-func (uc *UserUseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, fullName string) (*domain.User, error) {
+func (uc *UserUseCase) UpdateProfile(ctx context.Context, userID uuid.UUID, fullName, timezone *string) (*domain.User, error) {
 
-	fullName = strings.TrimSpace(fullName)
-	if err := domain.ValidateFullName(fullName); err != nil {
-		return nil, err
+	if fullName == nil && timezone == nil {
+		return nil, domain.ErrNothingToUpdate
+	}
+
+	if fullName != nil {
+		trimmed := strings.TrimSpace(*fullName)
+		if err := domain.ValidateFullName(trimmed); err != nil {
+			return nil, err
+		}
+		fullName = &trimmed
+	}
+
+	if timezone != nil {
+		trimmed := strings.TrimSpace(*timezone)
+		if err := domain.ValidateTimezone(trimmed); err != nil {
+			return nil, err
+		}
+		timezone = &trimmed
 	}
 
 	updatedAt := time.Now().UTC()
-	user, err := uc.userRepo.UpdateFullName(ctx, userID, fullName, updatedAt)
+	user, err := uc.userRepo.UpdateProfile(ctx, userID, fullName, timezone, updatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("update profile: %w", err)
 	}
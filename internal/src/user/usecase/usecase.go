@@ -1,24 +1,28 @@
 package usecase
 
 import (
+	"saythis-backend/internal/src/auth"
 	authrepo "saythis-backend/internal/src/auth/repository"
 	userrepo "saythis-backend/internal/src/user/repository"
 )
 
 type UserUseCase struct {
-	userRepo userrepo.UserRepository
-	authRepo authrepo.AuthRepository
-	uploader ImageUploader
+	userRepo    userrepo.UserRepository
+	authRepo    authrepo.AuthRepository
+	revocations *auth.RevocationList
+	uploader    ImageUploader
 }
 
 func NewUserUseCase(
 	userRepo userrepo.UserRepository,
 	authRepo authrepo.AuthRepository,
+	revocations *auth.RevocationList,
 	uploader ImageUploader,
 ) *UserUseCase {
 	return &UserUseCase{
-		userRepo: userRepo,
-		authRepo: authRepo,
-		uploader: uploader,
+		userRepo:    userRepo,
+		authRepo:    authRepo,
+		revocations: revocations,
+		uploader:    uploader,
 	}
 }
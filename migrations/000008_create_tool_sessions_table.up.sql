@@ -0,0 +1,28 @@
+CREATE TABLE tool_sessions (
+    id               UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id          UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tool_type        VARCHAR(50)  NOT NULL,
+    started_at       TIMESTAMPTZ  NOT NULL,
+    duration_seconds INTEGER      NOT NULL,
+    self_rating      INTEGER,
+    metadata         JSONB        NOT NULL DEFAULT '{}'::jsonb,
+    created_at       TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT tool_sessions_tool_type_check
+        CHECK (tool_type IN (
+            'DAF', 'FAF',
+            'BOX_BREATHING', 'DIAPHRAGMATIC', 'PRE_SPEECH',
+            'GENTLE_ONSET', 'PROLONGED_SPEECH',
+            'STUTTER_TAP_COUNTER', 'TIMED_READING_WPM',
+            'VIRTUAL_COFFEE_ORDER', 'PHONE_CALL_SIMULATOR'
+        )),
+    CONSTRAINT tool_sessions_duration_seconds_check
+        CHECK (duration_seconds > 0 AND duration_seconds <= 14400),
+    CONSTRAINT tool_sessions_self_rating_check
+        CHECK (self_rating IS NULL OR (self_rating >= 1 AND self_rating <= 5)),
+    CONSTRAINT tool_sessions_metadata_object_check
+        CHECK (jsonb_typeof(metadata) = 'object')
+);
+
+CREATE INDEX idx_tool_sessions_user_started ON tool_sessions (user_id, started_at DESC);
+CREATE INDEX idx_tool_sessions_user_tool_started ON tool_sessions (user_id, tool_type, started_at DESC);
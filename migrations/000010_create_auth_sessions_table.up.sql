@@ -0,0 +1,26 @@
+CREATE TABLE auth_sessions (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    device_name  VARCHAR(100) NOT NULL DEFAULT '',
+    user_agent   TEXT         NOT NULL DEFAULT '',
+    ip_address   VARCHAR(45)  NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_auth_sessions_user_id ON auth_sessions (user_id);
+
+ALTER TABLE refresh_tokens
+    ADD COLUMN session_id UUID REFERENCES auth_sessions(id) ON DELETE CASCADE;
+
+INSERT INTO auth_sessions (id, user_id, created_at, last_used_at)
+SELECT id, user_id, created_at, created_at
+FROM refresh_tokens;
+
+UPDATE refresh_tokens
+SET session_id = id;
+
+ALTER TABLE refresh_tokens
+    ALTER COLUMN session_id SET NOT NULL;
+
+CREATE INDEX idx_refresh_tokens_session_id ON refresh_tokens (session_id);
@@ -0,0 +1,13 @@
+CREATE TABLE revoked_access_tokens (
+    jti        UUID        PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_access_tokens_expires_at ON revoked_access_tokens (expires_at);
+
+CREATE TABLE user_access_revocations (
+    user_id    UUID        PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    revoked_at TIMESTAMPTZ NOT NULL
+);
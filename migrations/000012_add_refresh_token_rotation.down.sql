@@ -0,0 +1,5 @@
+DELETE FROM refresh_tokens
+WHERE rotated_at IS NOT NULL;
+
+ALTER TABLE refresh_tokens
+    DROP COLUMN IF EXISTS rotated_at;
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_mfa_recovery_codes_user_id;
+
+DROP TABLE IF EXISTS mfa_recovery_codes;
+
+ALTER TABLE auth_credentials
+    DROP COLUMN IF EXISTS mfa_last_used_step,
+    DROP COLUMN IF EXISTS mfa_enabled_at,
+    DROP COLUMN IF EXISTS mfa_secret;
@@ -0,0 +1,14 @@
+ALTER TABLE auth_credentials
+    ADD COLUMN mfa_secret         TEXT,
+    ADD COLUMN mfa_enabled_at     TIMESTAMPTZ,
+    ADD COLUMN mfa_last_used_step BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE mfa_recovery_codes (
+    id         UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  TEXT        NOT NULL UNIQUE,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_mfa_recovery_codes_user_id ON mfa_recovery_codes (user_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_audit_log_target_created;
+DROP INDEX IF EXISTS idx_audit_log_actor_created;
+
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,13 @@
+CREATE TABLE audit_log (
+    id          UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    actor_id    UUID         REFERENCES users(id) ON DELETE SET NULL,
+    action      VARCHAR(100) NOT NULL,
+    target_type VARCHAR(50)  NOT NULL DEFAULT '',
+    target_id   UUID,
+    metadata    JSONB        NOT NULL DEFAULT '{}'::jsonb,
+    ip_address  VARCHAR(45)  NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_actor_created ON audit_log (actor_id, created_at DESC);
+CREATE INDEX idx_audit_log_target_created ON audit_log (target_type, target_id, created_at DESC);
@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN suspended_at      TIMESTAMPTZ,
+    ADD COLUMN suspension_reason TEXT;
+
+CREATE INDEX idx_users_status_created ON users (status, created_at DESC);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_therapist_clients_client_id;
+DROP INDEX IF EXISTS idx_therapist_clients_active_pair;
+
+DROP TABLE IF EXISTS therapist_clients;
+
+DROP INDEX IF EXISTS idx_therapist_link_codes_therapist_id;
+
+DROP TABLE IF EXISTS therapist_link_codes;
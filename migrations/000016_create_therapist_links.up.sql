@@ -0,0 +1,32 @@
+CREATE TABLE therapist_link_codes (
+    code_hash    TEXT        PRIMARY KEY,
+    therapist_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at   TIMESTAMPTZ NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_therapist_link_codes_therapist_id ON therapist_link_codes (therapist_id);
+
+CREATE TABLE therapist_clients (
+    id           UUID        PRIMARY KEY DEFAULT gen_random_uuid(),
+    therapist_id UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    scopes       TEXT[]      NOT NULL DEFAULT '{}',
+    status       VARCHAR(20) NOT NULL DEFAULT 'active',
+    linked_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT therapist_clients_status_check
+        CHECK (status IN ('active', 'revoked')),
+    CONSTRAINT therapist_clients_scopes_check
+        CHECK (scopes <@ ARRAY['therapy_progress', 'daily_stats', 'journal', 'transcripts']::TEXT[]),
+    CONSTRAINT therapist_clients_not_self
+        CHECK (therapist_id <> client_id)
+);
+
+CREATE UNIQUE INDEX idx_therapist_clients_active_pair
+    ON therapist_clients (therapist_id, client_id)
+    WHERE status = 'active';
+
+CREATE INDEX idx_therapist_clients_client_id ON therapist_clients (client_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS practice_plan_items;
+
+DROP INDEX IF EXISTS idx_practice_plans_client_id;
+DROP INDEX IF EXISTS idx_practice_plans_active_pair;
+
+DROP TABLE IF EXISTS practice_plans;
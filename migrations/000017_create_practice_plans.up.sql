@@ -0,0 +1,46 @@
+CREATE TABLE practice_plans (
+    id           UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    therapist_id UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    client_id    UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title        VARCHAR(120) NOT NULL,
+    notes        TEXT         NOT NULL DEFAULT '',
+    starts_on    DATE         NOT NULL,
+    status       VARCHAR(20)  NOT NULL DEFAULT 'active',
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT practice_plans_status_check
+        CHECK (status IN ('active', 'archived')),
+    CONSTRAINT practice_plans_notes_length_check
+        CHECK (char_length(notes) <= 2000)
+);
+
+CREATE UNIQUE INDEX idx_practice_plans_active_pair
+    ON practice_plans (therapist_id, client_id)
+    WHERE status = 'active';
+
+CREATE INDEX idx_practice_plans_client_id ON practice_plans (client_id) WHERE status = 'active';
+
+CREATE TABLE practice_plan_items (
+    id               UUID         PRIMARY KEY DEFAULT gen_random_uuid(),
+    plan_id          UUID         NOT NULL REFERENCES practice_plans(id) ON DELETE CASCADE,
+    position         SMALLINT     NOT NULL,
+    kind             VARCHAR(20)  NOT NULL,
+    chapter_id       VARCHAR(50),
+    exercise_id      VARCHAR(50),
+    tool_type        VARCHAR(50),
+    times_per_week   SMALLINT     NOT NULL,
+    minutes_per_week SMALLINT,
+    due_date         DATE,
+
+    CONSTRAINT practice_plan_items_kind_check
+        CHECK (
+            (kind = 'exercise' AND chapter_id IS NOT NULL AND exercise_id IS NOT NULL AND tool_type IS NULL AND minutes_per_week IS NULL)
+         OR (kind = 'tool' AND tool_type IS NOT NULL AND chapter_id IS NULL AND exercise_id IS NULL)
+        ),
+    CONSTRAINT practice_plan_items_times_check
+        CHECK (times_per_week >= 1 AND times_per_week <= 14),
+    CONSTRAINT practice_plan_items_minutes_check
+        CHECK (minutes_per_week IS NULL OR (minutes_per_week >= 1 AND minutes_per_week <= 1000)),
+    CONSTRAINT uq_practice_plan_items_position UNIQUE (plan_id, position)
+);
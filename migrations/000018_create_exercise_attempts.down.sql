@@ -0,0 +1,16 @@
+DROP VIEW IF EXISTS exercise_progress;
+
+DROP INDEX IF EXISTS idx_exercise_attempts_user_completed;
+DROP INDEX IF EXISTS idx_exercise_attempts_user_exercise_completed;
+
+-- Keep only the latest attempt per exercise so the unique constraint holds.
+DELETE FROM exercise_attempts a
+USING  exercise_attempts b
+WHERE  a.user_id     = b.user_id
+  AND  a.exercise_id = b.exercise_id
+  AND  (a.completed_at, a.id) < (b.completed_at, b.id);
+
+ALTER INDEX idx_exercise_attempts_user_id RENAME TO idx_exercise_progress_user_id;
+ALTER INDEX exercise_attempts_pkey RENAME TO exercise_progress_pkey;
+ALTER TABLE exercise_attempts RENAME TO exercise_progress;
+ALTER TABLE exercise_progress ADD CONSTRAINT uq_user_exercise UNIQUE (user_id, exercise_id);
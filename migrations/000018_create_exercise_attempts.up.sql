@@ -0,0 +1,18 @@
+-- Every completion is now kept as an attempt. The old table already holds
+-- one attempt per exercise, so it is renamed rather than copied.
+ALTER TABLE exercise_progress RENAME TO exercise_attempts;
+ALTER TABLE exercise_attempts DROP CONSTRAINT uq_user_exercise;
+ALTER INDEX exercise_progress_pkey RENAME TO exercise_attempts_pkey;
+ALTER INDEX idx_exercise_progress_user_id RENAME TO idx_exercise_attempts_user_id;
+
+CREATE INDEX idx_exercise_attempts_user_exercise_completed
+    ON exercise_attempts (user_id, exercise_id, completed_at DESC);
+CREATE INDEX idx_exercise_attempts_user_completed
+    ON exercise_attempts (user_id, completed_at DESC);
+
+-- exercise_progress keeps its old shape: the latest attempt per exercise.
+CREATE VIEW exercise_progress AS
+SELECT DISTINCT ON (user_id, exercise_id)
+       id, user_id, chapter_id, exercise_id, completed, rating, remarks, completed_at
+FROM   exercise_attempts
+ORDER  BY user_id, exercise_id, completed_at DESC, id DESC;
@@ -0,0 +1,28 @@
+CREATE TABLE exercise_reviews (
+    user_id          UUID             NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    exercise_id      VARCHAR(50)      NOT NULL,
+    chapter_id       VARCHAR(50)      NOT NULL,
+    repetitions      INT              NOT NULL DEFAULT 0 CHECK (repetitions >= 0),
+    interval_days    INT              NOT NULL CHECK (interval_days >= 1),
+    ease_factor      DOUBLE PRECISION NOT NULL CHECK (ease_factor >= 1.3),
+    last_rating      INT              NOT NULL CHECK (last_rating >= 1 AND last_rating <= 5),
+    last_reviewed_at TIMESTAMPTZ      NOT NULL,
+    due_on           DATE             NOT NULL,
+
+    PRIMARY KEY (user_id, exercise_id)
+);
+
+CREATE INDEX idx_exercise_reviews_user_due ON exercise_reviews (user_id, due_on);
+
+-- Existing progress has no review history, so every exercise starts as if
+-- it had been reviewed once and comes due the day after its latest attempt.
+INSERT INTO exercise_reviews (
+    user_id, exercise_id, chapter_id, repetitions, interval_days,
+    ease_factor, last_rating, last_reviewed_at, due_on
+)
+SELECT p.user_id, p.exercise_id, p.chapter_id,
+       CASE WHEN p.rating >= 3 THEN 1 ELSE 0 END, 1,
+       2.5, p.rating, p.completed_at,
+       (p.completed_at AT TIME ZONE u.timezone)::date + 1
+FROM   exercise_progress p
+JOIN   users u ON u.id = p.user_id;
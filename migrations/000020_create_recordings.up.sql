@@ -0,0 +1,20 @@
+CREATE TABLE recordings (
+    id              UUID         PRIMARY KEY,
+    user_id         UUID         NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    daily_stat_id   UUID         REFERENCES user_daily_stats(id) ON DELETE SET NULL,
+    tool_session_id UUID         REFERENCES tool_sessions(id) ON DELETE SET NULL,
+    format          VARCHAR(10)  NOT NULL,
+    size_bytes      BIGINT       NOT NULL,
+    duration_ms     INTEGER      NOT NULL,
+    storage_key     TEXT         NOT NULL UNIQUE,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT recordings_format_check CHECK (format IN ('wav', 'opus')),
+    CONSTRAINT recordings_size_check CHECK (size_bytes > 0),
+    CONSTRAINT recordings_duration_check CHECK (duration_ms > 0),
+    CONSTRAINT recordings_single_link_check CHECK (num_nonnulls(daily_stat_id, tool_session_id) <= 1)
+);
+
+CREATE INDEX idx_recordings_user_created ON recordings (user_id, created_at DESC);
+CREATE INDEX idx_recordings_daily_stat ON recordings (daily_stat_id) WHERE daily_stat_id IS NOT NULL;
+CREATE INDEX idx_recordings_tool_session ON recordings (tool_session_id) WHERE tool_session_id IS NOT NULL;
@@ -0,0 +1,11 @@
+ALTER TABLE user_daily_stats
+    DROP COLUMN IF EXISTS longest_speech_seconds,
+    DROP COLUMN IF EXISTS longest_pause_seconds,
+    DROP COLUMN IF EXISTS mean_pause_seconds,
+    DROP COLUMN IF EXISTS long_pause_count,
+    DROP COLUMN IF EXISTS medium_pause_count,
+    DROP COLUMN IF EXISTS short_pause_count,
+    DROP COLUMN IF EXISTS pause_count,
+    DROP COLUMN IF EXISTS speech_seconds,
+    DROP COLUMN IF EXISTS audio_duration_seconds,
+    DROP COLUMN IF EXISTS speech_recording_id;
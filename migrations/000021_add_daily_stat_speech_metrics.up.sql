@@ -0,0 +1,13 @@
+-- Objective speech metrics from the latest analyzed recording linked to
+-- the day. Ratios and articulation rate are derived on read.
+ALTER TABLE user_daily_stats
+    ADD COLUMN speech_recording_id    UUID REFERENCES recordings(id) ON DELETE SET NULL,
+    ADD COLUMN audio_duration_seconds NUMERIC(6,2),
+    ADD COLUMN speech_seconds         NUMERIC(6,2),
+    ADD COLUMN pause_count            INTEGER,
+    ADD COLUMN short_pause_count      INTEGER,
+    ADD COLUMN medium_pause_count     INTEGER,
+    ADD COLUMN long_pause_count       INTEGER,
+    ADD COLUMN mean_pause_seconds     NUMERIC(6,2),
+    ADD COLUMN longest_pause_seconds  NUMERIC(6,2),
+    ADD COLUMN longest_speech_seconds NUMERIC(6,2);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS user_activity_streaks;
+DROP TABLE IF EXISTS user_activity_rollups;
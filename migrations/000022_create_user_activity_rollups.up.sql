@@ -0,0 +1,76 @@
+-- Per-day tool activity for each tool group, dated in the user's timezone.
+-- Kept in step with tool_sessions by the API, which also rebuilds a user's
+-- rows when their timezone changes; rebuild with cmd/rebuild-rollups after
+-- a group changes.
+CREATE TABLE user_activity_rollups (
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tool_group VARCHAR(20) NOT NULL,
+    date       DATE        NOT NULL,
+    sessions   INTEGER     NOT NULL,
+    seconds    INTEGER     NOT NULL,
+
+    PRIMARY KEY (user_id, tool_group, date),
+    CONSTRAINT user_activity_rollups_sessions_check CHECK (sessions > 0),
+    CONSTRAINT user_activity_rollups_seconds_check CHECK (seconds >= 0)
+);
+
+-- The latest run of consecutive days of each tool group and of journaling.
+-- The run is only current while it reaches today, which is checked on read.
+CREATE TABLE user_activity_streaks (
+    user_id       UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    streak        VARCHAR(20) NOT NULL,
+    current_start DATE        NOT NULL,
+    current_end   DATE        NOT NULL,
+    best_days     INTEGER     NOT NULL,
+    active_days   INTEGER     NOT NULL,
+
+    PRIMARY KEY (user_id, streak),
+    CONSTRAINT user_activity_streaks_run_check CHECK (current_start <= current_end)
+);
+
+-- The groups mirror statsdomain.ToolGroups at the time of writing.
+INSERT INTO user_activity_rollups (user_id, tool_group, date, sessions, seconds)
+SELECT s.user_id, g.name, (s.started_at AT TIME ZONE u.timezone)::date,
+       COUNT(*), SUM(s.duration_seconds)
+FROM   tool_sessions s
+JOIN   users u ON u.id = s.user_id
+JOIN   (
+    SELECT 'combined', tool_type FROM (SELECT DISTINCT tool_type FROM tool_sessions) t
+    UNION ALL VALUES
+        ('daf',         'DAF'),
+        ('faf',         'FAF'),
+        ('breathing',   'BOX_BREATHING'),
+        ('breathing',   'DIAPHRAGMATIC'),
+        ('breathing',   'PRE_SPEECH'),
+        ('drills',      'GENTLE_ONSET'),
+        ('drills',      'PROLONGED_SPEECH'),
+        ('biofeedback', 'STUTTER_TAP_COUNTER'),
+        ('biofeedback', 'TIMED_READING_WPM'),
+        ('simulation',  'VIRTUAL_COFFEE_ORDER'),
+        ('simulation',  'PHONE_CALL_SIMULATOR')
+) AS g(name, tool_type) ON g.tool_type = s.tool_type
+GROUP  BY 1, 2, 3;
+
+WITH days AS (
+    SELECT user_id, tool_group AS streak, date FROM user_activity_rollups
+    UNION ALL
+    SELECT user_id, 'journal', date FROM user_daily_stats WHERE journal_entry IS NOT NULL
+),
+islands AS (
+    SELECT user_id, streak, date,
+           date - ROW_NUMBER() OVER (PARTITION BY user_id, streak ORDER BY date)::int AS anchor
+    FROM   days
+),
+runs AS (
+    SELECT user_id, streak, MIN(date) AS run_start, MAX(date) AS run_end, COUNT(*) AS length
+    FROM   islands
+    GROUP  BY user_id, streak, anchor
+)
+INSERT INTO user_activity_streaks (user_id, streak, current_start, current_end, best_days, active_days)
+SELECT DISTINCT ON (user_id, streak)
+       user_id, streak, run_start, run_end,
+       (MAX(length) OVER w)::int,
+       (SUM(length) OVER w)::int
+FROM   runs
+WINDOW w AS (PARTITION BY user_id, streak)
+ORDER  BY user_id, streak, run_end DESC;
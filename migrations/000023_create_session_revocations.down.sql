@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_revoked_sessions_expires_at;
+
+DROP TABLE IF EXISTS revoked_sessions;
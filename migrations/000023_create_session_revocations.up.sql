@@ -0,0 +1,11 @@
+-- Access tokens carry their session ID, so signing a session out denies
+-- every access token issued to it. Rows are only needed until the last of
+-- those tokens expires.
+CREATE TABLE revoked_sessions (
+    session_id UUID        PRIMARY KEY,
+    user_id    UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_revoked_sessions_expires_at ON revoked_sessions (expires_at);
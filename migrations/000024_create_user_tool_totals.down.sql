@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_tool_labels;
+DROP TABLE IF EXISTS user_tool_totals;
+
+-- Tool types are upper case, tool groups lower case.
+DELETE FROM user_activity_rollups WHERE tool_group <> lower(tool_group);
+ALTER TABLE user_activity_rollups ALTER COLUMN tool_group TYPE VARCHAR(20);
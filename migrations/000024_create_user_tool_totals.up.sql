@@ -0,0 +1,96 @@
+-- Rating and headline metric totals of each tool type, kept in step with
+-- tool_sessions by the API like the rollups. Session counts and minutes
+-- come from the rollups, which now also hold a row per tool type.
+CREATE TABLE user_tool_totals (
+    user_id         UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tool_type       VARCHAR(50) NOT NULL,
+    rating_sum      FLOAT8      NOT NULL,
+    rating_count    INTEGER     NOT NULL,
+    metric_sum      FLOAT8      NOT NULL,
+    metric_count    INTEGER     NOT NULL,
+    last_started_at TIMESTAMPTZ NOT NULL,
+
+    PRIMARY KEY (user_id, tool_type)
+);
+
+-- How many sessions of each tool type carried each free-text label.
+CREATE TABLE user_tool_labels (
+    user_id   UUID        NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tool_type VARCHAR(50) NOT NULL,
+    label     TEXT        NOT NULL,
+    sessions  INTEGER     NOT NULL,
+
+    PRIMARY KEY (user_id, tool_type, label),
+    CONSTRAINT user_tool_labels_sessions_check CHECK (sessions > 0)
+);
+
+ALTER TABLE user_activity_rollups ALTER COLUMN tool_group TYPE VARCHAR(50);
+
+INSERT INTO user_activity_rollups (user_id, tool_group, date, sessions, seconds)
+SELECT s.user_id, s.tool_type, (s.started_at AT TIME ZONE u.timezone)::date,
+       COUNT(*), SUM(s.duration_seconds)
+FROM   tool_sessions s
+JOIN   users u ON u.id = s.user_id
+GROUP  BY 1, 2, 3;
+
+-- The metrics and labels mirror statsdomain.ToolMetrics and
+-- statsdomain.ToolLabelKeys at the time of writing.
+WITH sessions AS (
+    SELECT s.user_id, s.tool_type, s.started_at, s.duration_seconds, s.self_rating,
+           m.kind,
+           s.metadata -> m.key AS value
+    FROM   tool_sessions s
+    LEFT   JOIN (VALUES
+        ('DAF',                  'delay_ms',        'value'),
+        ('FAF',                  'pitch_semitones', 'value'),
+        ('GENTLE_ONSET',         'average_score',   'value'),
+        ('PROLONGED_SPEECH',     'estimated_wpm',   'value'),
+        ('STUTTER_TAP_COUNTER',  'total_taps',      'per_minute'),
+        ('TIMED_READING_WPM',    'actual_wpm',      'value'),
+        ('VIRTUAL_COFFEE_ORDER', 'completed',       'completion'),
+        ('PHONE_CALL_SIMULATOR', 'completed',       'completion')
+    ) AS m(tool_type, key, kind) ON m.tool_type = s.tool_type
+),
+numbers AS (
+    SELECT user_id, tool_type, started_at, duration_seconds, self_rating, kind, value,
+           CASE jsonb_typeof(value)
+               WHEN 'number' THEN (value #>> '{}')::float8
+               WHEN 'string' THEN CASE WHEN pg_input_is_valid(value #>> '{}', 'float8') THEN (value #>> '{}')::float8 END
+           END AS number,
+           CASE jsonb_typeof(value)
+               WHEN 'boolean' THEN (value #>> '{}')::boolean
+               WHEN 'string'  THEN CASE lower(btrim(value #>> '{}', E' \t\n\r\f\v')) WHEN 'true' THEN true WHEN 'false' THEN false END
+           END AS flag
+    FROM   sessions
+),
+metrics AS (
+    SELECT user_id, tool_type, started_at, self_rating,
+           CASE kind
+               WHEN 'value'      THEN number
+               WHEN 'per_minute' THEN CASE WHEN duration_seconds > 0 THEN number / (duration_seconds::float8 / 60) END
+               WHEN 'completion' THEN CASE flag WHEN true THEN 100::float8 WHEN false THEN 0::float8 END
+           END AS metric
+    FROM   numbers
+)
+INSERT INTO user_tool_totals (user_id, tool_type, rating_sum, rating_count, metric_sum, metric_count, last_started_at)
+SELECT user_id, tool_type,
+       COALESCE(SUM(self_rating), 0), COUNT(self_rating),
+       COALESCE(SUM(metric), 0),      COUNT(metric),
+       MAX(started_at)
+FROM   metrics
+GROUP  BY user_id, tool_type;
+
+INSERT INTO user_tool_labels (user_id, tool_type, label, sessions)
+SELECT user_id, tool_type, label, COUNT(*)
+FROM (
+    SELECT s.user_id, s.tool_type,
+           NULLIF(btrim(s.metadata ->> m.key, E' \t\n\r\f\v'), '') AS label
+    FROM   tool_sessions s
+    JOIN   (VALUES
+        ('FAF',        'pitch_direction'),
+        ('PRE_SPEECH', 'situation')
+    ) AS m(tool_type, key) ON m.tool_type = s.tool_type
+    WHERE  jsonb_typeof(s.metadata -> m.key) = 'string'
+) l
+WHERE  label IS NOT NULL
+GROUP  BY user_id, tool_type, label;
@@ -0,0 +1,7 @@
+-- Counts the writes behind each user's stats overview, rollups included,
+-- for conditional requests. Bumped in the writing transaction, so the
+-- count follows commit order where updated_at timestamps do not.
+CREATE TABLE user_stats_versions (
+    user_id UUID   PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    writes  BIGINT NOT NULL
+);